@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/andiq123/sharpify/pkg/sharpify"
+)
+
+func Explain(name string, out io.Writer) error {
+	registry, userCfg, plugins, err := loadRegistry(".", os.Stderr)
+	defer plugins.Close()
+	if err != nil {
+		return err
+	}
+	rule, ok := registry.Get(name)
+	if !ok {
+		return fmt.Errorf("unknown rule %q (see --list-rules)", name)
+	}
+
+	info := sharpify.InfoOf(rule)
+	if s, ok := userCfg.Severity[info.Name]; ok {
+		info.Severity = s
+	}
+	var presets []string
+	for _, p := range registry.Presets() {
+		selected, err := registry.ResolvePreset(p.Name)
+		if err != nil {
+			continue
+		}
+		for _, r := range selected {
+			if r.Name() == info.Name {
+				presets = append(presets, p.Name)
+				break
+			}
+		}
+	}
+	doc, _ := sharpify.DocumentationOf(rule)
+	return writeExplanation(out, info, doc, presets)
+}
+
+func writeExplanation(out io.Writer, info sharpify.RuleInfo, doc sharpify.Documentation, presets []string) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n  %s\n\n", info.Name, info.Description)
+
+	safety := "safe"
+	if !info.Safe {
+		safety = "needs review"
+	}
+	fmt.Fprintf(&b, "  Requires: %s\n", versionRequirement(info.MinVersion))
+	fmt.Fprintf(&b, "  Safety:   %s\n", safety)
+	fmt.Fprintf(&b, "  Severity: %s\n", info.Severity)
+	if len(presets) > 0 {
+		fmt.Fprintf(&b, "  Presets:  %s\n", strings.Join(presets, ", "))
+	}
+
+	if doc.Rationale != "" {
+		fmt.Fprintf(&b, "\nWhy:\n  %s\n", doc.Rationale)
+	}
+	for i, ex := range doc.Examples {
+		title := "Example"
+		if len(doc.Examples) > 1 {
+			title = fmt.Sprintf("Example %d", i+1)
+		}
+		fmt.Fprintf(&b, "\n%s:\n  Before:\n%s  After:\n%s", title, indent(ex.Before), indent(ex.After))
+	}
+	if len(doc.Caveats) > 0 {
+		b.WriteString("\nCaveats:\n")
+		for _, c := range doc.Caveats {
+			fmt.Fprintf(&b, "  - %s\n", c)
+		}
+	}
+	if len(doc.Related) > 0 {
+		fmt.Fprintf(&b, "\nRelated: %s\n", strings.Join(doc.Related, ", "))
+	}
+
+	_, err := io.WriteString(out, b.String())
+	return err
+}
+
+func indent(code string) string {
+	var b strings.Builder
+	for _, line := range strings.Split(strings.TrimRight(code, "\n"), "\n") {
+		if line == "" {
+			b.WriteString("\n")
+			continue
+		}
+		b.WriteString("    " + strings.ReplaceAll(line, "\t", "    ") + "\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	var out bytes.Buffer
+	if err := Explain("throw-helper", &out); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"Requires: C# 10.0 (.NET 6.0+)",
+		"Safety:   safe",
+		"Why:",
+		"ArgumentNullException.ThrowIfNull(",
+		"Caveats:",
+		"Related: throw-expression",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output is missing %q\n%s", want, out.String())
+		}
+	}
+
+	if err := Explain("no-such-rule", &out); err == nil || !strings.Contains(err.Error(), "--list-rules") {
+		t.Errorf("err = %v, want unknown rule error", err)
+	}
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/andiq123/sharpify/internal/config"
+	"github.com/andiq123/sharpify/pkg/sharpify"
+)
+
+const ExitChanged = 2
+
+func Fix(cfg Config, in io.Reader, out, diagnostics io.Writer) (bool, error) {
+	if cfg.Path == "" {
+		return false, fmt.Errorf("--stdin-filename is required with --stdin")
+	}
+	source, err := io.ReadAll(in)
+	if err != nil {
+		return false, fmt.Errorf("failed to read stdin: %w", err)
+	}
+	path, err := filepath.Abs(cfg.Path)
+	if err != nil {
+		return false, fmt.Errorf("invalid path: %w", err)
+	}
+
+	registry, userCfg, plugins, err := loadRegistry(path, diagnostics)
+	defer plugins.Close()
+	if err != nil {
+		return false, err
+	}
+	opts, err := options(registry, cfg, userCfg)
+	if err != nil {
+		return false, err
+	}
+	opts.Filename = path
+	if v, ok := sharpify.ProjectVersion(path); ok {
+		opts.Version = v
+	}
+
+	result, err := sharpify.Transform(context.Background(), string(source), opts)
+	if err != nil {
+		return false, err
+	}
+
+	content := result.Content
+	if result.Changed {
+		editorConfig, err := config.EditorConfig(path)
+		if err != nil {
+			fmt.Fprintf(diagnostics, "Warning: %v\n", err)
+		}
+		content = lineEndings(content, editorConfig["end_of_line"])
+	}
+	if _, err := io.WriteString(out, content); err != nil {
+		return false, err
+	}
+
+	for _, rule := range result.Applied {
+		fmt.Fprintf(diagnostics, "%s: %s (%s)\n", cfg.Path, rule.Description, rule.Name)
+	}
+	for _, d := range result.Diagnostics {
+		if d.Kind == sharpify.DiagnosticFailure {
+			fmt.Fprintf(diagnostics, "%s: %s was rolled back: %s\n", cfg.Path, d.Rule, d.Message)
+			continue
+		}
+		fmt.Fprintf(diagnostics, "%s:%d: %s: %s (%s)\n", cfg.Path, d.Line, d.Severity, d.Message, d.Rule)
+	}
+	return result.Changed, nil
+}
+
+func lineEndings(content, style string) string {
+	switch style {
+	case "lf":
+		return strings.ReplaceAll(content, "\r\n", "\n")
+	case "crlf":
+		return strings.ReplaceAll(strings.ReplaceAll(content, "\r\n", "\n"), "\n", "\r\n")
+	}
+	return content
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFixStdin(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	root := t.TempDir()
+	files := map[string]string{
+		".editorconfig":  "root = true\n[*.cs]\nend_of_line = crlf\n",
+		"src/App.csproj": `<Project Sdk="Microsoft.NET.Sdk"><PropertyGroup><TargetFramework>net6.0</TargetFramework></PropertyGroup></Project>`,
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	filename := filepath.Join(root, "src", "B.cs")
+
+	source := "class B\r\n{\r\n    List<int> xs = new List<int>();\r\n    void M(string s) { throw new ArgumentNullException(\"s\"); }\r\n}\r\n"
+	var out, diagnostics bytes.Buffer
+	changed, err := Fix(Config{Path: filename}, strings.NewReader(source), &out, &diagnostics)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Fatal("expected a change")
+	}
+	if !strings.Contains(out.String(), "nameof(s)") {
+		t.Errorf("nameof-expression not applied:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), "new List<int>()") {
+		t.Errorf("collection-expression applied although the project targets C# 10:\n%s", out.String())
+	}
+	if strings.Count(out.String(), "\n") != strings.Count(out.String(), "\r\n") {
+		t.Errorf("output does not use CRLF line endings: %q", out.String())
+	}
+	if !strings.Contains(diagnostics.String(), "nameof-expression") {
+		t.Errorf("diagnostics = %q", diagnostics.String())
+	}
+
+	out.Reset()
+	changed, err = Fix(Config{Path: filename}, strings.NewReader("class C { }\n"), &out, &diagnostics)
+	if err != nil || changed || out.String() != "class C { }\n" {
+		t.Fatalf("unchanged input: changed=%v err=%v out=%q", changed, err, out.String())
+	}
+}
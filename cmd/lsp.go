@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/andiq123/sharpify/internal/lsp"
+)
+
+func ServeLSP(cfg Config, version string) error {
+	registry, userCfg, plugins, err := loadRegistry(".", os.Stderr)
+	defer plugins.Close()
+	if err != nil {
+		return err
+	}
+	opts, err := options(registry, cfg, userCfg)
+	if err != nil {
+		return err
+	}
+	return lsp.NewServer(opts, version).Serve(context.Background(), os.Stdin, os.Stdout)
+}
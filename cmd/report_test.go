@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andiq123/sharpify/internal/report"
+)
+
+func TestRunWritesStructuredReport(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	root := t.TempDir()
+	source := "class A\n{\n    void M(string s) { throw new ArgumentNullException(\"s\"); }\n}\n"
+	file := filepath.Join(root, "A.cs")
+	if err := os.WriteFile(file, []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	output := filepath.Join(t.TempDir(), "report.json")
+	if err := Run(Config{Path: root, DryRun: true, Format: "json", Output: output}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var r report.Report
+	if err := json.Unmarshal(data, &r); err != nil {
+		t.Fatal(err)
+	}
+	if r.Summary.Changed != 1 || len(r.Files) != 1 || r.Files[0].Path != "A.cs" || !r.DryRun {
+		t.Fatalf("report = %+v", r)
+	}
+	if content, _ := os.ReadFile(file); string(content) != source {
+		t.Error("--dry-run must not modify files")
+	}
+
+	html := filepath.Join(t.TempDir(), "report.html")
+	if err := Run(Config{Path: root, Format: "html", Output: html}); err != nil {
+		t.Fatal(err)
+	}
+	if content, _ := os.ReadFile(file); !strings.Contains(string(content), "nameof(s)") {
+		t.Errorf("changes were not applied:\n%s", content)
+	}
+
+	if err := Run(Config{Path: root, Format: "xml"}); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+	if err := Run(Config{Path: root, Format: "json", VerifyBuild: "true"}); err == nil {
+		t.Error("expected an error when combining --format with --verify-build")
+	}
+}
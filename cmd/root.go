@@ -1,13 +1,18 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 
-	"github.com/andiq123/sharpify/internal/scanner"
-	"github.com/andiq123/sharpify/internal/transformer"
+	"github.com/andiq123/sharpify/internal/backup"
+	"github.com/andiq123/sharpify/internal/config"
+	"github.com/andiq123/sharpify/internal/report"
+	"github.com/andiq123/sharpify/internal/verify"
+	"github.com/andiq123/sharpify/pkg/sharpify"
 )
 
 
@@ -15,12 +20,28 @@ type Config struct {
 	Path      string
 	DryRun    bool
 	Rules     []string
+	Preset    string
 	Verbose   bool
 	Recursive bool
+
+	VerifyBuild string
+	Format      string
+	Output      string
 }
 
 
 func Run(cfg Config) error {
+	if cfg.VerifyBuild != "" && cfg.DryRun {
+		return fmt.Errorf("--verify-build cannot be combined with --dry-run")
+	}
+	structured := cfg.Format != "" && cfg.Format != "text"
+	if structured && cfg.Format != "json" && cfg.Format != "html" {
+		return fmt.Errorf("unknown format %q (want text, json or html)", cfg.Format)
+	}
+	if structured && cfg.VerifyBuild != "" {
+		return fmt.Errorf("--verify-build cannot be combined with --format %s", cfg.Format)
+	}
+
 	
 	path, err := filepath.Abs(cfg.Path)
 	if err != nil {
@@ -34,73 +55,99 @@ func Run(cfg Config) error {
 	}
 
 	
-	s := scanner.New()
+	registry, userCfg, plugins, err := loadRegistry(path, os.Stdout)
+	defer plugins.Close()
+	if err != nil {
+		return err
+	}
 
-	
-	var files []scanner.FileInfo
-	if info.IsDir() {
-		files, err = s.Scan(path)
-	} else {
-		content, err := os.ReadFile(path)
-		if err != nil {
-			return fmt.Errorf("failed to read file: %w", err)
-		}
-		files = []scanner.FileInfo{{Path: path, Content: string(content)}}
+
+	opts, err := options(registry, cfg, userCfg)
+	if err != nil {
+		return err
 	}
+	selected, err := sharpify.SelectRules(opts)
 	if err != nil {
-		return fmt.Errorf("scan failed: %w", err)
+		return err
 	}
 
-	if len(files) == 0 {
-		fmt.Println("No C# files found")
-		return nil
+	
+	results, err := sharpify.TransformDir(context.Background(), path, opts)
+	if err != nil {
+		return err
 	}
 
-	fmt.Printf("Found %d C# file(s)\n", len(files))
-
-	
-	registry := transformer.NewRegistry()
+	if structured {
+		return writeReport(cfg, buildDir(path, info), results, selected, opts)
+	}
 
-	
-	var rules = registry.All()
-	if len(cfg.Rules) > 0 {
-		rules = registry.GetByNames(cfg.Rules)
+	scanned := 0
+	for _, r := range results {
+		if r.Scanned {
+			scanned++
+		}
+	}
+	if scanned == 0 {
+		fmt.Println("No C# files found")
+		return nil
 	}
 
+	fmt.Printf("Found %d C# file(s)\n", scanned)
+
 	if cfg.Verbose {
-		fmt.Printf("Using %d rule(s):\n", len(rules))
-		for _, r := range rules {
+		fmt.Printf("Using %d rule(s):\n", len(selected))
+		for _, r := range selected {
 			fmt.Printf("  - %s: %s\n", r.Name(), r.Description())
 		}
 		fmt.Println()
 	}
 
-	
-	t := transformer.New(rules)
-
-	
-	results := t.TransformAll(files)
+	var backupMgr *backup.Manager
+	if cfg.VerifyBuild != "" {
+		backupMgr = backup.New(buildDir(path, info))
+	}
 
 	
 	changedCount := 0
+	findingCount := 0
+	failureCount := 0
 	for _, result := range results {
-		if !result.Changed {
+		if !result.Changed && len(result.Diagnostics) == 0 {
 			continue
 		}
 
-		changedCount++
-		relPath, _ := filepath.Rel(path, result.File.Path)
+		relPath, _ := filepath.Rel(path, result.Path)
 		if relPath == "" || strings.HasPrefix(relPath, "..") {
-			relPath = result.File.Path
+			relPath = result.Path
 		}
 
 		fmt.Printf("\n%s:\n", relPath)
-		for _, rule := range result.AppliedRules {
+		for _, rule := range result.Applied {
 			fmt.Printf("  ✓ %s\n", rule.Description)
 		}
+		findings := result.Findings()
+		for _, f := range findings {
+			fmt.Printf("  • [%s] line %d: %s (%s)\n", f.Severity, f.Line, f.Message, f.Rule)
+		}
+		failures := result.Failures()
+		for _, f := range failures {
+			fmt.Printf("  ✗ %s was rolled back: %s\n", f.Rule, f.Message)
+		}
+		findingCount += len(findings)
+		failureCount += len(failures)
+
+		if !result.Changed {
+			continue
+		}
+		changedCount++
 
 		if !cfg.DryRun {
-			err := os.WriteFile(result.File.Path, []byte(result.NewContent), 0644)
+			if backupMgr != nil {
+				if err := backupOriginal(backupMgr, result); err != nil {
+					return err
+				}
+			}
+			err := os.WriteFile(result.Path, []byte(result.Content), 0644)
 			if err != nil {
 				fmt.Printf("  ✗ Failed to write: %v\n", err)
 				continue
@@ -110,10 +157,156 @@ func Run(cfg Config) error {
 	}
 
 	fmt.Printf("\n%d file(s) %s\n", changedCount, modeText(cfg.DryRun))
+	if findingCount > 0 {
+		fmt.Printf("%d suggestion(s) need manual review\n", findingCount)
+	}
+	if failureCount > 0 {
+		fmt.Printf("%d internal rule failure(s); affected edits were not applied\n", failureCount)
+	}
+
+	if backupMgr != nil && changedCount > 0 {
+		names := make([]string, len(selected))
+		for i, r := range selected {
+			names[i] = r.Name()
+		}
+		v := &verify.Verifier{
+			Command: cfg.VerifyBuild,
+			Dir:     buildDir(path, info),
+			Rules:   names,
+			Transform: func(ruleNames []string, excluded map[string][]string) ([]sharpify.Result, error) {
+				if len(ruleNames) == 0 {
+					return nil, nil
+				}
+				trial := opts
+				trial.Rules = ruleNames
+				trial.Exclude = excluded
+				return sharpify.TransformDir(context.Background(), path, trial)
+			},
+			Backup: backupMgr,
+		}
+		return reportVerification(v, results)
+	}
+
+	return nil
+}
+
+func writeReport(cfg Config, root string, results []sharpify.Result, selected []sharpify.Rule, opts sharpify.Options) error {
+	if !cfg.DryRun {
+		for _, result := range results {
+			if !result.Changed {
+				continue
+			}
+			if err := os.WriteFile(result.Path, []byte(result.Content), 0644); err != nil {
+				return fmt.Errorf("failed to write %s: %w", result.Path, err)
+			}
+		}
+	}
 
+	r := report.New(root, results, selected, opts.Severity, cfg.DryRun)
+	if cfg.Output == "" {
+		return report.Write(os.Stdout, r, cfg.Format)
+	}
+	f, err := os.Create(cfg.Output)
+	if err != nil {
+		return fmt.Errorf("failed to create report: %w", err)
+	}
+	if err := report.Write(f, r, cfg.Format); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func loadRegistry(path string, warnings io.Writer) (*sharpify.Registry, *config.Config, config.Plugins, error) {
+	registry := sharpify.NewRegistry()
+	userCfg := config.Load()
+	userCfg.RegisterPresets(registry)
+	if err := config.RegisterRules(registry, path); err != nil {
+		return nil, nil, nil, err
+	}
+	plugins, err := userCfg.StartPlugins(registry)
+	if err != nil {
+		fmt.Fprintf(warnings, "Warning: %v\n", err)
+	}
+	if err := userCfg.ConfigureRules(registry); err != nil {
+		return nil, nil, plugins, err
+	}
+	return registry, userCfg, plugins, nil
+}
+
+func buildDir(path string, info os.FileInfo) string {
+	if info.IsDir() {
+		return path
+	}
+	return filepath.Dir(path)
+}
+
+func backupOriginal(m *backup.Manager, result sharpify.Result) error {
+	if _, err := os.Stat(result.Path); os.IsNotExist(err) {
+		return m.BackupNew(result.Path)
+	}
+	return m.Backup(result.Path, result.Original)
+}
+
+func reportVerification(v *verify.Verifier, results []sharpify.Result) error {
+	fmt.Printf("\nVerifying build: %s\n", v.Command)
+	report, err := v.Verify(results)
+	if err != nil {
+		return fmt.Errorf("build verification failed: %w", err)
+	}
+
+	switch {
+	case report.BaselineFailed:
+		fmt.Println("  ✗ Build also fails without Sharpify's changes; keeping all changes")
+		printBuildOutput(report.Output)
+	case !report.Passed:
+		fmt.Println("  ✗ Could not isolate the failing rewrites; all changes were reverted")
+		printBuildOutput(report.Output)
+	case len(report.Reverted) == 0:
+		fmt.Println("  ✓ Build passed")
+	default:
+		fmt.Printf("  ✓ Build passed after reverting %d rewrite(s):\n", len(report.Reverted))
+		for _, r := range report.Reverted {
+			rel, err := filepath.Rel(v.Dir, r.Path)
+			if err != nil {
+				rel = r.Path
+			}
+			fmt.Printf("    ✗ %s in %s\n", r.Rule, rel)
+		}
+	}
+	fmt.Printf("  %d build(s) run, backup in %s\n", report.Builds, v.Backup.BackupDir())
 	return nil
 }
 
+func printBuildOutput(output string) {
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	if len(lines) > 20 {
+		lines = lines[len(lines)-20:]
+	}
+	for _, line := range lines {
+		fmt.Printf("    %s\n", line)
+	}
+}
+
+func options(registry *sharpify.Registry, cfg Config, userCfg *config.Config) (sharpify.Options, error) {
+	for _, name := range cfg.Rules {
+		if _, ok := registry.Get(name); !ok {
+			return sharpify.Options{}, fmt.Errorf("unknown rule %q (see --list-rules)", name)
+		}
+	}
+
+	preset := cfg.Preset
+	if preset == "" {
+		preset = userCfg.Preset
+	}
+	return sharpify.Options{
+		Registry: registry,
+		Rules:    cfg.Rules,
+		Preset:   preset,
+		Severity: userCfg.Severity,
+	}, nil
+}
+
 func modeText(dryRun bool) string {
 	if dryRun {
 		return "would be modified (dry-run)"
@@ -122,13 +315,53 @@ func modeText(dryRun bool) string {
 }
 
 
-func ListRules() {
-	registry := transformer.NewRegistry()
-	rules := registry.All()
+func ListRules(out io.Writer) error {
+	registry, _, plugins, err := loadRegistry(".", out)
+	defer plugins.Close()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, "Available transformation rules:")
+	fmt.Fprintln(out)
+	for _, r := range registry.Rules() {
+		fmt.Fprintf(out, "  %s\n    %s\n    %s\n\n", r.Name, r.Description, ruleRequirements(r))
+	}
+	fmt.Fprintln(out, "Run `sharpify explain <rule>` for examples and caveats.")
+	return nil
+}
 
-	fmt.Println("Available transformation rules:")
-	fmt.Println()
-	for _, r := range rules {
-		fmt.Printf("  %s\n    %s\n\n", r.Name(), r.Description())
+func ruleRequirements(r sharpify.RuleInfo) string {
+	requires := "Requires " + versionRequirement(r.MinVersion)
+	if !r.Safe {
+		requires += "; needs review"
 	}
+	return requires
+}
+
+func versionRequirement(v sharpify.CSharpVersion) string {
+	if v == 0 {
+		return "any C# version"
+	}
+	return fmt.Sprintf("%s (%s)", v, v.DotNetVersion())
+}
+
+
+func ListPresets(out io.Writer) error {
+	registry, _, plugins, err := loadRegistry(".", out)
+	defer plugins.Close()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out, "Available presets:")
+	fmt.Fprintln(out)
+	for _, p := range registry.Presets() {
+		selected, err := registry.ResolvePreset(p.Name)
+		if err != nil {
+			fmt.Fprintf(out, "  %s\n    %v\n\n", p.Name, err)
+			continue
+		}
+		fmt.Fprintf(out, "  %s (%d rules)\n    %s\n\n", p.Name, len(selected), p.Description)
+	}
+	return nil
 }
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestListingsSeeCustomRules(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	preset := `{"presets": {"team": {"description": "House style", "rules": ["legacy-log", "nameof-expression"]}}}`
+	if err := os.WriteFile(filepath.Join(home, ".sharpify.json"), []byte(preset), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".sharpify", "rules"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	rule := "name: legacy-log\nsafe: true\nmatch: LegacyLog.Write($msg:expr)\nreplace: _logger.LogInformation($msg)\n"
+	if err := os.WriteFile(filepath.Join(root, ".sharpify", "rules", "log.yaml"), []byte(rule), 0644); err != nil {
+		t.Fatal(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	var out bytes.Buffer
+	if err := ListPresets(&out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "team (2 rules)") {
+		t.Errorf("presets listing:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := ListRules(&out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "legacy-log") {
+		t.Errorf("rules listing:\n%s", out.String())
+	}
+}
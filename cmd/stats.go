@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/andiq123/sharpify/internal/scanner"
+	"github.com/andiq123/sharpify/internal/stats"
+	"github.com/andiq123/sharpify/pkg/sharpify"
+)
+
+func Stats(cfg Config, format string, record bool, out io.Writer) error {
+	report, err := collectStats(cfg)
+	if err != nil {
+		return err
+	}
+	if err := stats.Write(out, report, format); err != nil {
+		return err
+	}
+	if !record {
+		return nil
+	}
+	path := stats.HistoryPath(report.Root)
+	if err := stats.AppendHistory(path, stats.NewSnapshot(report, gitCommit(report.Root))); err != nil {
+		return fmt.Errorf("failed to record history: %w", err)
+	}
+	return nil
+}
+
+func Trend(cfg Config, limit int, out io.Writer) error {
+	root, err := statsRoot(cfg.Path)
+	if err != nil {
+		return err
+	}
+	history, err := stats.LoadHistory(stats.HistoryPath(root))
+	if err != nil {
+		return err
+	}
+	return stats.WriteTrend(out, history, limit)
+}
+
+func statsRoot(path string) (string, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("invalid path: %w", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("path not found: %w", err)
+	}
+	return buildDir(path, info), nil
+}
+
+func gitCommit(dir string) string {
+	c := exec.Command("git", "rev-parse", "--short", "HEAD")
+	c.Dir = dir
+	out, err := c.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func collectStats(cfg Config) (*stats.Report, error) {
+	path, err := filepath.Abs(cfg.Path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path: %w", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("path not found: %w", err)
+	}
+
+	registry, userCfg, plugins, err := loadRegistry(path, os.Stderr)
+	defer plugins.Close()
+	if err != nil {
+		return nil, err
+	}
+	opts, err := options(registry, cfg, userCfg)
+	if err != nil {
+		return nil, err
+	}
+	if len(opts.Rules) == 0 && cfg.Preset == "" {
+		for _, r := range registry.Rules() {
+			opts.Rules = append(opts.Rules, r.Name)
+		}
+	}
+	selected, err := sharpify.SelectRules(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []scanner.FileInfo
+	if info.IsDir() {
+		if files, err = scanner.New().Scan(path); err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+	} else {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		files = []scanner.FileInfo{{Path: path, Content: string(content)}}
+	}
+	return stats.Collect(buildDir(path, info), files, selected), nil
+}
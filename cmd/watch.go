@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/andiq123/sharpify/internal/watch"
+	"github.com/andiq123/sharpify/pkg/sharpify"
+)
+
+func Watch(cfg Config, apply bool) error {
+	path, err := filepath.Abs(cfg.Path)
+	if err != nil {
+		return fmt.Errorf("invalid path: %w", err)
+	}
+	registry, userCfg, plugins, err := loadRegistry(path, os.Stdout)
+	defer plugins.Close()
+	if err != nil {
+		return err
+	}
+	opts, err := options(registry, cfg, userCfg)
+	if err != nil {
+		return err
+	}
+	if apply {
+		if opts.Rules, err = safeRules(opts); err != nil {
+			return err
+		}
+		if len(opts.Rules) == 0 {
+			return fmt.Errorf("no safe rules selected")
+		}
+	}
+
+	versions := make(map[string]sharpify.CSharpVersion)
+	w := &watch.Watcher{
+		Root:  path,
+		Apply: apply,
+		Transform: func(ctx context.Context, file, content string) (sharpify.Result, error) {
+			fileOpts := opts
+			fileOpts.Filename = file
+			dir := filepath.Dir(file)
+			if _, ok := versions[dir]; !ok {
+				versions[dir], _ = sharpify.ProjectVersion(file)
+			}
+			fileOpts.Version = versions[dir]
+			return sharpify.Transform(ctx, content, fileOpts)
+		},
+		OnEvent: func(e watch.Event) {
+			printWatchEvent(path, e)
+		},
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	mode := "reporting"
+	if apply {
+		mode = "applying safe rules"
+	}
+	fmt.Printf("Watching %s (%s, press Ctrl+C to stop)\n", path, mode)
+	return w.Run(ctx)
+}
+
+func safeRules(opts sharpify.Options) ([]string, error) {
+	selected, err := sharpify.SelectRules(opts)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, r := range selected {
+		if sharpify.InfoOf(r).Safe {
+			names = append(names, r.Name())
+		}
+	}
+	return names, nil
+}
+
+func printWatchEvent(root string, e watch.Event) {
+	stamp := time.Now().Format("15:04:05")
+	relPath := e.Path
+	if rel, err := filepath.Rel(root, e.Path); err == nil && !strings.HasPrefix(rel, "..") {
+		relPath = rel
+	}
+	if e.Path == "" {
+		fmt.Printf("[%s] ✗ %v\n", stamp, e.Err)
+		return
+	}
+
+	fmt.Printf("[%s] %s:\n", stamp, relPath)
+	if e.Err != nil {
+		fmt.Printf("  ✗ %v\n", e.Err)
+	}
+	for _, rule := range e.Result.Applied {
+		fmt.Printf("  ✓ %s\n", rule.Description)
+	}
+	for _, f := range e.Result.Findings() {
+		fmt.Printf("  • [%s] line %d: %s (%s)\n", f.Severity, f.Line, f.Message, f.Rule)
+	}
+	for _, f := range e.Result.Failures() {
+		fmt.Printf("  ✗ %s was rolled back: %s\n", f.Rule, f.Message)
+	}
+	switch {
+	case e.Written:
+		fmt.Printf("  → File updated\n")
+	case e.Result.Changed:
+		fmt.Printf("  → Would be modified (run with --apply to write)\n")
+	}
+}
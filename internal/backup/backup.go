@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"time"
 )
 
@@ -11,6 +12,7 @@ import (
 type Manager struct {
 	backupDir string
 	enabled   bool
+	files     map[string]string
 }
 
 
@@ -21,6 +23,7 @@ func New(projectPath string) *Manager {
 	return &Manager{
 		backupDir: backupDir,
 		enabled:   true,
+		files:     make(map[string]string),
 	}
 }
 
@@ -77,6 +80,80 @@ func (m *Manager) Backup(filePath string, content string) error {
 		return fmt.Errorf("failed to write backup: %w", err)
 	}
 
+	m.files[absPath] = backupPath
+	return nil
+}
+
+
+func (m *Manager) BackupNew(filePath string) error {
+	if !m.enabled {
+		return nil
+	}
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path: %w", err)
+	}
+	if _, tracked := m.files[absPath]; !tracked {
+		m.files[absPath] = ""
+	}
+	return nil
+}
+
+
+func (m *Manager) Tracks(filePath string) bool {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return false
+	}
+	_, ok := m.files[absPath]
+	return ok
+}
+
+
+func (m *Manager) Files() []string {
+	files := make([]string, 0, len(m.files))
+	for path := range m.files {
+		files = append(files, path)
+	}
+	sort.Strings(files)
+	return files
+}
+
+
+func (m *Manager) RestoreFile(filePath string) error {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path: %w", err)
+	}
+	backupPath, ok := m.files[absPath]
+	if !ok {
+		return fmt.Errorf("no backup for %s", filePath)
+	}
+
+	if backupPath == "" {
+		if err := os.Remove(absPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", filePath, err)
+		}
+		return nil
+	}
+
+	content, err := os.ReadFile(backupPath)
+	if err != nil {
+		return fmt.Errorf("failed to read backup of %s: %w", filePath, err)
+	}
+	if err := os.WriteFile(absPath, content, 0644); err != nil {
+		return fmt.Errorf("failed to restore %s: %w", filePath, err)
+	}
+	return nil
+}
+
+
+func (m *Manager) RestoreAll() error {
+	for _, path := range m.Files() {
+		if err := m.RestoreFile(path); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -2,18 +2,25 @@ package config
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 
 	"github.com/andiq123/sharpify/internal/rules"
+	"github.com/andiq123/sharpify/internal/transformer"
 )
 
 type Config struct {
-	TargetVersion string   `json:"targetVersion"`
-	SafeOnly      bool     `json:"safeOnly"`
-	BackupEnabled bool     `json:"backupEnabled"`
-	DisabledRules []string `json:"disabledRules,omitempty"`
-	WorkingPath   string   `json:"workingPath,omitempty"`
+	TargetVersion string                        `json:"targetVersion"`
+	SafeOnly      bool                          `json:"safeOnly"`
+	BackupEnabled bool                          `json:"backupEnabled"`
+	DisabledRules []string                      `json:"disabledRules,omitempty"`
+	WorkingPath   string                        `json:"workingPath,omitempty"`
+	Preset        string                        `json:"preset,omitempty"`
+	Presets       map[string]transformer.Preset `json:"presets,omitempty"`
+	Severity      map[string]rules.Severity     `json:"severity,omitempty"`
+	RuleOptions   map[string]json.RawMessage    `json:"ruleOptions,omitempty"`
+	Plugins       map[string]PluginConfig       `json:"plugins,omitempty"`
 }
 
 func DefaultConfig() *Config {
@@ -131,3 +138,29 @@ func (c *Config) GetEnabledRules(allRules []rules.Rule) []rules.Rule {
 	return result
 }
 
+
+type Registry interface {
+	Get(name string) (rules.Rule, bool)
+	Register(rule rules.Rule) error
+	Replace(rule rules.Rule)
+	RegisterPreset(p transformer.Preset)
+	Configure(name string, options json.RawMessage) error
+}
+
+
+func (c *Config) RegisterPresets(registry Registry) {
+	for name, p := range c.Presets {
+		p.Name = name
+		registry.RegisterPreset(p)
+	}
+}
+
+
+func (c *Config) ConfigureRules(registry Registry) error {
+	for name, options := range c.RuleOptions {
+		if err := registry.Configure(name, options); err != nil {
+			return fmt.Errorf("ruleOptions: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package config
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const EditorConfigFile = ".editorconfig"
+
+type editorConfigSection struct {
+	pattern    *regexp.Regexp
+	properties map[string]string
+}
+
+func EditorConfig(path string) (map[string]string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for dir := filepath.Dir(abs); ; {
+		candidate := filepath.Join(dir, EditorConfigFile)
+		if _, err := os.Stat(candidate); err == nil {
+			files = append(files, candidate)
+			root, err := isEditorConfigRoot(candidate)
+			if err != nil {
+				return nil, err
+			}
+			if root {
+				break
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	properties := make(map[string]string)
+	for i := len(files) - 1; i >= 0; i-- {
+		sections, err := readEditorConfig(files[i])
+		if err != nil {
+			return nil, err
+		}
+		rel, err := filepath.Rel(filepath.Dir(files[i]), abs)
+		if err != nil {
+			continue
+		}
+		rel = filepath.ToSlash(rel)
+		for _, s := range sections {
+			if s.pattern != nil && s.pattern.MatchString(rel) {
+				for k, v := range s.properties {
+					properties[k] = v
+				}
+			}
+		}
+	}
+	return properties, nil
+}
+
+func isEditorConfigRoot(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			return false, nil
+		}
+		if key, value, ok := editorConfigProperty(line); ok && key == "root" {
+			return value == "true", nil
+		}
+	}
+	return false, scanner.Err()
+}
+
+func readEditorConfig(path string) ([]editorConfigSection, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var sections []editorConfigSection
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			pattern, _ := editorConfigGlob(line[1 : len(line)-1])
+			sections = append(sections, editorConfigSection{pattern: pattern, properties: make(map[string]string)})
+			continue
+		}
+		if key, value, ok := editorConfigProperty(line); ok && len(sections) > 0 {
+			sections[len(sections)-1].properties[key] = value
+		}
+	}
+	return sections, scanner.Err()
+}
+
+func editorConfigProperty(line string) (string, string, bool) {
+	key, value, ok := strings.Cut(line, "=")
+	if !ok {
+		return "", "", false
+	}
+	key = strings.ToLower(strings.TrimSpace(key))
+	return key, strings.ToLower(strings.TrimSpace(value)), key != ""
+}
+
+func editorConfigGlob(glob string) (*regexp.Regexp, error) {
+	if strings.HasPrefix(glob, "/") {
+		glob = glob[1:]
+	} else if !strings.Contains(glob, "/") {
+		glob = "**/" + glob
+	}
+
+	var b strings.Builder
+	b.WriteString("^")
+	braces := 0
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				i++
+				if i+1 < len(glob) && glob[i+1] == '/' {
+					i++
+					b.WriteString("(?:.*/)?")
+				} else {
+					b.WriteString(".*")
+				}
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(glob[i:], ']')
+			if end == -1 {
+				b.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i += end
+		case '{':
+			braces++
+			b.WriteString("(?:")
+		case '}':
+			if braces == 0 {
+				b.WriteString(`\}`)
+				continue
+			}
+			braces--
+			b.WriteString(")")
+		case ',':
+			if braces > 0 {
+				b.WriteString("|")
+			} else {
+				b.WriteString(",")
+			}
+		case '\\':
+			if i+1 < len(glob) {
+				i++
+				b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+			}
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEditorConfig(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		".editorconfig":     "root = true\n\n[*]\nend_of_line = lf\nindent_size = 2\n\n[*.{cs,csx}]\nindent_size = 4\n",
+		"src/.editorconfig": "# nested\n[Legacy/**.cs]\nend_of_line = CRLF\n\n[/Generated.cs]\nindent_size = 8\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		path, key, want string
+	}{
+		{"README.md", "indent_size", "2"},
+		{"src/A.cs", "indent_size", "4"},
+		{"src/A.cs", "end_of_line", "lf"},
+		{"src/Legacy/Old/B.cs", "end_of_line", "crlf"},
+		{"src/Generated.cs", "indent_size", "8"},
+		{"src/Sub/Generated.cs", "indent_size", "4"},
+	}
+	for _, tt := range tests {
+		props, err := EditorConfig(filepath.Join(root, tt.path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := props[tt.key]; got != tt.want {
+			t.Errorf("%s: %s = %q, want %q", tt.path, tt.key, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/andiq123/sharpify/internal/plugin"
+)
+
+type PluginConfig struct {
+	Command string   `json:"command"`
+	Args    []string `json:"args,omitempty"`
+	Timeout string   `json:"timeout,omitempty"`
+}
+
+type Plugins []*plugin.Plugin
+
+func (ps Plugins) Close() {
+	for _, p := range ps {
+		p.Close()
+	}
+}
+
+func (c *Config) StartPlugins(registry Registry) (Plugins, error) {
+	names := make([]string, 0, len(c.Plugins))
+	for name := range c.Plugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var started Plugins
+	var errs []error
+	for _, name := range names {
+		p, err := startPlugin(name, c.Plugins[name], registry)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		started = append(started, p)
+	}
+	return started, errors.Join(errs...)
+}
+
+func startPlugin(name string, pc PluginConfig, registry Registry) (*plugin.Plugin, error) {
+	if pc.Command == "" {
+		return nil, fmt.Errorf("plugins.%s: command is required", name)
+	}
+	opts := plugin.Options{Name: name, Command: pc.Command, Args: pc.Args}
+	if pc.Timeout != "" {
+		d, err := time.ParseDuration(pc.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("plugins.%s: invalid timeout: %w", name, err)
+		}
+		opts.Timeout = d
+	}
+
+	p, err := plugin.Start(opts)
+	if err != nil {
+		return nil, err
+	}
+	pluginRules, err := p.Rules()
+	if err != nil {
+		p.Close()
+		return nil, err
+	}
+	for _, r := range pluginRules {
+		if _, exists := registry.Get(r.Name()); exists {
+			p.Close()
+			return nil, fmt.Errorf("plugin %s: rule %s conflicts with an existing rule", name, r.Name())
+		}
+	}
+	for _, r := range pluginRules {
+		if err := registry.Register(r); err != nil {
+			p.Close()
+			return nil, fmt.Errorf("plugin %s: %w", name, err)
+		}
+	}
+	return p, nil
+}
@@ -0,0 +1,153 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/andiq123/sharpify/internal/rules"
+	"gopkg.in/yaml.v3"
+)
+
+const RulesDir = ".sharpify/rules"
+
+type RuleSpec struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	MinVersion  string `yaml:"minVersion"`
+	Safe        bool   `yaml:"safe"`
+	Match       string `yaml:"match"`
+	Replace     string `yaml:"replace"`
+}
+
+var ruleNamePattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+func (s RuleSpec) Rule() (*rules.TemplateRule, error) {
+	if !ruleNamePattern.MatchString(s.Name) {
+		return nil, fmt.Errorf("rule name %q must be lowercase words separated by dashes", s.Name)
+	}
+	if strings.TrimSpace(s.Match) == "" {
+		return nil, fmt.Errorf("rule %s: match is required", s.Name)
+	}
+
+	version := rules.CSharp6
+	if s.MinVersion != "" {
+		v, ok := rules.ParseVersion(s.MinVersion)
+		if !ok {
+			return nil, fmt.Errorf("rule %s: unknown minVersion %q", s.Name, s.MinVersion)
+		}
+		version = v
+	}
+
+	r, err := rules.NewTemplateRule(s.Name, s.Description, version, s.Safe, strings.TrimSpace(s.Match), strings.TrimSpace(s.Replace))
+	if err != nil {
+		return nil, fmt.Errorf("rule %s: %w", s.Name, err)
+	}
+	return r, nil
+}
+
+func FindRulesDir(path string) string {
+	dir, err := filepath.Abs(path)
+	if err != nil {
+		return ""
+	}
+	if info, err := os.Stat(dir); err == nil && !info.IsDir() {
+		dir = filepath.Dir(dir)
+	}
+	for {
+		candidate := filepath.Join(dir, RulesDir)
+		if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+			return candidate
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+func LoadRules(dir string) ([]*rules.TemplateRule, error) {
+	var paths []string
+	for _, pattern := range []string{"*.yaml", "*.yml"} {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, matches...)
+	}
+	sort.Strings(paths)
+
+	var result []*rules.TemplateRule
+	seen := make(map[string]string)
+	for _, path := range paths {
+		specs, err := readRuleSpecs(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, spec := range specs {
+			r, err := spec.Rule()
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", path, err)
+			}
+			if prev, ok := seen[r.Name()]; ok {
+				return nil, fmt.Errorf("%s: rule %s is already defined in %s", path, r.Name(), prev)
+			}
+			seen[r.Name()] = path
+			result = append(result, r)
+		}
+	}
+	return result, nil
+}
+
+func readRuleSpecs(path string) ([]RuleSpec, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	var specs []RuleSpec
+	for {
+		var spec RuleSpec
+		err := dec.Decode(&spec)
+		if errors.Is(err, io.EOF) {
+			return specs, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		specs = append(specs, spec)
+	}
+}
+
+func RegisterRules(registry Registry, path string) error {
+	dir := FindRulesDir(path)
+	if dir == "" {
+		return nil
+	}
+	loaded, err := LoadRules(dir)
+	if err != nil {
+		return err
+	}
+	for _, r := range loaded {
+		if existing, ok := registry.Get(r.Name()); ok {
+			if _, custom := existing.(*rules.TemplateRule); !custom {
+				return fmt.Errorf("%s: rule %s conflicts with a built-in rule", dir, r.Name())
+			}
+			registry.Replace(r)
+			continue
+		}
+		if err := registry.Register(r); err != nil {
+			return fmt.Errorf("%s: %w", dir, err)
+		}
+	}
+	return nil
+}
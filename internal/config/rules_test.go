@@ -0,0 +1,102 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andiq123/sharpify/internal/rules"
+	"github.com/andiq123/sharpify/internal/scanner"
+	"github.com/andiq123/sharpify/internal/transformer"
+)
+
+const legacyLogRule = `name: legacy-log
+description: Replace LegacyLog.Write with ILogger
+minVersion: "9"
+safe: true
+match: LegacyLog.Write($msg:expr)
+replace: _logger.LogInformation($msg)
+---
+name: service-locator
+match: ServiceLocator.Get<$T:type>()
+replace: _services.GetRequiredService<$T>()
+`
+
+func writeRules(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	dir := filepath.Join(root, RulesDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestRegisterRules(t *testing.T) {
+	root := t.TempDir()
+	writeRules(t, root, map[string]string{"logging.yaml": legacyLogRule})
+	src := filepath.Join(root, "src", "App")
+	if err := os.MkdirAll(src, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	registry := transformer.NewRegistry()
+	if err := RegisterRules(registry, src); err != nil {
+		t.Fatal(err)
+	}
+
+	r, ok := registry.Get("legacy-log")
+	if !ok {
+		t.Fatal("legacy-log was not registered")
+	}
+	vr := r.(rules.VersionedRule)
+	if vr.MinVersion() != rules.CSharp9 || !vr.IsSafe() {
+		t.Errorf("unexpected version/safety: %v %v", vr.MinVersion(), vr.IsSafe())
+	}
+	if _, ok := registry.Get("service-locator"); !ok {
+		t.Error("second document was not registered")
+	}
+
+	safe := registry.GetByVersion(rules.CSharp12, true)
+	out := transformer.New(safe).Transform(scanner.FileInfo{Path: "App.cs", Content: "LegacyLog.Write(\"hi\"); // LegacyLog.Write(x)\n"})
+	if out.NewContent != "_logger.LogInformation(\"hi\"); // LegacyLog.Write(x)\n" {
+		t.Errorf("unexpected output: %q", out.NewContent)
+	}
+}
+
+func TestLoadRulesErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"unknown field", "name: a\nmatch: Foo()\nreplce: Bar()\n", "replce"},
+		{"bad name", "name: Bad Name\nmatch: Foo()\n", "lowercase"},
+		{"missing match", "name: a\nreplace: Bar()\n", "match is required"},
+		{"bad version", "name: a\nminVersion: \"5\"\nmatch: Foo()\n", "minVersion"},
+		{"undefined placeholder", "name: a\nmatch: Foo($x)\nreplace: Bar($y)\n", "$y"},
+		{"duplicate", "name: a\nmatch: Foo()\n---\nname: a\nmatch: Bar()\n", "already defined"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeRules(t, root, map[string]string{"rules.yaml": tt.content})
+			_, err := LoadRules(filepath.Join(root, RulesDir))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("got error %v, want one mentioning %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisterRulesRejectsBuiltinNames(t *testing.T) {
+	root := t.TempDir()
+	writeRules(t, root, map[string]string{"rules.yml": "name: expression-body\nmatch: Foo()\n"})
+	if err := RegisterRules(transformer.NewRegistry(), root); err == nil {
+		t.Error("expected a conflict with the built-in rule")
+	}
+}
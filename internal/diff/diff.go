@@ -0,0 +1,115 @@
+package diff
+
+import "strings"
+
+const maxCells = 4 << 20
+
+type Hunk struct {
+	OldStart int
+	OldEnd   int
+	NewStart int
+	NewEnd   int
+	Old      []string
+	New      []string
+}
+
+func (h Hunk) OldText() string {
+	return strings.Join(h.Old, "")
+}
+
+func (h Hunk) NewText() string {
+	return strings.Join(h.New, "")
+}
+
+func Lines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+func Hunks(old, new string) []Hunk {
+	a, b := Lines(old), Lines(new)
+
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	am, bm := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	if len(am) == 0 && len(bm) == 0 {
+		return nil
+	}
+	if len(am) == 0 || len(bm) == 0 || (len(am)+1)*(len(bm)+1) > maxCells {
+		return []Hunk{newHunk(a, b, prefix, len(a)-suffix, prefix, len(b)-suffix)}
+	}
+
+	n, m := len(am), len(bm)
+	lcs := make([][]int32, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			switch {
+			case am[i] == bm[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var hunks []Hunk
+	i, j := 0, 0
+	for i < n || j < m {
+		if i < n && j < m && am[i] == bm[j] {
+			i++
+			j++
+			continue
+		}
+		si, sj := i, j
+		for i < n || j < m {
+			if i < n && j < m && am[i] == bm[j] {
+				break
+			}
+			if j == m || (i < n && lcs[i+1][j] >= lcs[i][j+1]) {
+				i++
+			} else {
+				j++
+			}
+		}
+		hunks = append(hunks, newHunk(a, b, prefix+si, prefix+i, prefix+sj, prefix+j))
+	}
+	return hunks
+}
+
+func newHunk(a, b []string, oldStart, oldEnd, newStart, newEnd int) Hunk {
+	return Hunk{
+		OldStart: oldStart,
+		OldEnd:   oldEnd,
+		NewStart: newStart,
+		NewEnd:   newEnd,
+		Old:      a[oldStart:oldEnd],
+		New:      b[newStart:newEnd],
+	}
+}
+
+func Apply(old string, hunks []Hunk) string {
+	a := Lines(old)
+	var b strings.Builder
+	last := 0
+	for _, h := range hunks {
+		b.WriteString(strings.Join(a[last:h.OldStart], ""))
+		b.WriteString(h.NewText())
+		last = h.OldEnd
+	}
+	b.WriteString(strings.Join(a[last:], ""))
+	return b.String()
+}
@@ -0,0 +1,39 @@
+package diff
+
+import "testing"
+
+func TestHunks(t *testing.T) {
+	tests := []struct {
+		name  string
+		old   string
+		new   string
+		hunks int
+	}{
+		{"equal", "a\nb\n", "a\nb\n", 0},
+		{"replace", "a\nb\nc\n", "a\nB\nc\n", 1},
+		{"two changes", "a\nb\nc\nd\ne\n", "A\nb\nc\nd\nE\n", 2},
+		{"insert", "a\nc\n", "a\nb\nc\n", 1},
+		{"delete", "a\nb\nc\n", "a\nc\n", 1},
+		{"no trailing newline", "a\nb", "a\nc", 1},
+		{"from empty", "", "a\n", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hunks := Hunks(tt.old, tt.new)
+			if len(hunks) != tt.hunks {
+				t.Fatalf("got %d hunks, want %d: %+v", len(hunks), tt.hunks, hunks)
+			}
+			if got := Apply(tt.old, hunks); got != tt.new {
+				t.Errorf("Apply = %q, want %q", got, tt.new)
+			}
+		})
+	}
+}
+
+func TestApplySubset(t *testing.T) {
+	old := "a\nb\nc\nd\ne\n"
+	hunks := Hunks(old, "A\nb\nc\nd\nE\n")
+	if got := Apply(old, hunks[1:]); got != "a\nb\nc\nd\nE\n" {
+		t.Errorf("Apply = %q", got)
+	}
+}
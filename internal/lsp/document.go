@@ -0,0 +1,223 @@
+package lsp
+
+import (
+	"context"
+	"net/url"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/andiq123/sharpify/internal/diff"
+	"github.com/andiq123/sharpify/pkg/sharpify"
+)
+
+type document struct {
+	uri         string
+	path        string
+	version     int
+	text        string
+	lineStarts  []int
+	diagnostics []Diagnostic
+	fixes       []fix
+	opts        *sharpify.Options
+}
+
+type fix struct {
+	diagnostic Diagnostic
+	title      string
+	edit       TextEdit
+}
+
+func newDocument(uri string, version int, text string) *document {
+	d := &document{uri: uri, path: uriPath(uri)}
+	d.update(version, text)
+	return d
+}
+
+func (d *document) update(version int, text string) {
+	d.version = version
+	d.text = text
+	d.lineStarts = []int{0}
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\n' {
+			d.lineStarts = append(d.lineStarts, i+1)
+		}
+	}
+}
+
+func (d *document) analyze(ctx context.Context, opts sharpify.Options) error {
+	d.diagnostics = nil
+	d.fixes = nil
+
+	selected, err := sharpify.SelectRules(opts)
+	if err != nil {
+		return err
+	}
+	for _, rule := range selected {
+		info := sharpify.InfoOf(rule)
+		ruleOpts := opts
+		ruleOpts.Rules = []string{info.Name}
+		result, err := sharpify.Transform(ctx, d.text, ruleOpts)
+		if err != nil {
+			return err
+		}
+
+		data := DiagnosticData{Rule: info.Name, MinVersion: info.MinVersion.String()}
+		if opts.Version != 0 {
+			data.LanguageVersion = opts.Version.String()
+		}
+		hunks := diff.Hunks(d.text, result.Content)
+		for _, f := range result.Findings() {
+			data := data
+			d.diagnostics = append(d.diagnostics, Diagnostic{
+				Range:    d.lineRange(originalLine(hunks, f.Line) - 1),
+				Severity: diagnosticSeverity(f.Severity),
+				Code:     info.Name,
+				Source:   "sharpify",
+				Message:  f.Message,
+				Data:     data,
+			})
+		}
+		if !result.Changed {
+			continue
+		}
+
+		data.Fixable = true
+		for _, h := range hunks {
+			edit := d.hunkEdit(h)
+			diagnostic := Diagnostic{
+				Range:    edit.Range,
+				Severity: severityInformation,
+				Code:     info.Name,
+				Source:   "sharpify",
+				Message:  info.Description,
+				Data:     data,
+			}
+			if edit.Range.Start == edit.Range.End {
+				diagnostic.Range = d.lineRange(edit.Range.Start.Line)
+			}
+			d.diagnostics = append(d.diagnostics, diagnostic)
+			d.fixes = append(d.fixes, fix{diagnostic: diagnostic, title: "Sharpify: " + info.Description, edit: edit})
+		}
+	}
+
+	sort.SliceStable(d.diagnostics, func(i, j int) bool {
+		return before(d.diagnostics[i].Range.Start, d.diagnostics[j].Range.Start)
+	})
+	return nil
+}
+
+func originalLine(hunks []diff.Hunk, line int) int {
+	n, delta := line-1, 0
+	for _, h := range hunks {
+		if n < h.NewStart {
+			break
+		}
+		if n < h.NewEnd {
+			return h.OldStart + 1
+		}
+		delta = h.OldEnd - h.NewEnd
+	}
+	return n + delta + 1
+}
+
+func (d *document) fixAll(ctx context.Context, opts sharpify.Options) ([]TextEdit, error) {
+	result, err := sharpify.Transform(ctx, d.text, opts)
+	if err != nil || !result.Changed {
+		return nil, err
+	}
+	var edits []TextEdit
+	for _, h := range diff.Hunks(d.text, result.Content) {
+		edits = append(edits, d.hunkEdit(h))
+	}
+	return edits, nil
+}
+
+func (d *document) hunkEdit(h diff.Hunk) TextEdit {
+	start := d.lineOffset(h.OldStart)
+	oldText, newText := h.OldText(), h.NewText()
+
+	prefix := 0
+	for prefix < len(oldText) && prefix < len(newText) && oldText[prefix] == newText[prefix] {
+		prefix++
+	}
+	for prefix > 0 && !utf8.RuneStart(oldText[prefix]) {
+		prefix--
+	}
+	suffix := 0
+	for suffix < len(oldText)-prefix && suffix < len(newText)-prefix && oldText[len(oldText)-1-suffix] == newText[len(newText)-1-suffix] {
+		suffix++
+	}
+	for suffix > 0 && !utf8.RuneStart(oldText[len(oldText)-suffix]) {
+		suffix--
+	}
+
+	return TextEdit{
+		Range: Range{
+			Start: d.position(start + prefix),
+			End:   d.position(start + len(oldText) - suffix),
+		},
+		NewText: newText[prefix : len(newText)-suffix],
+	}
+}
+
+func (d *document) lineOffset(line int) int {
+	if line >= len(d.lineStarts) {
+		return len(d.text)
+	}
+	return d.lineStarts[line]
+}
+
+func (d *document) position(offset int) Position {
+	line := sort.Search(len(d.lineStarts), func(i int) bool { return d.lineStarts[i] > offset }) - 1
+	prefix := d.text[d.lineStarts[line]:offset]
+	return Position{Line: line, Character: len(utf16.Encode([]rune(prefix)))}
+}
+
+func (d *document) lineRange(line int) Range {
+	if line < 0 {
+		line = 0
+	}
+	if line >= len(d.lineStarts) {
+		line = len(d.lineStarts) - 1
+	}
+	end := len(d.text)
+	if line+1 < len(d.lineStarts) {
+		end = d.lineStarts[line+1] - 1
+	}
+	end = len(strings.TrimRight(d.text[:end], "\r"))
+	if end < d.lineStarts[line] {
+		end = d.lineStarts[line]
+	}
+	return Range{Start: d.position(d.lineStarts[line]), End: d.position(end)}
+}
+
+func diagnosticSeverity(s sharpify.Severity) int {
+	if s == sharpify.SeverityInfo {
+		return severityHint
+	}
+	return severityInformation
+}
+
+func before(a, b Position) bool {
+	return a.Line < b.Line || (a.Line == b.Line && a.Character < b.Character)
+}
+
+func overlaps(a, b Range) bool {
+	return !before(a.End, b.Start) && !before(b.End, a.Start)
+}
+
+func uriPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return ""
+	}
+	path := u.Path
+	if runtime.GOOS == "windows" {
+		path = strings.TrimPrefix(path, "/")
+	}
+	return filepath.FromSlash(path)
+}
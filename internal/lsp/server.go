@@ -0,0 +1,262 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/andiq123/sharpify/pkg/sharpify"
+)
+
+type Server struct {
+	opts     sharpify.Options
+	version  string
+	conn     *conn
+	docs     map[string]*document
+	pending  map[string]bool
+	delay    time.Duration
+	shutdown bool
+}
+
+const changeDelay = 200 * time.Millisecond
+
+func NewServer(opts sharpify.Options, version string) *Server {
+	return &Server{
+		opts:    opts,
+		version: version,
+		docs:    make(map[string]*document),
+		pending: make(map[string]bool),
+		delay:   changeDelay,
+	}
+}
+
+type incoming struct {
+	msg *message
+	err error
+}
+
+func (s *Server) Serve(ctx context.Context, in io.Reader, out io.Writer) error {
+	s.conn = newConn(in, out)
+	messages := make(chan incoming)
+	go func() {
+		for {
+			msg, err := s.conn.read()
+			select {
+			case messages <- incoming{msg, err}:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	var debounce <-chan time.Time
+	for {
+		var next incoming
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-debounce:
+			debounce = nil
+			if err := s.publishPending(ctx); err != nil {
+				return err
+			}
+			continue
+		case next = <-messages:
+		}
+		msg, err := next.msg, next.err
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if msg.Method == "exit" {
+			if !s.shutdown {
+				return fmt.Errorf("exit before shutdown")
+			}
+			return nil
+		}
+		if msg.ID == nil {
+			if err := s.handleNotification(ctx, msg); err != nil {
+				return err
+			}
+			if len(s.pending) > 0 {
+				debounce = time.After(s.delay)
+			}
+			continue
+		}
+		result, err := s.handleRequest(ctx, msg)
+		if err := s.conn.reply(msg.ID, result, err); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) handleRequest(ctx context.Context, msg *message) (any, error) {
+	switch msg.Method {
+	case "initialize":
+		return InitializeResult{
+			Capabilities: ServerCapabilities{
+				TextDocumentSync:   syncFull,
+				CodeActionProvider: CodeActionOptions{CodeActionKinds: []string{KindQuickFix, KindFixAll}},
+			},
+			ServerInfo: ServerInfo{Name: "sharpify", Version: s.version},
+		}, nil
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+	case "textDocument/codeAction":
+		var params CodeActionParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.codeActions(ctx, params)
+	default:
+		return nil, &ResponseError{Code: methodNotFound, Message: "method not found: " + msg.Method}
+	}
+}
+
+func (s *Server) handleNotification(ctx context.Context, msg *message) error {
+	switch msg.Method {
+	case "textDocument/didOpen":
+		var params DidOpenParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil
+		}
+		item := params.TextDocument
+		doc := newDocument(item.URI, item.Version, item.Text)
+		s.docs[item.URI] = doc
+		return s.publish(ctx, doc)
+	case "textDocument/didChange":
+		var params DidChangeParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil || len(params.ContentChanges) == 0 {
+			return nil
+		}
+		doc, ok := s.docs[params.TextDocument.URI]
+		if !ok {
+			return nil
+		}
+		doc.update(params.TextDocument.Version, params.ContentChanges[len(params.ContentChanges)-1].Text)
+		s.pending[doc.uri] = true
+		return nil
+	case "textDocument/didClose":
+		var params DidCloseParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil
+		}
+		delete(s.docs, params.TextDocument.URI)
+		delete(s.pending, params.TextDocument.URI)
+		return s.conn.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{
+			URI:         params.TextDocument.URI,
+			Diagnostics: []Diagnostic{},
+		})
+	}
+	return nil
+}
+
+func (s *Server) options(doc *document) sharpify.Options {
+	if doc.opts != nil {
+		return *doc.opts
+	}
+	opts := s.opts
+	if doc.path != "" {
+		opts.Filename = doc.path
+		if v, ok := sharpify.ProjectVersion(doc.path); ok {
+			opts.Version = v
+		}
+	}
+	doc.opts = &opts
+	return opts
+}
+
+func (s *Server) publishPending(ctx context.Context) error {
+	for uri := range s.pending {
+		delete(s.pending, uri)
+		if doc, ok := s.docs[uri]; ok {
+			if err := s.publish(ctx, doc); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Server) publish(ctx context.Context, doc *document) error {
+	if err := doc.analyze(ctx, s.options(doc)); err != nil {
+		return s.conn.notify("window/logMessage", map[string]any{"type": 1, "message": "sharpify: " + err.Error()})
+	}
+	diagnostics := doc.diagnostics
+	if diagnostics == nil {
+		diagnostics = []Diagnostic{}
+	}
+	version := doc.version
+	return s.conn.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{
+		URI:         doc.uri,
+		Version:     &version,
+		Diagnostics: diagnostics,
+	})
+}
+
+func (s *Server) codeActions(ctx context.Context, params CodeActionParams) ([]CodeAction, error) {
+	actions := []CodeAction{}
+	doc, ok := s.docs[params.TextDocument.URI]
+	if !ok {
+		return actions, nil
+	}
+	if s.pending[doc.uri] {
+		delete(s.pending, doc.uri)
+		if err := s.publish(ctx, doc); err != nil {
+			return nil, err
+		}
+	}
+
+	if wants(params.Context.Only, KindQuickFix) {
+		for _, f := range doc.fixes {
+			if !overlaps(f.diagnostic.Range, params.Range) {
+				continue
+			}
+			actions = append(actions, CodeAction{
+				Title:       f.title,
+				Kind:        KindQuickFix,
+				Diagnostics: []Diagnostic{f.diagnostic},
+				IsPreferred: true,
+				Edit:        &WorkspaceEdit{Changes: map[string][]TextEdit{doc.uri: {f.edit}}},
+			})
+		}
+	}
+
+	if wants(params.Context.Only, KindFixAll) && len(doc.fixes) > 0 {
+		edits, err := doc.fixAll(ctx, s.options(doc))
+		if err != nil {
+			return nil, err
+		}
+		if len(edits) > 0 {
+			actions = append(actions, CodeAction{
+				Title: "Sharpify: fix all in file",
+				Kind:  KindFixAll,
+				Edit:  &WorkspaceEdit{Changes: map[string][]TextEdit{doc.uri: edits}},
+			})
+		}
+	}
+	return actions, nil
+}
+
+func wants(only []string, kind string) bool {
+	if len(only) == 0 {
+		return true
+	}
+	for _, k := range only {
+		if k == kind || strings.HasPrefix(kind, k+".") {
+			return true
+		}
+	}
+	return false
+}
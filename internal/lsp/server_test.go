@@ -0,0 +1,342 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andiq123/sharpify/internal/diff"
+	"github.com/andiq123/sharpify/pkg/sharpify"
+)
+
+type client struct {
+	t             *testing.T
+	conn          *conn
+	nextID        int
+	responses     chan *message
+	notifications chan *message
+	done          chan error
+}
+
+func newClient(t *testing.T, opts sharpify.Options) *client {
+	t.Helper()
+	serverIn, clientOut := io.Pipe()
+	clientIn, serverOut := io.Pipe()
+
+	c := &client{
+		t:             t,
+		conn:          newConn(clientIn, clientOut),
+		responses:     make(chan *message, 16),
+		notifications: make(chan *message, 16),
+		done:          make(chan error, 1),
+	}
+	go func() {
+		c.done <- NewServer(opts, "test").Serve(context.Background(), serverIn, serverOut)
+		serverOut.Close()
+	}()
+	go func() {
+		for {
+			msg, err := c.conn.read()
+			if err != nil {
+				close(c.responses)
+				close(c.notifications)
+				return
+			}
+			if msg.ID != nil {
+				c.responses <- msg
+			} else {
+				c.notifications <- msg
+			}
+		}
+	}()
+	t.Cleanup(func() { clientOut.Close() })
+
+	var init InitializeResult
+	c.call("initialize", map[string]any{"capabilities": map[string]any{}}, &init)
+	if init.Capabilities.TextDocumentSync != syncFull || len(init.Capabilities.CodeActionProvider.CodeActionKinds) != 2 {
+		t.Fatalf("unexpected capabilities: %+v", init.Capabilities)
+	}
+	c.notify("initialized", map[string]any{})
+	return c
+}
+
+func (c *client) notify(method string, params any) {
+	c.t.Helper()
+	if err := c.conn.notify(method, params); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+func (c *client) call(method string, params, result any) {
+	c.t.Helper()
+	c.nextID++
+	id := json.RawMessage(strings.TrimSpace(string(mustJSON(c.t, c.nextID))))
+	data := mustJSON(c.t, params)
+	if err := c.conn.write(&message{ID: &id, Method: method, Params: data}); err != nil {
+		c.t.Fatal(err)
+	}
+	select {
+	case msg := <-c.responses:
+		if msg == nil {
+			c.t.Fatalf("%s: connection closed", method)
+		}
+		if msg.Error != nil {
+			c.t.Fatalf("%s: %v", method, msg.Error)
+		}
+		if result != nil {
+			if err := json.Unmarshal(msg.Result, result); err != nil {
+				c.t.Fatal(err)
+			}
+		}
+	case <-time.After(10 * time.Second):
+		c.t.Fatalf("%s: no response", method)
+	}
+}
+
+func (c *client) diagnostics() PublishDiagnosticsParams {
+	c.t.Helper()
+	for {
+		select {
+		case msg := <-c.notifications:
+			if msg == nil {
+				c.t.Fatal("connection closed")
+			}
+			if msg.Method != "textDocument/publishDiagnostics" {
+				continue
+			}
+			var params PublishDiagnosticsParams
+			if err := json.Unmarshal(msg.Params, &params); err != nil {
+				c.t.Fatal(err)
+			}
+			return params
+		case <-time.After(10 * time.Second):
+			c.t.Fatal("no diagnostics published")
+		}
+	}
+}
+
+func mustJSON(t *testing.T, v any) []byte {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func applyEdits(t *testing.T, text string, edits []TextEdit) string {
+	t.Helper()
+	doc := newDocument("", 0, text)
+	sorted := append([]TextEdit(nil), edits...)
+	sort.Slice(sorted, func(i, j int) bool { return before(sorted[j].Range.Start, sorted[i].Range.Start) })
+	for _, e := range sorted {
+		start, end := offsetOf(doc, e.Range.Start), offsetOf(doc, e.Range.End)
+		text = text[:start] + e.NewText + text[end:]
+	}
+	return text
+}
+
+func offsetOf(doc *document, p Position) int {
+	return doc.lineOffset(p.Line) + p.Character
+}
+
+const source = `using System.Collections.Generic;
+
+namespace Demo
+{
+    class A
+    {
+        private List<string> _names = new List<string>();
+
+        void M(string name)
+        {
+            if (name == null) throw new ArgumentNullException("name");
+        }
+    }
+}
+`
+
+func project(t *testing.T, framework string) string {
+	t.Helper()
+	dir := t.TempDir()
+	csproj := `<Project Sdk="Microsoft.NET.Sdk"><PropertyGroup><TargetFramework>` + framework + `</TargetFramework></PropertyGroup></Project>`
+	if err := os.WriteFile(filepath.Join(dir, "Demo.csproj"), []byte(csproj), 0644); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "A.cs")
+	if err := os.WriteFile(path, []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
+
+func rulesOf(diagnostics []Diagnostic) map[string]Diagnostic {
+	result := make(map[string]Diagnostic)
+	for _, d := range diagnostics {
+		result[d.Code] = d
+	}
+	return result
+}
+
+func TestDiagnosticsUseProjectVersion(t *testing.T) {
+	for _, tt := range []struct {
+		framework  string
+		collection bool
+	}{
+		{"net6.0", false},
+		{"net8.0", true},
+	} {
+		t.Run(tt.framework, func(t *testing.T) {
+			c := newClient(t, sharpify.Options{})
+			uri := project(t, tt.framework)
+			c.notify("textDocument/didOpen", DidOpenParams{TextDocument: TextDocumentItem{URI: uri, Version: 1, Text: source}})
+
+			published := c.diagnostics()
+			if published.URI != uri || published.Version == nil || *published.Version != 1 {
+				t.Fatalf("unexpected publish: %+v", published)
+			}
+			byRule := rulesOf(published.Diagnostics)
+			nameof, ok := byRule["nameof-expression"]
+			if !ok {
+				t.Fatalf("missing nameof-expression diagnostic: %+v", published.Diagnostics)
+			}
+			if nameof.Source != "sharpify" || !nameof.Data.Fixable || nameof.Data.MinVersion != "C# 6.0" || nameof.Data.LanguageVersion == "" {
+				t.Errorf("unexpected diagnostic: %+v", nameof)
+			}
+			if nameof.Range.Start.Line != 10 {
+				t.Errorf("nameof diagnostic on line %d", nameof.Range.Start.Line)
+			}
+			if _, ok := byRule["collection-expression"]; ok != tt.collection {
+				t.Errorf("collection-expression reported = %v for %s", ok, tt.framework)
+			}
+		})
+	}
+}
+
+func TestCodeActions(t *testing.T) {
+	c := newClient(t, sharpify.Options{})
+	uri := project(t, "net8.0")
+	c.notify("textDocument/didOpen", DidOpenParams{TextDocument: TextDocumentItem{URI: uri, Version: 1, Text: source}})
+	nameof := rulesOf(c.diagnostics().Diagnostics)["nameof-expression"]
+
+	var actions []CodeAction
+	params := map[string]any{
+		"textDocument": TextDocumentIdentifier{URI: uri},
+		"range":        nameof.Range,
+		"context":      map[string]any{"diagnostics": []Diagnostic{nameof}, "only": []string{KindQuickFix}},
+	}
+	c.call("textDocument/codeAction", params, &actions)
+	var quickFix *CodeAction
+	for i, a := range actions {
+		if a.Kind != KindQuickFix {
+			t.Errorf("unexpected action kind %q", a.Kind)
+		}
+		if a.Diagnostics[0].Code == "nameof-expression" {
+			quickFix = &actions[i]
+		}
+	}
+	if quickFix == nil {
+		t.Fatalf("no nameof-expression quick fix: %+v", actions)
+	}
+	got := applyEdits(t, source, quickFix.Edit.Changes[uri])
+	want := strings.Replace(source, `ArgumentNullException("name")`, `ArgumentNullException(nameof(name))`, 1)
+	if got != want {
+		t.Errorf("quick fix produced:\n%s", got)
+	}
+
+	params["context"] = map[string]any{"diagnostics": []Diagnostic{}, "only": []string{"source.fixAll"}}
+	c.call("textDocument/codeAction", params, &actions)
+	if len(actions) != 1 || actions[0].Kind != KindFixAll {
+		t.Fatalf("unexpected actions: %+v", actions)
+	}
+	all := applyEdits(t, source, actions[0].Edit.Changes[uri])
+	result, err := sharpify.Transform(context.Background(), source, sharpify.Options{Version: sharpify.CSharp12})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if all != result.Content {
+		t.Errorf("fix all produced:\n%s\nwant:\n%s", all, result.Content)
+	}
+}
+
+func TestDidChangeAndClose(t *testing.T) {
+	c := newClient(t, sharpify.Options{Rules: []string{"nameof-expression"}})
+	uri := "untitled:A.cs"
+	c.notify("textDocument/didOpen", DidOpenParams{TextDocument: TextDocumentItem{URI: uri, Version: 1, Text: source}})
+	if got := c.diagnostics().Diagnostics; len(got) != 1 {
+		t.Fatalf("expected one diagnostic, got %+v", got)
+	}
+
+	fixed := strings.Replace(source, `"name"`, `nameof(name)`, 1)
+	c.notify("textDocument/didChange", map[string]any{
+		"textDocument":   map[string]any{"uri": uri, "version": 2},
+		"contentChanges": []map[string]any{{"text": fixed}},
+	})
+	published := c.diagnostics()
+	if len(published.Diagnostics) != 0 || *published.Version != 2 {
+		t.Fatalf("expected no diagnostics after the fix, got %+v", published)
+	}
+
+	c.notify("textDocument/didClose", DidCloseParams{TextDocument: TextDocumentIdentifier{URI: uri}})
+	if got := c.diagnostics(); got.URI != uri || len(got.Diagnostics) != 0 {
+		t.Fatalf("unexpected publish after close: %+v", got)
+	}
+
+	c.call("shutdown", nil, nil)
+	c.notify("exit", nil)
+	select {
+	case err := <-c.done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not exit")
+	}
+}
+
+func TestPositionsUseUTF16(t *testing.T) {
+	doc := newDocument("", 0, "var s = \"😀\"; x\n")
+	if got := doc.position(strings.Index(doc.text, "x")); got != (Position{Line: 0, Character: 14}) {
+		t.Errorf("position = %+v", got)
+	}
+}
+
+func TestDidChangeIsDebounced(t *testing.T) {
+	c := newClient(t, sharpify.Options{Rules: []string{"nameof-expression"}})
+	uri := "untitled:A.cs"
+	c.notify("textDocument/didOpen", DidOpenParams{TextDocument: TextDocumentItem{URI: uri, Version: 1, Text: source}})
+	c.diagnostics()
+
+	for version := 2; version <= 4; version++ {
+		c.notify("textDocument/didChange", map[string]any{
+			"textDocument":   map[string]any{"uri": uri, "version": version},
+			"contentChanges": []map[string]any{{"text": source}},
+		})
+	}
+	if got := c.diagnostics(); *got.Version != 4 {
+		t.Fatalf("published version %d, want only the last change", *got.Version)
+	}
+	select {
+	case msg := <-c.notifications:
+		t.Fatalf("unexpected notification after the debounced publish: %s", msg.Method)
+	case <-time.After(2 * changeDelay):
+	}
+}
+
+func TestOriginalLine(t *testing.T) {
+	before := "a\nb\nc\nd\ne\n"
+	after := "a\nx\ny\nc\ne\n"
+	hunks := diff.Hunks(before, after)
+	for newLine, want := range map[int]int{1: 1, 2: 2, 3: 2, 4: 3, 5: 5} {
+		if got := originalLine(hunks, newLine); got != want {
+			t.Errorf("originalLine(%d) = %d, want %d", newLine, got, want)
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package plugin
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultTimeout = 10 * time.Second
+	maxFailures    = 3
+	stderrLimit    = 4096
+)
+
+type Options struct {
+	Name    string
+	Command string
+	Args    []string
+	Timeout time.Duration
+}
+
+type Plugin struct {
+	opts     Options
+	info     DescribeResult
+	mu       sync.Mutex
+	proc     *process
+	failures int
+}
+
+func Start(opts Options) (*Plugin, error) {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	p := &Plugin{opts: opts}
+
+	var info DescribeResult
+	if err := p.call("describe", DescribeParams{ProtocolVersion: ProtocolVersion}, &info); err != nil {
+		p.Close()
+		return nil, fmt.Errorf("plugin %s: %w", opts.Name, err)
+	}
+	p.info = info
+	return p, nil
+}
+
+func (p *Plugin) Name() string {
+	return p.opts.Name
+}
+
+func (p *Plugin) Rules() ([]*Rule, error) {
+	result := make([]*Rule, 0, len(p.info.Rules))
+	for _, info := range p.info.Rules {
+		r, err := newRule(p, info)
+		if err != nil {
+			return nil, fmt.Errorf("plugin %s: %w", p.opts.Name, err)
+		}
+		result = append(result, r)
+	}
+	return result, nil
+}
+
+func (p *Plugin) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.proc != nil {
+		p.proc.shutdown()
+		p.proc = nil
+	}
+}
+
+func (p *Plugin) call(method string, params, result any) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.failures >= maxFailures {
+		return fmt.Errorf("disabled after %d failures", maxFailures)
+	}
+	if p.proc == nil {
+		proc, err := spawn(p.opts)
+		if err != nil {
+			p.failures++
+			return err
+		}
+		p.proc = proc
+	}
+
+	err := p.proc.call(method, params, result, p.opts.Timeout)
+	var rpcErr *RPCError
+	switch {
+	case err == nil:
+		p.failures = 0
+	case !errors.As(err, &rpcErr):
+		p.proc.kill()
+		p.proc = nil
+		p.failures++
+	}
+	return err
+}
+
+type process struct {
+	cmd       *exec.Cmd
+	stdin     io.WriteCloser
+	responses chan []byte
+	stderr    *tailBuffer
+	nextID    int
+	waited    sync.Once
+}
+
+func spawn(opts Options) (*process, error) {
+	cmd := exec.Command(opts.Command, opts.Args...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	stderr := &tailBuffer{}
+	cmd.Stderr = stderr
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start %s: %w", opts.Command, err)
+	}
+
+	p := &process{cmd: cmd, stdin: stdin, responses: make(chan []byte), stderr: stderr}
+	go func() {
+		defer close(p.responses)
+		reader := bufio.NewReader(stdout)
+		for {
+			line, err := reader.ReadBytes('\n')
+			if len(strings.TrimSpace(string(line))) > 0 {
+				p.responses <- line
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return p, nil
+}
+
+func (p *process) call(method string, params, result any, timeout time.Duration) error {
+	p.nextID++
+	id := p.nextID
+	data, err := json.Marshal(request{JSONRPC: "2.0", ID: id, Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+
+	written := make(chan error, 1)
+	go func() {
+		_, err := p.stdin.Write(append(data, '\n'))
+		written <- err
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case err := <-written:
+			if err != nil {
+				return fmt.Errorf("%s: %w%s", method, err, p.stderr.last())
+			}
+		case line, ok := <-p.responses:
+			if !ok {
+				p.waitFor(time.Second)
+				return fmt.Errorf("%s: plugin exited%s", method, p.stderr.last())
+			}
+			var resp response
+			if err := json.Unmarshal(line, &resp); err != nil {
+				return fmt.Errorf("%s: invalid response: %w", method, err)
+			}
+			if resp.ID != id {
+				continue
+			}
+			if resp.Error != nil {
+				return resp.Error
+			}
+			if result == nil {
+				return nil
+			}
+			if err := json.Unmarshal(resp.Result, result); err != nil {
+				return fmt.Errorf("%s: invalid result: %w", method, err)
+			}
+			return nil
+		case <-timer.C:
+			return fmt.Errorf("%s: timed out after %s", method, timeout)
+		}
+	}
+}
+
+func (p *process) shutdown() {
+	if data, err := json.Marshal(request{JSONRPC: "2.0", Method: "shutdown"}); err == nil {
+		p.stdin.Write(append(data, '\n'))
+	}
+	p.stdin.Close()
+	go func() {
+		for range p.responses {
+		}
+	}()
+
+	if !p.waitFor(2 * time.Second) {
+		p.cmd.Process.Kill()
+		p.wait()
+	}
+}
+
+func (p *process) kill() {
+	p.stdin.Close()
+	p.cmd.Process.Kill()
+	go func() {
+		for range p.responses {
+		}
+	}()
+	p.wait()
+}
+
+func (p *process) wait() {
+	p.waited.Do(func() { p.cmd.Wait() })
+}
+
+func (p *process) waitFor(timeout time.Duration) bool {
+	exited := make(chan struct{})
+	go func() {
+		p.wait()
+		close(exited)
+	}()
+	select {
+	case <-exited:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+type tailBuffer struct {
+	mu  sync.Mutex
+	buf []byte
+}
+
+func (b *tailBuffer) Write(data []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.buf = append(b.buf, data...)
+	if len(b.buf) > stderrLimit {
+		b.buf = b.buf[len(b.buf)-stderrLimit:]
+	}
+	return len(data), nil
+}
+
+func (b *tailBuffer) last() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	lines := strings.Split(strings.TrimSpace(string(b.buf)), "\n")
+	if line := strings.TrimSpace(lines[len(lines)-1]); line != "" {
+		return ": " + line
+	}
+	return ""
+}
@@ -0,0 +1,159 @@
+package plugin
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andiq123/sharpify/internal/rules"
+	"github.com/andiq123/sharpify/internal/scanner"
+	"github.com/andiq123/sharpify/internal/transformer"
+)
+
+var (
+	buildOnce  sync.Once
+	samplePath string
+	buildErr   error
+)
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if samplePath != "" {
+		os.RemoveAll(filepath.Dir(samplePath))
+	}
+	os.Exit(code)
+}
+
+func sample(t *testing.T) string {
+	t.Helper()
+	buildOnce.Do(func() {
+		dir, err := os.MkdirTemp("", "sharpify-plugin")
+		if err != nil {
+			buildErr = err
+			return
+		}
+		samplePath = filepath.Join(dir, "sample")
+		if runtime.GOOS == "windows" {
+			samplePath += ".exe"
+		}
+		out, err := exec.Command("go", "build", "-o", samplePath, "./testdata/sample").CombinedOutput()
+		if err != nil {
+			buildErr = err
+			t.Logf("%s", out)
+		}
+	})
+	if buildErr != nil {
+		t.Skipf("cannot build sample plugin: %v", buildErr)
+	}
+	return samplePath
+}
+
+func start(t *testing.T, timeout time.Duration, args ...string) (*Plugin, rules.Rule) {
+	t.Helper()
+	p, err := Start(Options{Name: "sample", Command: sample(t), Args: args, Timeout: timeout})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(p.Close)
+
+	pluginRules, err := p.Rules()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pluginRules) != 1 || pluginRules[0].Name() != "console-to-logger" {
+		t.Fatalf("unexpected rules: %+v", pluginRules)
+	}
+	return p, pluginRules[0]
+}
+
+const source = `class A
+{
+    void M()
+    {
+        // Console.WriteLine("commented");
+        Console.WriteLine("hello");
+        System.Console.WriteLine("qualified");
+    }
+}
+`
+
+func transform(r rules.Rule, content string) transformer.Result {
+	return transformer.New([]rules.Rule{r}).Transform(scanner.FileInfo{Path: "A.cs", Content: content})
+}
+
+func TestPluginRule(t *testing.T) {
+	_, r := start(t, 0)
+	vr := r.(rules.VersionedRule)
+	if vr.MinVersion() != rules.CSharp6 || !vr.IsSafe() {
+		t.Errorf("unexpected metadata: %v %v", vr.MinVersion(), vr.IsSafe())
+	}
+
+	got := transform(r, source)
+	if len(got.Failures) > 0 {
+		t.Fatalf("unexpected failures: %v", got.Failures)
+	}
+	want := strings.Replace(source, `Console.WriteLine("hello")`, `_logger.LogInformation("hello")`, 1)
+	if got.NewContent != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got.NewContent, want)
+	}
+
+	registry := transformer.NewRegistry()
+	registry.Register(r)
+	if _, ok := registry.Get("console-to-logger"); !ok {
+		t.Error("plugin rule not found in registry")
+	}
+}
+
+func TestPluginCrashIsIsolated(t *testing.T) {
+	_, r := start(t, 0, "-crash")
+
+	for i := 0; i < maxFailures+1; i++ {
+		got := transform(r, source)
+		if got.Changed {
+			t.Fatal("crashed plugin changed the file")
+		}
+		if len(got.Failures) != 1 {
+			t.Fatalf("run %d: expected one failure, got %v", i, got.Failures)
+		}
+		msg := got.Failures[0].Err.Error()
+		switch {
+		case i < maxFailures && !strings.Contains(msg, "crashed on purpose"):
+			t.Errorf("run %d: failure does not include the plugin's stderr: %s", i, msg)
+		case i >= maxFailures && !strings.Contains(msg, "disabled"):
+			t.Errorf("run %d: expected the plugin to be disabled: %s", i, msg)
+		}
+	}
+}
+
+func TestPluginTimeout(t *testing.T) {
+	_, r := start(t, 200*time.Millisecond, "-hang")
+
+	begin := time.Now()
+	got := transform(r, source)
+	if elapsed := time.Since(begin); elapsed > 5*time.Second {
+		t.Fatalf("timeout not enforced, took %s", elapsed)
+	}
+	if got.Changed || len(got.Failures) != 1 || !strings.Contains(got.Failures[0].Err.Error(), "timed out") {
+		t.Fatalf("unexpected result: changed=%v failures=%v", got.Changed, got.Failures)
+	}
+}
+
+func TestPluginInvalidEdits(t *testing.T) {
+	_, r := start(t, 0, "-bad")
+
+	got := transform(r, source)
+	if got.Changed || len(got.Failures) != 1 || !strings.Contains(got.Failures[0].Err.Error(), "invalid edit") {
+		t.Fatalf("unexpected result: changed=%v failures=%v", got.Changed, got.Failures)
+	}
+}
+
+func TestStartFailsForMissingCommand(t *testing.T) {
+	if _, err := Start(Options{Name: "missing", Command: filepath.Join(t.TempDir(), "nope")}); err == nil {
+		t.Fatal("expected an error")
+	}
+}
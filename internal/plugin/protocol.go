@@ -0,0 +1,81 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const ProtocolVersion = 1
+
+type request struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      int    `json:"id,omitempty"`
+	Method  string `json:"method"`
+	Params  any    `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      int             `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *RPCError       `json:"error,omitempty"`
+}
+
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("%s (code %d)", e.Message, e.Code)
+}
+
+type DescribeParams struct {
+	ProtocolVersion int `json:"protocolVersion"`
+}
+
+type DescribeResult struct {
+	Name  string     `json:"name"`
+	Rules []RuleInfo `json:"rules"`
+}
+
+type RuleInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	MinVersion  string `json:"minVersion,omitempty"`
+	Safe        bool   `json:"safe,omitempty"`
+	Severity    string `json:"severity,omitempty"`
+}
+
+type ApplyParams struct {
+	Rule         string        `json:"rule"`
+	Path         string        `json:"path"`
+	Content      string        `json:"content"`
+	Tokens       []Token       `json:"tokens"`
+	Declarations []Declaration `json:"declarations"`
+}
+
+type Token struct {
+	Kind string `json:"kind"`
+	Text string `json:"text"`
+	Pos  int    `json:"pos"`
+	End  int    `json:"end"`
+}
+
+type Declaration struct {
+	Name       string `json:"name"`
+	Kind       string `json:"kind"`
+	Type       string `json:"type,omitempty"`
+	Pos        int    `json:"pos"`
+	References []int  `json:"references"`
+}
+
+type ApplyResult struct {
+	Edits []Edit `json:"edits"`
+}
+
+type Edit struct {
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+	Text  string `json:"text"`
+}
@@ -0,0 +1,138 @@
+package plugin
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/andiq123/sharpify/internal/rules"
+	"github.com/andiq123/sharpify/internal/syntax"
+)
+
+type Rule struct {
+	plugin     *Plugin
+	info       RuleInfo
+	minVersion rules.CSharpVersion
+	severity   rules.Severity
+}
+
+func newRule(p *Plugin, info RuleInfo) (*Rule, error) {
+	if info.Name == "" {
+		return nil, fmt.Errorf("rule without a name")
+	}
+	r := &Rule{plugin: p, info: info, minVersion: rules.CSharp6}
+	if info.MinVersion != "" {
+		v, ok := rules.ParseVersion(info.MinVersion)
+		if !ok {
+			return nil, fmt.Errorf("rule %s: unknown minVersion %q", info.Name, info.MinVersion)
+		}
+		r.minVersion = v
+	}
+	if info.Severity != "" {
+		s, err := rules.ParseSeverity(info.Severity)
+		if err != nil {
+			return nil, fmt.Errorf("rule %s: %w", info.Name, err)
+		}
+		r.severity = s
+	}
+	return r, nil
+}
+
+func (r *Rule) Name() string {
+	return r.info.Name
+}
+
+func (r *Rule) Description() string {
+	if r.info.Description == "" {
+		return fmt.Sprintf("%s (plugin %s)", r.info.Name, r.plugin.Name())
+	}
+	return r.info.Description
+}
+
+func (r *Rule) Plugin() string {
+	return r.plugin.Name()
+}
+
+func (r *Rule) MinVersion() rules.CSharpVersion {
+	return r.minVersion
+}
+
+func (r *Rule) IsSafe() bool {
+	return r.info.Safe
+}
+
+func (r *Rule) DefaultSeverity() rules.Severity {
+	return r.severity
+}
+
+func (r *Rule) RewritesLiterals() bool {
+	return true
+}
+
+func (r *Rule) Apply(content string) (string, bool) {
+	out, changed, _ := r.TryApply("", content)
+	return out, changed
+}
+
+func (r *Rule) TryApply(path, content string) (string, bool, error) {
+	params := ApplyParams{
+		Rule:         r.info.Name,
+		Path:         path,
+		Content:      content,
+		Tokens:       tokens(content),
+		Declarations: declarations(content),
+	}
+	var result ApplyResult
+	if err := r.plugin.call("apply", params, &result); err != nil {
+		return content, false, fmt.Errorf("plugin %s: %w", r.plugin.Name(), err)
+	}
+	if len(result.Edits) == 0 {
+		return content, false, nil
+	}
+
+	out, err := applyEdits(content, result.Edits)
+	if err != nil {
+		return content, false, fmt.Errorf("plugin %s: %w", r.plugin.Name(), err)
+	}
+	return out, out != content, nil
+}
+
+func applyEdits(content string, edits []Edit) (string, error) {
+	sorted := append([]Edit(nil), edits...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Start < sorted[j].Start })
+
+	var b []byte
+	last := 0
+	for _, e := range sorted {
+		if e.Start < last || e.End < e.Start || e.End > len(content) {
+			return "", fmt.Errorf("invalid edit [%d, %d) for %d bytes of content", e.Start, e.End, len(content))
+		}
+		b = append(b, content[last:e.Start]...)
+		b = append(b, e.Text...)
+		last = e.End
+	}
+	b = append(b, content[last:]...)
+	return string(b), nil
+}
+
+func tokens(content string) []Token {
+	toks := syntax.Lex(content)
+	result := make([]Token, len(toks))
+	for i, t := range toks {
+		result[i] = Token{Kind: t.Kind.String(), Text: t.Text, Pos: t.Pos, End: t.End}
+	}
+	return result
+}
+
+func declarations(content string) []Declaration {
+	b := syntax.Bind(content)
+	decls := b.Declarations()
+	result := make([]Declaration, len(decls))
+	for i, d := range decls {
+		refs := b.References(d)
+		if refs == nil {
+			refs = []int{}
+		}
+		result[i] = Declaration{Name: d.Name, Kind: d.Kind.String(), Type: d.Type, Pos: d.Pos, References: refs}
+	}
+	return result
+}
@@ -0,0 +1,127 @@
+// Command sample is a Sharpify plugin used by the plugin tests. It reads
+// newline-delimited JSON-RPC 2.0 requests on stdin and answers on stdout.
+//
+// It provides one rule, console-to-logger, which rewrites Console.WriteLine
+// calls to _logger.LogInformation using the tokens Sharpify sends along with
+// the file content. Flags make it misbehave so crash handling can be tested:
+//
+//	-crash   exit while handling apply
+//	-hang    never answer apply
+//	-bad     answer apply with an out-of-range edit
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+)
+
+type request struct {
+	ID     int             `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+type token struct {
+	Kind string `json:"kind"`
+	Text string `json:"text"`
+	Pos  int    `json:"pos"`
+	End  int    `json:"end"`
+}
+
+type applyParams struct {
+	Rule    string  `json:"rule"`
+	Content string  `json:"content"`
+	Tokens  []token `json:"tokens"`
+}
+
+type edit struct {
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+	Text  string `json:"text"`
+}
+
+func main() {
+	crash := flag.Bool("crash", false, "exit while handling apply")
+	hang := flag.Bool("hang", false, "never answer apply")
+	bad := flag.Bool("bad", false, "return an invalid edit")
+	flag.Parse()
+
+	in := bufio.NewScanner(os.Stdin)
+	in.Buffer(make([]byte, 1024*1024), 64*1024*1024)
+	out := json.NewEncoder(os.Stdout)
+
+	for in.Scan() {
+		var req request
+		if err := json.Unmarshal(in.Bytes(), &req); err != nil {
+			fmt.Fprintln(os.Stderr, "bad request:", err)
+			continue
+		}
+
+		switch req.Method {
+		case "describe":
+			reply(out, req.ID, map[string]any{
+				"name": "sample",
+				"rules": []map[string]any{{
+					"name":        "console-to-logger",
+					"description": "Use ILogger instead of Console.WriteLine",
+					"minVersion":  "6",
+					"safe":        true,
+				}},
+			})
+		case "apply":
+			var p applyParams
+			if err := json.Unmarshal(req.Params, &p); err != nil {
+				fail(out, req.ID, err.Error())
+				continue
+			}
+			switch {
+			case *crash:
+				fmt.Fprintln(os.Stderr, "sample plugin crashed on purpose")
+				os.Exit(2)
+			case *hang:
+				time.Sleep(time.Hour)
+			case *bad:
+				reply(out, req.ID, map[string]any{"edits": []edit{{Start: 0, End: len(p.Content) + 10, Text: "x"}}})
+				continue
+			}
+			reply(out, req.ID, map[string]any{"edits": consoleEdits(p.Tokens)})
+		case "shutdown":
+			return
+		default:
+			fail(out, req.ID, "unknown method "+req.Method)
+		}
+	}
+}
+
+func consoleEdits(tokens []token) []edit {
+	var significant []token
+	for _, t := range tokens {
+		if t.Kind != "comment" && t.Kind != "preprocessor" {
+			significant = append(significant, t)
+		}
+	}
+
+	edits := []edit{}
+	for i := 0; i+3 < len(significant); i++ {
+		t := significant[i : i+4]
+		if t[0].Text == "Console" && t[1].Text == "." && t[2].Text == "WriteLine" && t[3].Text == "(" {
+			if i > 0 && significant[i-1].Text == "." {
+				continue
+			}
+			edits = append(edits, edit{Start: t[0].Pos, End: t[2].End, Text: "_logger.LogInformation"})
+		}
+	}
+	return edits
+}
+
+func reply(out *json.Encoder, id int, result any) {
+	out.Encode(map[string]any{"jsonrpc": "2.0", "id": id, "result": result})
+}
+
+func fail(out *json.Encoder, id int, message string) {
+	out.Encode(map[string]any{"jsonrpc": "2.0", "id": id, "error": map[string]any{"code": -32601, "message": message}})
+}
@@ -0,0 +1,126 @@
+package project
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var baseImplicitUsings = []string{
+	"System",
+	"System.Collections.Generic",
+	"System.IO",
+	"System.Linq",
+	"System.Net.Http",
+	"System.Threading",
+	"System.Threading.Tasks",
+}
+
+var sdkImplicitUsings = map[string][]string{
+	"Microsoft.NET.Sdk.Web": {
+		"System.Net.Http.Json",
+		"Microsoft.AspNetCore.Builder",
+		"Microsoft.AspNetCore.Hosting",
+		"Microsoft.AspNetCore.Http",
+		"Microsoft.AspNetCore.Routing",
+		"Microsoft.Extensions.Configuration",
+		"Microsoft.Extensions.DependencyInjection",
+		"Microsoft.Extensions.Hosting",
+		"Microsoft.Extensions.Logging",
+	},
+	"Microsoft.NET.Sdk.Worker": {
+		"Microsoft.Extensions.Configuration",
+		"Microsoft.Extensions.DependencyInjection",
+		"Microsoft.Extensions.Hosting",
+		"Microsoft.Extensions.Logging",
+	},
+	"Microsoft.NET.Sdk.BlazorWebAssembly": {
+		"System.Net.Http.Json",
+		"Microsoft.AspNetCore.Components.Forms",
+		"Microsoft.AspNetCore.Components.Routing",
+		"Microsoft.AspNetCore.Components.Web",
+		"Microsoft.AspNetCore.Components.WebAssembly.Hosting",
+		"Microsoft.Extensions.Configuration",
+		"Microsoft.Extensions.DependencyInjection",
+		"Microsoft.Extensions.Logging",
+	},
+}
+
+func (p *Project) ImplicitUsingsEnabled() bool {
+	switch strings.ToLower(p.ImplicitUsings) {
+	case "enable", "true":
+		return p.SupportsImplicitUsings()
+	}
+	return false
+}
+
+func (p *Project) ImplicitUsingsDisabled() bool {
+	switch strings.ToLower(p.ImplicitUsings) {
+	case "disable", "false":
+		return true
+	}
+	return false
+}
+
+func (p *Project) SupportsImplicitUsings() bool {
+	tfm := strings.ToLower(p.TargetFramework)
+	if !strings.HasPrefix(tfm, "net") || strings.HasPrefix(tfm, "netstandard") || strings.HasPrefix(tfm, "netcoreapp") {
+		return false
+	}
+	version, _, dotted := strings.Cut(strings.TrimPrefix(tfm, "net"), ".")
+	if !dotted {
+		return false
+	}
+	major, err := strconv.Atoi(version)
+	return err == nil && major >= 6
+}
+
+func (p *Project) SdkImplicitUsings() []string {
+	set := make(map[string]bool)
+	for _, ns := range baseImplicitUsings {
+		set[ns] = true
+	}
+	for _, ns := range sdkImplicitUsings[p.Sdk] {
+		set[ns] = true
+	}
+	if p.UseWindowsForms {
+		set["System.Drawing"] = true
+		set["System.Windows.Forms"] = true
+	}
+	if p.UseWPF && !p.UseWindowsForms {
+		delete(set, "System.IO")
+		delete(set, "System.Net.Http")
+	}
+	return sortedKeys(set)
+}
+
+func (p *Project) ImplicitUsingSet() map[string]bool {
+	set := make(map[string]bool)
+	if p.ImplicitUsingsEnabled() {
+		for _, ns := range p.SdkImplicitUsings() {
+			set[ns] = true
+		}
+	}
+	for _, u := range p.Usings {
+		switch {
+		case u.Remove != "":
+			delete(set, u.Remove)
+			delete(set, "static "+u.Remove)
+		case u.Include == "" || u.Alias != "":
+		case u.Static:
+			set["static "+u.Include] = true
+		default:
+			set[u.Include] = true
+		}
+	}
+	return set
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
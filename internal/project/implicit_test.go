@@ -0,0 +1,30 @@
+package project
+
+import "testing"
+
+func TestSupportsImplicitUsings(t *testing.T) {
+	tests := []struct {
+		tfm  string
+		want bool
+	}{
+		{"net6.0", true},
+		{"net8.0", true},
+		{"net8.0-windows", true},
+		{"NET10.0", true},
+		{"net5.0", false},
+		{"net20", false},
+		{"net35", false},
+		{"net48", false},
+		{"net481", false},
+		{"net6", false},
+		{"netcoreapp3.1", false},
+		{"netstandard2.0", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		p := &Project{TargetFramework: tt.tfm}
+		if got := p.SupportsImplicitUsings(); got != tt.want {
+			t.Errorf("SupportsImplicitUsings(%q) = %v, want %v", tt.tfm, got, tt.want)
+		}
+	}
+}
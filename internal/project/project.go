@@ -0,0 +1,243 @@
+package project
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type Project struct {
+	Path            string
+	Dir             string
+	Sdk             string
+	ImplicitUsings  string
+	LangVersion     string
+	Nullable        string
+	TargetFramework string
+	UseWPF          bool
+	UseWindowsForms bool
+	Usings          []Using
+	Content         string
+}
+
+type Using struct {
+	Include string
+	Remove  string
+	Alias   string
+	Static  bool
+}
+
+type projectXML struct {
+	Sdk  string `xml:"Sdk,attr"`
+	Sdks []struct {
+		Name string `xml:"Name,attr"`
+	} `xml:"Sdk"`
+	PropertyGroups []struct {
+		ImplicitUsings   string `xml:"ImplicitUsings"`
+		LangVersion      string `xml:"LangVersion"`
+		Nullable         string `xml:"Nullable"`
+		TargetFramework  string `xml:"TargetFramework"`
+		TargetFrameworks string `xml:"TargetFrameworks"`
+		UseWPF           string `xml:"UseWPF"`
+		UseWindowsForms  string `xml:"UseWindowsForms"`
+	} `xml:"PropertyGroup"`
+	ItemGroups []struct {
+		Usings []struct {
+			Include string `xml:"Include,attr"`
+			Remove  string `xml:"Remove,attr"`
+			Alias   string `xml:"Alias,attr"`
+			Static  string `xml:"Static,attr"`
+		} `xml:"Using"`
+	} `xml:"ItemGroup"`
+}
+
+func Load(path string) (*Project, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Project{}
+	if props := findBuildProps(filepath.Dir(path)); props != "" {
+		if propsData, err := os.ReadFile(props); err == nil {
+			if err := p.parse(props, propsData); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := p.parse(path, data); err != nil {
+		return nil, err
+	}
+	p.Path = path
+	p.Dir = filepath.Dir(path)
+	p.Content = string(data)
+	return p, nil
+}
+
+func Parse(path string, data []byte) (*Project, error) {
+	p := &Project{
+		Path:    path,
+		Dir:     filepath.Dir(path),
+		Content: string(data),
+	}
+	if err := p.parse(path, data); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func findBuildProps(dir string) string {
+	for {
+		path := filepath.Join(dir, "Directory.Build.props")
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+func (p *Project) parse(path string, data []byte) error {
+	var doc projectXML
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	if sdk := strings.TrimSpace(doc.Sdk); sdk != "" {
+		p.Sdk = sdk
+	}
+	if p.Sdk == "" && len(doc.Sdks) > 0 {
+		p.Sdk = strings.TrimSpace(doc.Sdks[0].Name)
+	}
+	if i := strings.Index(p.Sdk, "/"); i != -1 {
+		p.Sdk = p.Sdk[:i]
+	}
+
+	for _, g := range doc.PropertyGroups {
+		if v := strings.TrimSpace(g.ImplicitUsings); v != "" {
+			p.ImplicitUsings = v
+		}
+		if v := strings.TrimSpace(g.LangVersion); v != "" {
+			p.LangVersion = v
+		}
+		if v := strings.TrimSpace(g.Nullable); v != "" {
+			p.Nullable = v
+		}
+		if v := strings.TrimSpace(g.TargetFramework); v != "" {
+			p.TargetFramework = v
+		}
+		if v := strings.TrimSpace(g.TargetFrameworks); v != "" && strings.TrimSpace(g.TargetFramework) == "" {
+			p.TargetFramework = strings.TrimSpace(strings.Split(v, ";")[0])
+		}
+		if v := strings.TrimSpace(g.UseWPF); v != "" {
+			p.UseWPF = strings.EqualFold(v, "true")
+		}
+		if v := strings.TrimSpace(g.UseWindowsForms); v != "" {
+			p.UseWindowsForms = strings.EqualFold(v, "true")
+		}
+	}
+
+	for _, g := range doc.ItemGroups {
+		for _, u := range g.Usings {
+			p.Usings = append(p.Usings, Using{
+				Include: strings.TrimSpace(u.Include),
+				Remove:  strings.TrimSpace(u.Remove),
+				Alias:   strings.TrimSpace(u.Alias),
+				Static:  strings.EqualFold(strings.TrimSpace(u.Static), "true"),
+			})
+		}
+	}
+	return nil
+}
+
+func (p *Project) Name() string {
+	return strings.TrimSuffix(filepath.Base(p.Path), filepath.Ext(p.Path))
+}
+
+func (p *Project) SourceFiles() ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(p.Dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			switch d.Name() {
+			case "bin", "obj", ".git", "node_modules":
+				return filepath.SkipDir
+			}
+			if path != p.Dir && FindProjectFile(path) != "" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.EqualFold(filepath.Ext(path), ".cs") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files, err
+}
+
+func FindProjectFile(dir string) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+	for _, e := range entries {
+		if !e.IsDir() && strings.EqualFold(filepath.Ext(e.Name()), ".csproj") {
+			return filepath.Join(dir, e.Name())
+		}
+	}
+	return ""
+}
+
+type Locator struct {
+	owners   map[string]string
+	projects map[string]*Project
+}
+
+func NewLocator() *Locator {
+	return &Locator{
+		owners:   make(map[string]string),
+		projects: make(map[string]*Project),
+	}
+}
+
+func (l *Locator) Owner(file string) *Project {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return nil
+	}
+	path := l.ownerOf(filepath.Dir(abs))
+	if path == "" {
+		return nil
+	}
+	if p, ok := l.projects[path]; ok {
+		return p
+	}
+	p, err := Load(path)
+	if err != nil {
+		p = nil
+	}
+	l.projects[path] = p
+	return p
+}
+
+func (l *Locator) ownerOf(dir string) string {
+	if path, ok := l.owners[dir]; ok {
+		return path
+	}
+	path := FindProjectFile(dir)
+	if path == "" {
+		if parent := filepath.Dir(dir); parent != dir {
+			path = l.ownerOf(parent)
+		}
+	}
+	l.owners[dir] = path
+	return path
+}
@@ -0,0 +1,161 @@
+package report
+
+import (
+	"html/template"
+	"io"
+	"strings"
+)
+
+type row struct {
+	LeftNo, RightNo int
+	Left, Right     string
+	LeftKind        string
+	RightKind       string
+}
+
+func rows(c Change) []row {
+	var result []row
+	oldNo := c.OldLine - len(c.Leading)
+	newNo := c.NewLine - len(c.Leading)
+	for _, l := range c.Leading {
+		result = append(result, row{LeftNo: oldNo, RightNo: newNo, Left: l, Right: l, LeftKind: "ctx", RightKind: "ctx"})
+		oldNo++
+		newNo++
+	}
+	for i := 0; i < len(c.Before) || i < len(c.After); i++ {
+		r := row{LeftKind: "empty", RightKind: "empty"}
+		if i < len(c.Before) {
+			r.LeftNo, r.Left, r.LeftKind = oldNo, c.Before[i], "del"
+			oldNo++
+		}
+		if i < len(c.After) {
+			r.RightNo, r.Right, r.RightKind = newNo, c.After[i], "add"
+			newNo++
+		}
+		result = append(result, r)
+	}
+	for _, l := range c.Trailing {
+		result = append(result, row{LeftNo: oldNo, RightNo: newNo, Left: l, Right: l, LeftKind: "ctx", RightKind: "ctx"})
+		oldNo++
+		newNo++
+	}
+	return result
+}
+
+func anchor(s string) string {
+	return "f-" + strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '-'
+	}, s)
+}
+
+var page = template.Must(template.New("report").Funcs(template.FuncMap{
+	"rows":   rows,
+	"anchor": anchor,
+	"tabs":   func(s string) string { return strings.ReplaceAll(s, "\t", "    ") },
+}).Parse(pageTemplate))
+
+func WriteHTML(w io.Writer, r *Report) error {
+	return page.Execute(w, r)
+}
+
+const pageTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Sharpify report</title>
+<style>
+:root { --fg: #1f2328; --muted: #656d76; --border: #d0d7de; --bg: #f6f8fa; --accent: #7c3aed;
+  --del: #ffebe9; --del-strong: #ffcecb; --add: #e6ffec; --add-strong: #abf2bc; }
+* { box-sizing: border-box; }
+body { margin: 0; font: 14px/1.5 -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; color: var(--fg); }
+header { padding: 24px 32px; background: var(--accent); color: #fff; }
+header h1 { margin: 0 0 4px; font-size: 24px; }
+header p { margin: 0; opacity: .85; }
+main { padding: 24px 32px; max-width: 1400px; }
+h2 { margin: 32px 0 12px; font-size: 18px; border-bottom: 1px solid var(--border); padding-bottom: 6px; }
+.cards { display: flex; flex-wrap: wrap; gap: 12px; }
+.card { border: 1px solid var(--border); border-radius: 8px; padding: 12px 16px; min-width: 140px; background: var(--bg); }
+.card b { display: block; font-size: 24px; }
+.card span { color: var(--muted); }
+table.list { border-collapse: collapse; width: 100%; }
+table.list th, table.list td { text-align: left; padding: 6px 10px; border-bottom: 1px solid var(--border); vertical-align: top; }
+table.list th { background: var(--bg); }
+code, .code { font-family: ui-monospace, SFMono-Regular, Menlo, Consolas, monospace; font-size: 12px; }
+.tag { display: inline-block; padding: 0 8px; margin: 0 4px 4px 0; border-radius: 12px; background: var(--bg); border: 1px solid var(--border); font-size: 12px; }
+.safe { color: #1a7f37; } .unsafe { color: #9a6700; }
+.badge { background: #fff3; border-radius: 12px; padding: 2px 10px; margin-left: 8px; font-size: 12px; }
+details.file { border: 1px solid var(--border); border-radius: 8px; margin: 12px 0; }
+details.file > summary { padding: 10px 14px; background: var(--bg); cursor: pointer; border-radius: 8px; }
+details.file[open] > summary { border-bottom: 1px solid var(--border); border-radius: 8px 8px 0 0; }
+.file-body { padding: 10px 14px; }
+.diags { margin: 0 0 10px; padding-left: 20px; }
+.failure { color: #cf222e; }
+table.diff { border-collapse: collapse; width: 100%; table-layout: fixed; margin: 8px 0 16px; border: 1px solid var(--border); }
+table.diff td { padding: 0 8px; white-space: pre-wrap; word-break: break-all; vertical-align: top; }
+table.diff td.no { width: 48px; text-align: right; color: var(--muted); user-select: none; background: var(--bg); }
+table.diff td.del { background: var(--del); } table.diff td.no.del { background: var(--del-strong); }
+table.diff td.add { background: var(--add); } table.diff td.no.add { background: var(--add-strong); }
+table.diff td.empty { background: var(--bg); }
+table.diff td.split { border-left: 1px solid var(--border); }
+.muted { color: var(--muted); }
+</style>
+</head>
+<body>
+<header>
+<h1>Sharpify report{{if .DryRun}}<span class="badge">dry run</span>{{end}}</h1>
+<p>{{.Root}} · {{.Generated.Format "2006-01-02 15:04 MST"}}</p>
+</header>
+<main>
+<div class="cards">
+<div class="card"><b>{{.Summary.Files}}</b><span>files scanned</span></div>
+<div class="card"><b>{{.Summary.Changed}}</b><span>files {{if .DryRun}}to change{{else}}changed{{end}}</span></div>
+<div class="card"><b>{{.Summary.Changes}}</b><span>changes</span></div>
+<div class="card"><b>{{.Summary.Findings}}</b><span>suggestions</span></div>
+<div class="card"><b>{{.Summary.Failures}}</b><span>rolled back</span></div>
+</div>
+
+<h2>By rule</h2>
+<table class="list">
+<tr><th>Rule</th><th>Files</th><th>Suggestions</th><th>Min version</th><th>Safety</th></tr>
+{{range .Rules}}{{if or .Files .Findings}}<tr><td><a href="#rule-{{.Name}}"><code>{{.Name}}</code></a></td><td>{{.Files}}</td><td>{{.Findings}}</td><td>{{or .MinVersion "Any"}}</td><td>{{if .Safe}}<span class="safe">safe</span>{{else}}<span class="unsafe">review</span>{{end}}</td></tr>
+{{end}}{{end}}</table>
+
+<h2>By C# version</h2>
+<table class="list">
+<tr><th>Version</th><th>Files</th><th>Rules</th></tr>
+{{range .Versions}}<tr><td>{{.Version}}</td><td>{{.Files}}</td><td>{{range .Rules}}<span class="tag">{{.}}</span>{{end}}</td></tr>
+{{else}}<tr><td colspan="3" class="muted">No rules applied</td></tr>
+{{end}}</table>
+
+<h2>Files</h2>
+<table class="list">
+<tr><th>File</th><th>Rules</th><th>Changes</th><th>Suggestions</th></tr>
+{{range .Files}}<tr><td><a href="#{{anchor .Path}}"><code>{{.Path}}</code></a></td><td>{{range .Applied}}<span class="tag">{{.Name}}</span>{{end}}</td><td>{{len .Changes}}</td><td>{{len .Diagnostics}}</td></tr>
+{{else}}<tr><td colspan="4" class="muted">All files are already up to date</td></tr>
+{{end}}</table>
+
+<h2>Changes</h2>
+{{range .Files}}<details class="file" id="{{anchor .Path}}" open>
+<summary><code>{{.Path}}</code> <span class="muted">· {{len .Changes}} change(s)</span></summary>
+<div class="file-body">
+{{if .Applied}}<div>{{range .Applied}}<span class="tag" title="{{.Description}}">{{.Name}}</span>{{end}}</div>{{end}}
+{{if .Diagnostics}}<ul class="diags">{{range .Diagnostics}}<li{{if eq .Kind.String "failure"}} class="failure"{{end}}>{{if .Line}}line {{.Line}}: {{end}}{{.Message}} <span class="muted">({{.Rule}}, {{.Severity}})</span></li>{{end}}</ul>{{end}}
+{{range .Changes}}<table class="diff code">
+{{range rows .}}<tr><td class="no {{.LeftKind}}">{{if .LeftNo}}{{.LeftNo}}{{end}}</td><td class="{{.LeftKind}}">{{tabs .Left}}</td><td class="no split {{.RightKind}}">{{if .RightNo}}{{.RightNo}}{{end}}</td><td class="{{.RightKind}}">{{tabs .Right}}</td></tr>
+{{end}}</table>
+{{end}}</div>
+</details>
+{{end}}
+<h2>Rule documentation</h2>
+<table class="list">
+<tr><th>Rule</th><th>Description</th><th>Min version</th><th>Safety</th><th>Severity</th></tr>
+{{range .Rules}}<tr id="rule-{{.Name}}"><td><code>{{.Name}}</code></td><td>{{.Description}}</td><td>{{or .MinVersion "Any"}}</td><td>{{if .Safe}}<span class="safe">safe</span>{{else}}<span class="unsafe">needs review</span>{{end}}</td><td>{{.Severity}}</td></tr>
+{{end}}</table>
+</main>
+</body>
+</html>
+`
@@ -0,0 +1,224 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/andiq123/sharpify/internal/diff"
+	"github.com/andiq123/sharpify/pkg/sharpify"
+)
+
+const contextLines = 3
+
+type Report struct {
+	Root      string    `json:"root"`
+	Generated time.Time `json:"generated"`
+	DryRun    bool      `json:"dryRun"`
+	Summary   Summary   `json:"summary"`
+	Versions  []Version `json:"versions"`
+	Rules     []Rule    `json:"rules"`
+	Files     []File    `json:"files"`
+}
+
+type Summary struct {
+	Files    int `json:"files"`
+	Changed  int `json:"changed"`
+	Changes  int `json:"changes"`
+	Findings int `json:"findings"`
+	Failures int `json:"failures"`
+}
+
+type Version struct {
+	Version string   `json:"version"`
+	Rules   []string `json:"rules"`
+	Files   int      `json:"files"`
+}
+
+type Rule struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	MinVersion  string `json:"minVersion,omitempty"`
+	Safe        bool   `json:"safe"`
+	Severity    string `json:"severity"`
+	Files       int    `json:"files"`
+	Findings    int    `json:"findings"`
+}
+
+type File struct {
+	Path        string                 `json:"path"`
+	Changed     bool                   `json:"changed"`
+	Applied     []sharpify.AppliedRule `json:"applied,omitempty"`
+	Diagnostics []sharpify.Diagnostic  `json:"diagnostics,omitempty"`
+	Changes     []Change               `json:"changes,omitempty"`
+	scanned     bool
+}
+
+type Change struct {
+	OldLine  int      `json:"oldLine"`
+	NewLine  int      `json:"newLine"`
+	Leading  []string `json:"leading,omitempty"`
+	Before   []string `json:"before"`
+	After    []string `json:"after"`
+	Trailing []string `json:"trailing,omitempty"`
+}
+
+func New(root string, results []sharpify.Result, selected []sharpify.Rule, severities map[string]sharpify.Severity, dryRun bool) *Report {
+	r := &Report{Root: root, Generated: time.Now().UTC(), DryRun: dryRun}
+
+	ruleIndex := make(map[string]int, len(selected))
+	for _, rule := range selected {
+		info := sharpify.InfoOf(rule)
+		severity := info.Severity
+		if s, ok := severities[info.Name]; ok {
+			severity = s
+		}
+		entry := Rule{Name: info.Name, Description: info.Description, Safe: info.Safe, Severity: severity.String()}
+		if info.MinVersion > 0 {
+			entry.MinVersion = info.MinVersion.String()
+		}
+		ruleIndex[info.Name] = len(r.Rules)
+		r.Rules = append(r.Rules, entry)
+	}
+
+	for _, res := range results {
+		if res.Scanned {
+			r.Summary.Files++
+		}
+		if !res.Changed && len(res.Diagnostics) == 0 {
+			continue
+		}
+		f := File{Path: rel(root, res.Path), Changed: res.Changed, Applied: res.Applied, Diagnostics: res.Diagnostics, scanned: res.Scanned}
+		if res.Changed {
+			f.Changes = changes(res.Original, res.Content)
+			r.Summary.Changed++
+			r.Summary.Changes += len(f.Changes)
+		}
+		for _, a := range res.Applied {
+			if i, ok := ruleIndex[a.Name]; ok && res.Scanned {
+				r.Rules[i].Files++
+			}
+		}
+		for _, d := range res.Diagnostics {
+			if d.Kind == sharpify.DiagnosticFailure {
+				r.Summary.Failures++
+				continue
+			}
+			r.Summary.Findings++
+			if i, ok := ruleIndex[d.Rule]; ok {
+				r.Rules[i].Findings++
+			}
+		}
+		r.Files = append(r.Files, f)
+	}
+	sort.Slice(r.Files, func(i, j int) bool { return r.Files[i].Path < r.Files[j].Path })
+	r.Versions = versions(r.Rules, r.Files)
+	return r
+}
+
+func changes(before, after string) []Change {
+	lines := diff.Lines(before)
+	var result []Change
+	hunks := diff.Hunks(before, after)
+	for i, h := range hunks {
+		prevEnd, nextStart := 0, len(lines)
+		if i > 0 {
+			prevEnd = hunks[i-1].OldEnd
+		}
+		if i+1 < len(hunks) {
+			nextStart = hunks[i+1].OldStart
+		}
+		start := max(h.OldStart-contextLines, prevEnd)
+		end := min(h.OldEnd+contextLines, nextStart)
+		result = append(result, Change{
+			OldLine:  h.OldStart + 1,
+			NewLine:  h.NewStart + 1,
+			Leading:  trim(lines[start:h.OldStart]),
+			Before:   trim(h.Old),
+			After:    trim(h.New),
+			Trailing: trim(lines[h.OldEnd:end]),
+		})
+	}
+	return result
+}
+
+func versions(rules []Rule, files []File) []Version {
+	byVersion := make(map[string]*Version)
+	ruleVersion := make(map[string]string, len(rules))
+	for _, rule := range rules {
+		if rule.Files == 0 && rule.Findings == 0 {
+			continue
+		}
+		version := rule.MinVersion
+		if version == "" {
+			version = "Any"
+		}
+		ruleVersion[rule.Name] = version
+		v, ok := byVersion[version]
+		if !ok {
+			v = &Version{Version: version}
+			byVersion[version] = v
+		}
+		v.Rules = append(v.Rules, rule.Name)
+	}
+	for _, f := range files {
+		if !f.scanned {
+			continue
+		}
+		seen := make(map[string]bool)
+		for _, a := range f.Applied {
+			if version, ok := ruleVersion[a.Name]; ok && !seen[version] {
+				seen[version] = true
+				byVersion[version].Files++
+			}
+		}
+	}
+
+	result := make([]Version, 0, len(byVersion))
+	for _, v := range byVersion {
+		result = append(result, *v)
+	}
+	sort.Slice(result, func(i, j int) bool { return versionKey(result[i].Version) < versionKey(result[j].Version) })
+	return result
+}
+
+func versionKey(v string) string {
+	if v == "Any" {
+		return ""
+	}
+	var major int
+	fmt.Sscanf(strings.TrimPrefix(v, "C# "), "%d", &major)
+	return fmt.Sprintf("%03d", major)
+}
+
+func trim(lines []string) []string {
+	result := make([]string, len(lines))
+	for i, l := range lines {
+		result[i] = strings.TrimRight(l, "\r\n")
+	}
+	return result
+}
+
+func rel(root, path string) string {
+	r, err := filepath.Rel(root, path)
+	if err != nil || strings.HasPrefix(r, "..") {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(r)
+}
+
+func Write(w io.Writer, r *Report, format string) error {
+	switch strings.ToLower(format) {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	case "html":
+		return WriteHTML(w, r)
+	}
+	return fmt.Errorf("unknown format %q (want text, json or html)", format)
+}
@@ -0,0 +1,98 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/andiq123/sharpify/pkg/sharpify"
+)
+
+func testReport() *Report {
+	before := "class A\n{\n    int a;\n    List<int> x = new List<int>();\n    int b;\n    int c;\n    int d;\n    int e;\n    int f;\n    int g;\n    string s = \"x\" + y;\n}\n"
+	after := strings.Replace(strings.Replace(before, "new List<int>()", "[]", 1), `"x" + y`, `$"x{y}"`, 1)
+	selected := []sharpify.Rule{
+		sharpify.NewRule("collection-expression", "Use collection expressions", sharpify.CSharp12, true, nil),
+		sharpify.NewRule("string-interpolation", "Use string interpolation", sharpify.CSharp6, false, nil),
+		sharpify.NewRule("unused", "Never applied", 0, true, nil),
+	}
+	results := []sharpify.Result{
+		{
+			Path: "/src/A.cs", Original: before, Content: after, Changed: true, Scanned: true,
+			Applied: []sharpify.AppliedRule{{Name: "collection-expression"}, {Name: "string-interpolation"}},
+			Diagnostics: []sharpify.Diagnostic{
+				{Kind: sharpify.DiagnosticFinding, Rule: "unused", Line: 3, Message: "Consider <this>"},
+			},
+		},
+		{Path: "/src/B.cs", Original: "class B { }\n", Content: "class B { }\n", Scanned: true},
+		{
+			Path: "/src/GlobalUsings.cs", Content: "global using System;\n", Changed: true,
+			Applied: []sharpify.AppliedRule{{Name: "collection-expression"}},
+		},
+	}
+	return New("/src", results, selected, map[string]sharpify.Severity{"unused": sharpify.SeveritySuggest}, true)
+}
+
+func TestNewSummarizesResults(t *testing.T) {
+	r := testReport()
+	if r.Summary != (Summary{Files: 2, Changed: 2, Changes: 3, Findings: 1}) {
+		t.Fatalf("summary = %+v", r.Summary)
+	}
+	if len(r.Files) != 2 || r.Files[0].Path != "A.cs" || r.Files[1].Path != "GlobalUsings.cs" {
+		t.Fatalf("files = %+v", r.Files)
+	}
+
+	first, second := r.Files[0].Changes[0], r.Files[0].Changes[1]
+	if first.OldLine != 4 || len(first.Leading) != 3 || first.After[0] != "    List<int> x = [];" {
+		t.Fatalf("first change = %+v", first)
+	}
+	if second.OldLine != 11 || second.Leading[0] != "    int e;" || len(second.Trailing) != 1 {
+		t.Fatalf("second change = %+v", second)
+	}
+
+	var versions []string
+	for _, v := range r.Versions {
+		versions = append(versions, v.Version)
+	}
+	if got := strings.Join(versions, ","); got != "Any,C# 6.0,C# 12.0" {
+		t.Fatalf("versions = %s", got)
+	}
+	if files := r.Versions[2].Files; files != 1 {
+		t.Fatalf("C# 12.0 files = %d, want 1", files)
+	}
+	if r.Rules[2].Severity != "suggest" || r.Rules[2].Findings != 1 || r.Rules[0].Files != 1 {
+		t.Fatalf("rules = %+v", r.Rules)
+	}
+}
+
+func TestWriteJSONAndHTML(t *testing.T) {
+	r := testReport()
+
+	var out bytes.Buffer
+	if err := Write(&out, r, "json"); err != nil {
+		t.Fatal(err)
+	}
+	var decoded Report
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil || len(decoded.Files[0].Changes) != 2 {
+		t.Fatalf("json round trip: %v, %+v", err, decoded)
+	}
+
+	out.Reset()
+	if err := Write(&out, r, "html"); err != nil {
+		t.Fatal(err)
+	}
+	html := out.String()
+	for _, want := range []string{"<style>", `class="del">    List&lt;int&gt; x = new List&lt;int&gt;();`, "Consider &lt;this&gt;", `id="rule-unused"`, "dry run"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("html is missing %q", want)
+		}
+	}
+	if strings.Contains(html, "http://") || strings.Contains(html, "https://") || strings.Contains(html, "<script") {
+		t.Error("html report should not reference external assets")
+	}
+
+	if err := Write(&out, r, "xml"); err == nil {
+		t.Fatal("expected an error for an unknown format")
+	}
+}
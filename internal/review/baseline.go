@@ -0,0 +1,199 @@
+package review
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/andiq123/sharpify/internal/diff"
+)
+
+const BaselineFile = ".sharpify/baseline.json"
+
+type Entry struct {
+	File        string `json:"file"`
+	Rule        string `json:"rule"`
+	Fingerprint string `json:"fingerprint"`
+	Line        string `json:"line,omitempty"`
+}
+
+type Baseline struct {
+	root    string
+	path    string
+	entries []Entry
+	index   map[Entry]bool
+	dirty   bool
+}
+
+func LoadBaseline(root string) (*Baseline, error) {
+	b := &Baseline{root: root, path: filepath.Join(root, BaselineFile), index: make(map[Entry]bool)}
+	data, err := os.ReadFile(b.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return b, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var file struct {
+		Entries []Entry `json:"entries"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	for _, e := range file.Entries {
+		b.add(e)
+	}
+	return b, nil
+}
+
+func FindBaseline(path string) (*Baseline, error) {
+	dir, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		dir = filepath.Dir(dir)
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, BaselineFile)); err == nil {
+			return LoadBaseline(dir)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+func OpenBaseline(path string) (*Baseline, error) {
+	b, err := FindBaseline(path)
+	if b != nil || err != nil {
+		return b, err
+	}
+	return LoadBaseline(baselineRoot(path))
+}
+
+func baselineRoot(path string) string {
+	start, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	if info, err := os.Stat(start); err != nil || !info.IsDir() {
+		start = filepath.Dir(start)
+	}
+	for dir := start; ; {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir
+		}
+		if slns, _ := filepath.Glob(filepath.Join(dir, "*.sln")); len(slns) > 0 {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return start
+		}
+		dir = parent
+	}
+}
+
+func (b *Baseline) Root() string {
+	return b.root
+}
+
+func (b *Baseline) Path() string {
+	return b.path
+}
+
+func (b *Baseline) Len() int {
+	return len(b.entries)
+}
+
+func (b *Baseline) Contains(file, rule string, h diff.Hunk) bool {
+	return b.index[key(file, rule, Fingerprint(h))]
+}
+
+func (b *Baseline) Suppresses(path, rule string, h diff.Hunk) bool {
+	root, err := filepath.Abs(b.root)
+	if err != nil {
+		return false
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil {
+		return false
+	}
+	return b.Contains(rel, rule, h)
+}
+
+func (b *Baseline) Add(file, rule string, h diff.Hunk) {
+	e := Entry{File: filepath.ToSlash(file), Rule: rule, Fingerprint: Fingerprint(h)}
+	for _, line := range h.Old {
+		if line = strings.TrimSpace(line); line != "" {
+			e.Line = line
+			break
+		}
+	}
+	if b.add(e) {
+		b.dirty = true
+	}
+}
+
+func (b *Baseline) add(e Entry) bool {
+	k := key(e.File, e.Rule, e.Fingerprint)
+	if b.index[k] {
+		return false
+	}
+	b.index[k] = true
+	b.entries = append(b.entries, e)
+	return true
+}
+
+func (b *Baseline) Save() error {
+	if !b.dirty {
+		return nil
+	}
+	sort.SliceStable(b.entries, func(i, j int) bool {
+		if b.entries[i].File != b.entries[j].File {
+			return b.entries[i].File < b.entries[j].File
+		}
+		return b.entries[i].Rule < b.entries[j].Rule
+	})
+	data, err := json.MarshalIndent(map[string]any{"entries": b.entries}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(b.path), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(b.path, append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	b.dirty = false
+	return nil
+}
+
+func Fingerprint(h diff.Hunk) string {
+	sum := sha256.New()
+	for _, line := range h.Old {
+		sum.Write([]byte(strings.TrimSpace(line) + "\n"))
+	}
+	sum.Write([]byte{0})
+	for _, line := range h.New {
+		sum.Write([]byte(strings.TrimSpace(line) + "\n"))
+	}
+	return hex.EncodeToString(sum.Sum(nil))[:16]
+}
+
+func key(file, rule, fingerprint string) Entry {
+	return Entry{File: filepath.ToSlash(file), Rule: rule, Fingerprint: fingerprint}
+}
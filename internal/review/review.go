@@ -0,0 +1,267 @@
+package review
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/andiq123/sharpify/internal/diff"
+	"github.com/andiq123/sharpify/internal/rules"
+	"github.com/andiq123/sharpify/internal/scanner"
+	"github.com/andiq123/sharpify/internal/syntax"
+	"github.com/andiq123/sharpify/internal/transformer"
+)
+
+type Decision int
+
+const (
+	Accept Decision = iota
+	Reject
+	RejectAndRemember
+	AcceptRule
+	SkipFile
+	Quit
+)
+
+type Change struct {
+	Path        string
+	RelPath     string
+	Rule        string
+	Description string
+	Hunk        diff.Hunk
+	Before      string
+	Index       int
+	Count       int
+}
+
+type File struct {
+	Path     string
+	Original string
+	Content  string
+	Applied  []string
+	Failures []transformer.RuleFailure
+}
+
+func (f File) Changed() bool {
+	return f.Content != f.Original
+}
+
+type Summary struct {
+	Files      []File
+	Accepted   int
+	Rejected   int
+	Suppressed int
+	Remembered int
+	Quit       bool
+}
+
+type Reviewer struct {
+	Root       string
+	Rules      []rules.Rule
+	Severities map[string]rules.Severity
+	Baseline   *Baseline
+
+	Prompt         func(Change) Decision
+	ConfirmProject func(rule rules.Rule, files []string) Decision
+
+	acceptRule map[string]bool
+	summary    Summary
+}
+
+func (r *Reviewer) Review(files []scanner.FileInfo) Summary {
+	r.acceptRule = make(map[string]bool)
+	r.summary = Summary{}
+
+	base := transformer.New(r.Rules)
+	base.SetSeverities(r.Severities)
+	base.Prepare(files)
+
+	var projectRules []rules.Rule
+	var fileRules []rules.Rule
+	for _, rule := range r.Rules {
+		if base.Severity(rule) != rules.SeverityFix {
+			continue
+		}
+		if _, ok := rule.(rules.ProjectRule); ok {
+			projectRules = append(projectRules, rule)
+		} else {
+			fileRules = append(fileRules, rule)
+		}
+	}
+
+	reviewed := make([]File, len(files))
+	for i, f := range files {
+		reviewed[i] = File{Path: f.Path, Original: f.Content, Content: f.Content}
+	}
+	for i := range reviewed {
+		if r.summary.Quit {
+			break
+		}
+		r.reviewFile(base, fileRules, &reviewed[i])
+	}
+	if !r.summary.Quit {
+		reviewed = r.reviewProjectRules(base, projectRules, reviewed)
+	}
+
+	for _, f := range reviewed {
+		if f.Changed() || len(f.Failures) > 0 {
+			r.summary.Files = append(r.summary.Files, f)
+		}
+	}
+	return r.summary
+}
+
+func (r *Reviewer) reviewFile(base *transformer.Transformer, fileRules []rules.Rule, file *File) {
+	rel := r.rel(file.Path)
+	skip := false
+	for _, rule := range fileRules {
+		res := base.Only(rule).Transform(scanner.FileInfo{Path: file.Path, Content: file.Content})
+		file.Failures = append(file.Failures, res.Failures...)
+		if !res.Changed {
+			continue
+		}
+
+		hunks := diff.Hunks(file.Content, res.NewContent)
+		var accepted []diff.Hunk
+		for i, h := range hunks {
+			switch {
+			case r.Baseline != nil && r.Baseline.Contains(rel, rule.Name(), h):
+				r.summary.Suppressed++
+				continue
+			case skip || r.summary.Quit:
+				r.summary.Rejected++
+				continue
+			case r.acceptRule[rule.Name()]:
+				accepted = append(accepted, h)
+				r.summary.Accepted++
+				continue
+			}
+
+			change := Change{
+				Path:        file.Path,
+				RelPath:     rel,
+				Rule:        rule.Name(),
+				Description: rule.Description(),
+				Hunk:        h,
+				Before:      file.Content,
+				Index:       i + 1,
+				Count:       len(hunks),
+			}
+			switch r.Prompt(change) {
+			case Accept:
+				accepted = append(accepted, h)
+				r.summary.Accepted++
+			case AcceptRule:
+				r.acceptRule[rule.Name()] = true
+				accepted = append(accepted, h)
+				r.summary.Accepted++
+			case RejectAndRemember:
+				if r.Baseline != nil {
+					r.Baseline.Add(rel, rule.Name(), h)
+					r.summary.Remembered++
+				}
+				r.summary.Rejected++
+			case SkipFile:
+				skip = true
+				r.summary.Rejected++
+			case Quit:
+				r.summary.Quit = true
+				r.summary.Rejected++
+			default:
+				r.summary.Rejected++
+			}
+		}
+
+		if len(accepted) == 0 {
+			continue
+		}
+		next := res.NewContent
+		if len(accepted) < len(hunks) {
+			next = diff.Apply(file.Content, accepted)
+			if err := syntax.Validate(file.Content, next); err != nil {
+				file.Failures = append(file.Failures, transformer.RuleFailure{
+					Rule: rule.Name(),
+					Err:  fmt.Errorf("accepted changes are invalid without the rejected ones: %w", err),
+				})
+				r.summary.Accepted -= len(accepted)
+				r.summary.Rejected += len(accepted)
+				continue
+			}
+		}
+		file.Content = next
+		file.Applied = append(file.Applied, rule.Name())
+	}
+}
+
+func (r *Reviewer) reviewProjectRules(base *transformer.Transformer, projectRules []rules.Rule, files []File) []File {
+	byPath := make(map[string]int, len(files))
+	for i, f := range files {
+		byPath[f.Path] = i
+	}
+
+	for _, rule := range projectRules {
+		if r.summary.Quit {
+			break
+		}
+		current := make([]scanner.FileInfo, len(files))
+		for i, f := range files {
+			current[i] = scanner.FileInfo{Path: f.Path, Content: f.Content}
+		}
+
+		t := base.Only(rule)
+		if r.Baseline != nil {
+			t.SetBaseline(r.Baseline)
+		}
+		var changed []transformer.Result
+		var paths []string
+		for _, res := range t.TransformAll(current) {
+			if res.Changed {
+				changed = append(changed, res)
+				paths = append(paths, r.rel(res.File.Path))
+			}
+		}
+		if len(changed) == 0 {
+			continue
+		}
+		sort.Strings(paths)
+		decision := Reject
+		if r.ConfirmProject != nil {
+			decision = r.ConfirmProject(rule, paths)
+		}
+		if decision == RejectAndRemember && r.Baseline != nil {
+			for _, res := range changed {
+				for _, h := range diff.Hunks(res.File.Content, res.NewContent) {
+					r.Baseline.Add(r.rel(res.File.Path), rule.Name(), h)
+				}
+			}
+			r.summary.Remembered++
+		}
+		if decision == Quit {
+			r.summary.Quit = true
+		}
+		if decision != Accept && decision != AcceptRule {
+			r.summary.Rejected++
+			continue
+		}
+
+		r.summary.Accepted++
+		for _, res := range changed {
+			i, ok := byPath[res.File.Path]
+			if !ok {
+				files = append(files, File{Path: res.File.Path, Original: res.File.Content})
+				i = len(files) - 1
+				byPath[res.File.Path] = i
+			}
+			files[i].Content = res.NewContent
+			files[i].Applied = append(files[i].Applied, rule.Name())
+		}
+	}
+	return files
+}
+
+func (r *Reviewer) rel(path string) string {
+	if rel, err := filepath.Rel(r.Root, path); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.ToSlash(path)
+}
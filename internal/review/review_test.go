@@ -0,0 +1,247 @@
+package review
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andiq123/sharpify/internal/rules"
+	"github.com/andiq123/sharpify/internal/scanner"
+	"github.com/andiq123/sharpify/internal/transformer"
+)
+
+type renameRule struct {
+	from, to string
+}
+
+func (r renameRule) Name() string        { return "rename-" + strings.ToLower(r.from) }
+func (r renameRule) Description() string { return "Rename " + r.from + " to " + r.to }
+
+func (r renameRule) Apply(content string) (string, bool) {
+	return strings.ReplaceAll(content, r.from, r.to), strings.Contains(content, r.from)
+}
+
+const source = `class A
+{
+    Foo first;
+    int a;
+    int b;
+    int c;
+    int d;
+    Foo second;
+    Baz third;
+}
+`
+
+func files(root string) []scanner.FileInfo {
+	return []scanner.FileInfo{
+		{Path: filepath.Join(root, "A.cs"), Content: source},
+		{Path: filepath.Join(root, "B.cs"), Content: strings.ReplaceAll(source, "class A", "class B")},
+	}
+}
+
+func reviewer(root string, baseline *Baseline, decisions ...Decision) (*Reviewer, *[]Change) {
+	var seen []Change
+	r := &Reviewer{
+		Root:     root,
+		Rules:    []rules.Rule{renameRule{"Foo", "Bar"}, renameRule{"Baz", "Qux"}},
+		Baseline: baseline,
+		Prompt: func(c Change) Decision {
+			seen = append(seen, c)
+			if len(decisions) == 0 {
+				return Reject
+			}
+			d := decisions[0]
+			decisions = decisions[1:]
+			return d
+		},
+	}
+	return r, &seen
+}
+
+func TestReviewAcceptsSelectedHunks(t *testing.T) {
+	root := t.TempDir()
+	r, seen := reviewer(root, nil, Accept, Reject, Accept)
+	summary := r.Review(files(root))
+
+	if len(*seen) != 6 {
+		t.Fatalf("expected 6 prompts, got %d", len(*seen))
+	}
+	first := (*seen)[0]
+	if first.RelPath != "A.cs" || first.Rule != "rename-foo" || first.Index != 1 || first.Count != 2 {
+		t.Errorf("unexpected first change: %+v", first)
+	}
+	if summary.Accepted != 2 || summary.Rejected != 4 || len(summary.Files) != 1 {
+		t.Fatalf("unexpected summary: %+v", summary)
+	}
+	got := summary.Files[0].Content
+	want := strings.Replace(strings.Replace(source, "Foo first", "Bar first", 1), "Baz", "Qux", 1)
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestReviewAcceptRuleSkipFileAndQuit(t *testing.T) {
+	root := t.TempDir()
+	r, seen := reviewer(root, nil, AcceptRule, SkipFile, Quit)
+	summary := r.Review(files(root))
+
+	if len(*seen) != 3 || (*seen)[1].Rule != "rename-baz" || (*seen)[2].RelPath != "B.cs" || (*seen)[2].Rule != "rename-baz" {
+		t.Fatalf("unexpected prompts: %+v", *seen)
+	}
+	if !summary.Quit {
+		t.Error("expected quit")
+	}
+	if len(summary.Files) != 2 {
+		t.Fatalf("expected both files changed, got %+v", summary.Files)
+	}
+	for _, f := range summary.Files {
+		if strings.Contains(f.Content, "Foo") || strings.Contains(f.Content, "Qux") {
+			t.Errorf("%s:\n%s", f.Path, f.Content)
+		}
+	}
+}
+
+func TestReviewRemembersRejections(t *testing.T) {
+	root := t.TempDir()
+	baseline, err := LoadBaseline(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, _ := reviewer(root, baseline, RejectAndRemember)
+	r.Review(files(root)[:1])
+	if err := baseline.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, err := LoadBaseline(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reloaded.Len() != 1 {
+		t.Fatalf("expected one baseline entry, got %d", reloaded.Len())
+	}
+
+	moved := strings.Replace(source, "int a;\n", "int a;\n    int inserted;\n", 1)
+	r, seen := reviewer(root, reloaded)
+	summary := r.Review([]scanner.FileInfo{{Path: filepath.Join(root, "A.cs"), Content: moved}})
+	if summary.Suppressed != 1 {
+		t.Errorf("expected the remembered hunk to be suppressed: %+v", summary)
+	}
+	for _, c := range *seen {
+		if c.Rule == "rename-foo" && strings.Contains(c.Hunk.OldText(), "Foo first") {
+			t.Errorf("remembered hunk was proposed again")
+		}
+	}
+}
+
+func TestBaselineSuppressesBatchRuns(t *testing.T) {
+	root := t.TempDir()
+	baseline, err := LoadBaseline(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, _ := reviewer(root, baseline, RejectAndRemember)
+	r.Review(files(root)[:1])
+	if err := baseline.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := FindBaseline(filepath.Join(root, "A.cs"))
+	if err != nil || found == nil {
+		t.Fatalf("FindBaseline = %v, %v", found, err)
+	}
+	tr := transformer.New([]rules.Rule{renameRule{"Foo", "Bar"}})
+	tr.SetBaseline(found)
+	result := tr.Transform(files(root)[0])
+
+	want := strings.Replace(source, "Foo second", "Bar second", 1)
+	if result.NewContent != want {
+		t.Errorf("got:\n%s\nwant:\n%s", result.NewContent, want)
+	}
+}
+
+type renameFilesRule struct {
+	renameRule
+}
+
+func (r renameFilesRule) ApplyProject(p rules.ProjectFiles) ([]rules.SourceFile, bool) {
+	var changed []rules.SourceFile
+	for _, f := range p.Files {
+		if content, ok := r.Apply(f.Content); ok {
+			changed = append(changed, rules.SourceFile{Path: f.Path, Content: content})
+		}
+	}
+	return changed, len(changed) > 0
+}
+
+func TestReviewRemembersProjectRuleRejections(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "App.csproj"), []byte(`<Project Sdk="Microsoft.NET.Sdk" />`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range files(root) {
+		if err := os.WriteFile(f.Path, []byte(f.Content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	baseline, err := LoadBaseline(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	confirmed := 0
+	r := &Reviewer{
+		Root:     root,
+		Rules:    []rules.Rule{renameFilesRule{renameRule{"Baz", "Qux"}}},
+		Baseline: baseline,
+		ConfirmProject: func(rules.Rule, []string) Decision {
+			confirmed++
+			return RejectAndRemember
+		},
+	}
+	if summary := r.Review(files(root)); summary.Remembered != 1 || len(summary.Files) != 0 {
+		t.Fatalf("unexpected summary: %+v", summary)
+	}
+	r.Review(files(root))
+	if confirmed != 1 {
+		t.Errorf("remembered project change was proposed again (%d prompts)", confirmed)
+	}
+}
+
+func TestOpenBaselineFromSubdirectory(t *testing.T) {
+	root := t.TempDir()
+	sub := filepath.Join(root, "src", "App")
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(root, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := OpenBaseline(sub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Root() != root {
+		t.Fatalf("new baseline root = %s, want the repository root %s", b.Root(), root)
+	}
+
+	r, _ := reviewer(b.Root(), b, RejectAndRemember)
+	r.Review([]scanner.FileInfo{{Path: filepath.Join(sub, "A.cs"), Content: source}})
+	if err := b.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(root, ".git")); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := OpenBaseline(sub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Root() != root || found.Len() != 1 {
+		t.Fatalf("reopened baseline at %s with %d entries", found.Root(), found.Len())
+	}
+}
@@ -0,0 +1,479 @@
+package rules
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/andiq123/sharpify/internal/syntax"
+)
+
+type memberKind int
+
+const (
+	memberField memberKind = iota
+	memberProperty
+	memberConstructor
+	memberMethod
+	memberEquality
+	memberOther
+)
+
+type classMember struct {
+	kind       memberKind
+	name       string
+	typeText   string
+	static     bool
+	modifiers  []string
+	attributes []string
+	auto       bool
+	accessors  []string
+	hasInit    bool
+	start, end int
+	toks       []syntax.Token
+}
+type classDecl struct {
+	name        string
+	start       int
+	keyword     syntax.Token
+	typeParams  string
+	modifiers   []string
+	attributes  []string
+	bases       []string
+	baseStart   int
+	baseEnd     int
+	open, close syntax.Token
+	members     []classMember
+}
+
+var classModifiers = map[string]bool{
+	"public": true, "internal": true, "private": true, "protected": true,
+	"sealed": true, "partial": true, "static": true, "abstract": true,
+	"new": true, "unsafe": true, "file": true,
+}
+
+var memberModifiers = map[string]bool{
+	"public": true, "internal": true, "private": true, "protected": true,
+	"static": true, "readonly": true, "virtual": true, "override": true,
+	"sealed": true, "abstract": true, "new": true, "const": true,
+	"required": true, "extern": true, "unsafe": true, "volatile": true, "async": true,
+}
+
+func findClasses(content string, toks []syntax.Token) []*classDecl {
+	var result []*classDecl
+	for i := range toks {
+		if !toks[i].Is("class") || i+1 >= len(toks) || toks[i+1].Kind != syntax.Identifier {
+			continue
+		}
+		if i > 0 && (toks[i-1].Is("where") || toks[i-1].Is("<") || toks[i-1].Is(",") || toks[i-1].Is(":")) {
+			continue
+		}
+		if c := parseClass(content, toks, i); c != nil {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+func parseClass(content string, toks []syntax.Token, kw int) *classDecl {
+	c := &classDecl{
+		name:    toks[kw+1].Text,
+		keyword: toks[kw],
+	}
+
+	first := kw
+	for first > 0 && toks[first-1].Kind != syntax.Punct && classModifiers[toks[first-1].Text] {
+		first--
+		c.modifiers = append(c.modifiers, toks[first].Text)
+	}
+	for first > 0 && toks[first-1].Is("]") {
+		open := matchingOpen(toks, first-1)
+		if open == -1 {
+			break
+		}
+		c.attributes = append(c.attributes, attributeNames(toks[open:first])...)
+		first = open
+	}
+	c.start = toks[first].Pos
+
+	i := kw + 2
+	if i < len(toks) && toks[i].Is("<") {
+		depth := 0
+		j := i
+		for ; j < len(toks); j++ {
+			if toks[j].Is("<") {
+				depth++
+			} else if toks[j].Is(">") {
+				depth--
+				if depth == 0 {
+					break
+				}
+			}
+		}
+		if j >= len(toks) {
+			return nil
+		}
+		c.typeParams = content[toks[i].Pos:toks[j].End]
+		i = j + 1
+	}
+
+	if i < len(toks) && toks[i].Is(":") {
+		c.baseStart = toks[i].Pos
+		i++
+		baseFrom := i
+		depth := 0
+		for ; i < len(toks); i++ {
+			t := toks[i]
+			if depth == 0 && (t.Is("{") || t.Is("where")) {
+				break
+			}
+			switch {
+			case t.Is("<"):
+				depth++
+			case t.Is(">"):
+				depth--
+			case t.Is(",") && depth == 0:
+				c.bases = append(c.bases, strings.TrimSpace(content[toks[baseFrom].Pos:toks[i-1].End]))
+				baseFrom = i + 1
+			}
+		}
+		if i >= len(toks) || baseFrom >= i {
+			return nil
+		}
+		c.bases = append(c.bases, strings.TrimSpace(content[toks[baseFrom].Pos:toks[i-1].End]))
+		c.baseEnd = toks[i-1].End
+	}
+
+	if i >= len(toks) || !toks[i].Is("{") {
+		return nil
+	}
+	closeIdx := syntax.MatchingClose(toks, i)
+	if closeIdx == -1 {
+		return nil
+	}
+	c.open, c.close = toks[i], toks[closeIdx]
+	c.members = parseMembers(content, toks, i+1, closeIdx, c.name)
+	return c
+}
+
+func (c *classDecl) hasModifier(name string) bool {
+	for _, m := range c.modifiers {
+		if m == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *classMember) hasModifier(name string) bool {
+	for _, mod := range m.modifiers {
+		if mod == name {
+			return true
+		}
+	}
+	return false
+}
+
+func matchingOpen(toks []syntax.Token, closeIdx int) int {
+	depth := 0
+	for i := closeIdx; i >= 0; i-- {
+		if toks[i].Kind != syntax.Punct {
+			continue
+		}
+		switch toks[i].Text {
+		case ")", "]", "}":
+			depth++
+		case "(", "[", "{":
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func enclosingOpen(toks []syntax.Token, i int) int {
+	depth := 0
+	for j := i - 1; j >= 0; j-- {
+		if toks[j].Kind != syntax.Punct {
+			continue
+		}
+		switch toks[j].Text {
+		case ")", "]", "}":
+			depth++
+		case "(", "[", "{":
+			if depth == 0 {
+				return j
+			}
+			depth--
+		}
+	}
+	return -1
+}
+
+func attributeNames(toks []syntax.Token) []string {
+	var names []string
+	expectName := true
+	depth := 0
+	for i := 1; i < len(toks)-1; i++ {
+		t := toks[i]
+		switch {
+		case t.Is("(") || t.Is("["):
+			depth++
+		case t.Is(")") || t.Is("]"):
+			depth--
+		case depth > 0:
+		case t.Is(","):
+			expectName = true
+		case t.Is(":") && i == 2:
+			expectName = true
+		case expectName && t.Kind != syntax.Punct:
+			name := t.Text
+			for i+2 < len(toks)-1 && toks[i+1].Is(".") {
+				i += 2
+				name = toks[i].Text
+			}
+			if i+1 < len(toks)-1 && toks[i+1].Is(":") && i == 1 {
+				continue
+			}
+			names = append(names, strings.TrimSuffix(name, "Attribute"))
+			expectName = false
+		}
+	}
+	return names
+}
+
+func hasAnyAttribute(attributes []string, names []string) bool {
+	for _, a := range attributes {
+		for _, n := range names {
+			if a == strings.TrimSuffix(n, "Attribute") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func parseMembers(content string, toks []syntax.Token, from, to int, className string) []classMember {
+	var members []classMember
+	for _, span := range syntax.MemberSpans(toks, from, to) {
+		m := classifyMember(toks[span.Header:span.End], className)
+		for i := span.Start; i < span.Header; {
+			end := syntax.MatchingClose(toks, i)
+			m.attributes = append(m.attributes, attributeNames(toks[i:end+1])...)
+			i = end + 1
+		}
+		m.start = toks[span.Start].Pos
+		m.end = toks[span.End-1].End
+		m.toks = toks[span.Header:span.End]
+		members = append(members, m)
+	}
+	return members
+}
+
+func classifyMember(toks []syntax.Token, className string) classMember {
+	m := classMember{kind: memberOther}
+
+	headerEnd := len(toks)
+	for j, t := range toks {
+		if t.Is("(") || t.Is("{") || t.Is("=") || t.Is(";") || t.Is("=>") {
+			headerEnd = j
+			break
+		}
+	}
+
+	mods := 0
+	for mods < headerEnd && (memberModifiers[toks[mods].Text] && toks[mods].Kind != syntax.Punct) {
+		if toks[mods].Text == "static" || toks[mods].Text == "const" {
+			m.static = true
+		}
+		m.modifiers = append(m.modifiers, toks[mods].Text)
+		mods++
+	}
+
+	for _, t := range toks[:headerEnd] {
+		switch t.Text {
+		case "class", "struct", "interface", "enum", "record", "delegate", "event":
+			m.name = t.Text
+			return m
+		case "operator":
+			m.kind = memberEquality
+			for _, op := range toks[:headerEnd+1] {
+				if op.Is("==") || op.Is("!=") {
+					m.name = "operator " + op.Text
+					return m
+				}
+			}
+			m.kind = memberMethod
+			m.name = "operator"
+			return m
+		}
+	}
+
+	if headerEnd == 0 || headerEnd <= mods {
+		return m
+	}
+	m.name = toks[headerEnd-1].Text
+	if headerEnd-1 > mods {
+		m.typeText = joinTokens(toks[mods : headerEnd-1])
+	}
+
+	if headerEnd == len(toks) {
+		return m
+	}
+
+	switch {
+	case toks[headerEnd].Is("("):
+		switch {
+		case m.name == className && m.typeText == "":
+			m.kind = memberConstructor
+		case isEqualityMember(m.name, toks, headerEnd, className):
+			m.kind = memberEquality
+		default:
+			m.kind = memberMethod
+		}
+	case toks[headerEnd].Is("{"):
+		m.kind = memberProperty
+		m.auto = true
+		end := syntax.MatchingClose(toks, headerEnd)
+		if end == -1 {
+			m.kind = memberOther
+			return m
+		}
+		var accessor []string
+		for j := headerEnd + 1; j < end; j++ {
+			t := toks[j]
+			switch {
+			case t.Is(";"):
+				m.accessors = append(m.accessors, strings.Join(accessor, " "))
+				accessor = nil
+			case t.Is("{") || t.Is("=>"):
+				m.auto = false
+				if t.Is("{") {
+					j = syntax.MatchingClose(toks, j)
+					if j == -1 {
+						return m
+					}
+				} else {
+					for j < end && !toks[j].Is(";") {
+						j++
+					}
+				}
+				m.accessors = append(m.accessors, strings.Join(accessor, " "))
+				accessor = nil
+			default:
+				accessor = append(accessor, t.Text)
+			}
+		}
+		m.hasInit = end+1 < len(toks) && toks[end+1].Is("=")
+	case toks[headerEnd].Is("=>"):
+		m.kind = memberProperty
+	default:
+		m.kind = memberField
+	}
+	return m
+}
+
+func isEqualityMember(name string, toks []syntax.Token, paren int, className string) bool {
+	end := syntax.MatchingClose(toks, paren)
+	if end == -1 {
+		return false
+	}
+	params := joinTokens(toks[paren+1 : end])
+	switch name {
+	case "GetHashCode":
+		return params == ""
+	case "Equals":
+		if end-paren < 3 || toks[end-1].Kind != syntax.Identifier {
+			return false
+		}
+		typ := strings.TrimSuffix(joinTokens(toks[paren+1:end-1]), "?")
+		return typ == "object" || typ == "Object" || typ == className
+	}
+	return false
+}
+func joinTokens(toks []syntax.Token) string {
+	var sb strings.Builder
+	for i, t := range toks {
+		if i > 0 && needsSpace(toks[i-1], t) {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(t.Text)
+	}
+	return sb.String()
+}
+
+func needsSpace(prev, next syntax.Token) bool {
+	if prev.Kind == syntax.Punct {
+		return prev.Text == "," || prev.Text == "=" || prev.Text == "]"
+	}
+	if next.Kind == syntax.Punct {
+		return next.Text == "="
+	}
+	return true
+}
+
+type textEdit struct {
+	start, end int
+	text       string
+}
+
+func memberLines(content string, start, end int) (int, int) {
+	lineStart := strings.LastIndex(content[:start], "\n") + 1
+	if strings.TrimSpace(content[lineStart:start]) != "" {
+		lineStart = start
+	}
+
+	for lineStart > 0 {
+		prev := strings.LastIndex(content[:lineStart-1], "\n") + 1
+		line := strings.TrimSpace(content[prev : lineStart-1])
+		if !strings.HasPrefix(line, "///") {
+			break
+		}
+		lineStart = prev
+	}
+
+	lineEnd := end
+	if nl := strings.IndexByte(content[end:], '\n'); nl != -1 && strings.TrimSpace(content[end:end+nl]) == "" {
+		lineEnd = end + nl + 1
+	}
+	return lineStart, lineEnd
+}
+
+func applyEdits(text string, edits []textEdit, base int) string {
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start > edits[j].start })
+	for _, e := range edits {
+		start, end := e.start-base, e.end-base
+		if start < 0 || end > len(text) || start > end {
+			continue
+		}
+		text = text[:start] + e.text + text[end:]
+	}
+	return text
+}
+
+var blankLineRuns = regexp.MustCompile(`\n[ \t]*\n(?:[ \t]*\n)+`)
+
+func tidyBody(body string) string {
+	body = blankLineRuns.ReplaceAllString(body, "\n\n")
+	if rest := strings.TrimLeft(body, " \t"); strings.HasPrefix(rest, "\n") {
+		trimmed := strings.TrimLeft(rest, "\n \t")
+		lead := rest[:len(rest)-len(trimmed)]
+		body = "\n" + lead[strings.LastIndex(lead, "\n")+1:] + trimmed
+	}
+	if idx := strings.LastIndex(strings.TrimRight(body, " \t"), "\n"); idx != -1 {
+		head := strings.TrimRight(body[:idx], " \t\n")
+		body = head + body[idx:]
+	}
+	return body
+}
+
+func lineIndent(content string, pos int) string {
+	lineStart := strings.LastIndex(content[:pos], "\n") + 1
+	end := lineStart
+	for end < len(content) && (content[end] == ' ' || content[end] == '\t') {
+		end++
+	}
+	return content[lineStart:end]
+}
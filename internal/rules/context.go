@@ -0,0 +1,125 @@
+package rules
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/andiq123/sharpify/internal/symbols"
+	"github.com/andiq123/sharpify/internal/syntax"
+)
+
+type Context struct {
+	File    string
+	Symbols *symbols.Index
+}
+
+type ContextRule interface {
+	Rule
+	ApplyContext(ctx *Context, content string) (string, bool)
+}
+
+func (c *Context) UsesNamedArgument(content, name string) bool {
+	if symbols.NamedArguments(syntax.Significant(syntax.Lex(content)))[name] {
+		return true
+	}
+	return c != nil && c.Symbols != nil && c.Symbols.UsesNamedArgument(name)
+}
+
+func (c *Context) MemberType(content string, offset int, name string) (string, bool) {
+	if c == nil || c.Symbols == nil {
+		return "", false
+	}
+	typeName := enclosingTypeName(content, offset)
+	if typeName == "" {
+		return "", false
+	}
+	m, ok := c.Symbols.Member(typeName, name)
+	if !ok || m.Kind == symbols.Method || m.Kind == symbols.Constructor {
+		return "", false
+	}
+	return m.Type, true
+}
+
+func (c *Context) TypeOf(binder *syntax.Binder, content string, offset int) (string, bool) {
+	end := offset
+	for end < len(content) && (content[end] == '_' || unicode.IsLetter(rune(content[end])) || unicode.IsDigit(rune(content[end]))) {
+		end++
+	}
+	if end == offset {
+		return "", false
+	}
+	if decl := binder.Resolve(offset); decl != nil {
+		if decl.Type == "" || decl.Type == "var" {
+			return "", false
+		}
+		return decl.Type, true
+	}
+	return c.MemberType(content, offset, content[offset:end])
+}
+
+func enclosingTypeName(content string, offset int) string {
+	toks := syntax.Significant(syntax.Lex(content))
+	name := ""
+	for i := 0; i+1 < len(toks); i++ {
+		switch toks[i].Text {
+		case "class", "struct", "record", "interface":
+		default:
+			continue
+		}
+		if toks[i].Kind == syntax.Punct || toks[i+1].Kind != syntax.Identifier {
+			continue
+		}
+		open := i + 2
+		for open < len(toks) && !toks[open].Is("{") && !toks[open].Is(";") {
+			open++
+		}
+		if open == len(toks) || toks[open].Is(";") || toks[open].Pos > offset {
+			continue
+		}
+		end := syntax.MatchingClose(toks, open)
+		if end != -1 && toks[end].Pos > offset {
+			name = toks[i+1].Text
+		}
+	}
+	return name
+}
+
+var countProperties = map[string]string{
+	"List": "Count", "IList": "Count", "ICollection": "Count", "IReadOnlyCollection": "Count",
+	"IReadOnlyList": "Count", "Collection": "Count", "ReadOnlyCollection": "Count",
+	"ObservableCollection": "Count", "HashSet": "Count", "ISet": "Count", "IReadOnlySet": "Count",
+	"SortedSet": "Count", "Dictionary": "Count", "IDictionary": "Count", "IReadOnlyDictionary": "Count",
+	"SortedDictionary": "Count", "SortedList": "Count", "ConcurrentDictionary": "Count",
+	"ConcurrentQueue": "Count", "ConcurrentStack": "Count", "ConcurrentBag": "Count",
+	"Queue": "Count", "Stack": "Count", "LinkedList": "Count", "ImmutableList": "Count",
+	"ImmutableHashSet": "Count", "ImmutableDictionary": "Count", "ImmutableArray": "Length",
+}
+
+func (c *Context) CountProperty(typeName string) string {
+	typeName = strings.TrimSuffix(strings.TrimSpace(typeName), "?")
+	if strings.HasSuffix(typeName, "]") {
+		return "Length"
+	}
+	base := typeName
+	if i := strings.IndexByte(base, '<'); i != -1 {
+		base = base[:i]
+	}
+	if i := strings.LastIndexByte(base, '.'); i != -1 {
+		base = base[i+1:]
+	}
+	if prop, ok := countProperties[base]; ok {
+		return prop
+	}
+	if c == nil || c.Symbols == nil || len(c.Symbols.Types(base)) == 0 {
+		return ""
+	}
+	if m, ok := c.Symbols.Member(base, "Count"); ok && m.Kind == symbols.Property {
+		return "Count"
+	}
+	for name, prop := range countProperties {
+		if strings.HasPrefix(name, "I") && c.Symbols.Inherits(base, name) {
+			return prop
+		}
+	}
+	return ""
+}
@@ -0,0 +1,666 @@
+package rules
+
+type Example struct {
+	Before string
+	After  string
+}
+
+type Documentation struct {
+	Rationale string
+	Examples  []Example
+	Caveats   []string
+	Related   []string
+}
+
+type DocumentedRule interface {
+	Rule
+	Documentation() Documentation
+}
+
+func DocumentationOf(rule Rule) (Documentation, bool) {
+	if dr, ok := rule.(DocumentedRule); ok {
+		return dr.Documentation(), true
+	}
+	doc, ok := builtinDocs[rule.Name()]
+	return doc, ok
+}
+
+var builtinDocs = map[string]Documentation{
+	"collection-expression": {
+		Rationale: "Collection expressions use one syntax for arrays, lists and spans, and let the compiler pick the most efficient construction.",
+		Examples: []Example{{
+			Before: `int[] primes = new int[] { 2, 3, 5, 7 };
+List<string> names = new List<string>();
+`,
+			After: `int[] primes = [2, 3, 5, 7];
+List<string> names = [];
+`,
+		}},
+		Caveats: []string{
+			"The target type must be known, so var declarations are left unchanged.",
+		},
+		Related: []string{"spread-operator", "target-typed-new"},
+	},
+	"conditional-access-delegate": {
+		Rationale: "Checking a delegate for null and then invoking it reads the field twice, which races with unsubscribing on another thread. ?.Invoke reads it once.",
+		Examples: []Example{{
+			Before: `if (Changed != null)
+{
+    Changed(this, EventArgs.Empty);
+}
+`,
+			After: `Changed?.Invoke(this, EventArgs.Empty);
+`,
+		}},
+		Related: []string{"null-propagation"},
+	},
+	"default-literal": {
+		Rationale: "The default literal infers its type from the target, so the type isn't repeated.",
+		Examples: []Example{{
+			Before: `int count = default(int);
+`,
+			After: `int count = default;
+`,
+		}},
+		Related: []string{"target-typed-new"},
+	},
+	"discard-variable": {
+		Rationale: "A discard makes it explicit that an out value is intentionally ignored, and avoids an unused variable warning.",
+		Examples: []Example{{
+			Before: `if (int.TryParse(text, out var unused))
+{
+    Console.WriteLine("number");
+}
+`,
+			After: `if (int.TryParse(text, out _))
+{
+    Console.WriteLine("number");
+}
+`,
+		}},
+		Caveats: []string{
+			"The variable must not be used anywhere else in the method. Review the result if the name is reused in another scope.",
+		},
+	},
+	"exception-filter": {
+		Rationale: "An exception filter decides whether to catch before the stack unwinds. Debuggers and crash dumps then keep the original throw site, and the catch block only contains the handling code.",
+		Examples: []Example{{
+			Before: `public class Importer
+{
+    public void Run()
+    {
+        try
+        {
+            Load();
+        }
+        catch (IOException ex)
+        {
+            if (!IsTransient(ex)) throw;
+            Retry();
+        }
+    }
+}
+`,
+			After: `public class Importer
+{
+    public void Run()
+    {
+        try
+        {
+            Load();
+        }
+        catch (IOException ex) when (IsTransient(ex))
+        {
+            Retry();
+        }
+    }
+}
+`,
+		}},
+		Caveats: []string{
+			"Only a catch block that starts with a conditional rethrow is converted. Blocks that log or have other side effects first are left alone.",
+		},
+	},
+	"expression-body": {
+		Rationale: "A getter that only returns a value reads better as an expression-bodied property. It drops four lines of braces and makes the member's intent obvious.",
+		Examples: []Example{{
+			Before: `class Person
+{
+    public string Name { get { return _name; } }
+}
+`,
+			After: `class Person
+{
+    public string Name => _name;
+}
+`,
+		}},
+		Caveats: []string{
+			"Only read-only properties whose getter is a single return statement are converted; methods are left alone.",
+		},
+		Related: []string{"init-property", "primary-constructor"},
+	},
+	"file-scoped-namespace": {
+		Rationale: "A file-scoped namespace removes one level of indentation from every line in the file.",
+		Examples: []Example{{
+			Before: `namespace MyApp.Services
+{
+    public class Mailer
+    {
+    }
+}
+`,
+			After: `namespace MyApp.Services;
+
+public class Mailer
+{
+}
+`,
+		}},
+		Caveats: []string{
+			"Files that declare more than one namespace are left alone.",
+		},
+		Related: []string{"global-using", "implicit-using"},
+	},
+	"global-using": {
+		Rationale: "Usings repeated in most files of a project move to a single GlobalUsings.cs, so every file starts with only the usings it specifically needs.",
+		Examples: []Example{{
+			Before: `using MyCompany.Core;
+
+namespace MyApp;
+
+class Service { }
+`,
+			After: `namespace MyApp;
+
+class Service { }
+`,
+		}},
+		Caveats: []string{
+			"A using moves only when it appears in more than the configured share of the project's files (50% by default).",
+			"Global usings apply to the whole project and can introduce ambiguous names in files that didn't import the namespace before.",
+		},
+		Related: []string{"implicit-using", "file-scoped-namespace"},
+	},
+	"implicit-using": {
+		Rationale: "Projects with ImplicitUsings enabled already import the SDK's common namespaces, so explicit usings for them are redundant.",
+		Examples: []Example{{
+			Before: `using System;
+using System.Linq;
+using MyCompany.Core;
+
+class Service { }
+`,
+			After: `using MyCompany.Core;
+
+class Service { }
+`,
+		}},
+		Caveats: []string{
+			"The set of implicit namespaces depends on the project SDK and on <Using> items in the project file.",
+			"The rule only runs for projects that target .NET 6 or later with ImplicitUsings enabled.",
+		},
+		Related: []string{"global-using"},
+	},
+	"index-range": {
+		Rationale: "The ^ operator indexes from the end without computing Length - 1 by hand.",
+		Examples: []Example{{
+			Before: `var last = items[items.Length - 1];
+`,
+			After: `var last = items[^1];
+`,
+		}},
+		Caveats: []string{
+			"Only Length - 1 is rewritten, so it applies to arrays, strings and spans. Count - 1 on lists is left alone.",
+		},
+		Related: []string{"list-pattern"},
+	},
+	"init-property": {
+		Rationale: "An init accessor allows a property to be set in constructors and object initializers only, which makes the type immutable after creation.",
+		Examples: []Example{{
+			Before: `public class Options
+{
+    public string Name { get; set; }
+
+    public Options(string name)
+    {
+        Name = name;
+    }
+}
+`,
+			After: `public class Options
+{
+    public string Name { get; init; }
+
+    public Options(string name)
+    {
+        Name = name;
+    }
+}
+`,
+		}},
+		Caveats: []string{
+			"The rule looks at writes in the whole project. Writes from reflection, serializers or other assemblies are not visible to it.",
+		},
+		Related: []string{"record-type", "required-property"},
+	},
+	"linq-count-any": {
+		Rationale: "Count() may enumerate the whole sequence just to compare the result with zero. Any() stops at the first element.",
+		Examples: []Example{{
+			Before: `if (orders.Count() > 0)
+{
+    Ship(orders);
+}
+`,
+			After: `if (orders.Any())
+{
+    Ship(orders);
+}
+`,
+		}},
+		Related: []string{"linq-where-first", "list-pattern"},
+	},
+	"linq-where-first": {
+		Rationale: "First, Single and Last accept a predicate, so a separate Where call only adds an extra iterator.",
+		Examples: []Example{{
+			Before: `var admin = users.Where(u => u.IsAdmin).First();
+`,
+			After: `var admin = users.First(u => u.IsAdmin);
+`,
+		}},
+		Related: []string{"linq-count-any"},
+	},
+	"list-pattern": {
+		Rationale: "A list pattern states the shape being checked, such as empty, directly in the condition.",
+		Examples: []Example{{
+			Before: `if (items.Length == 0) return;
+`,
+			After: `if (items is []) return;
+`,
+		}},
+		Caveats: []string{
+			"List patterns need a type with a Length or Count property and an indexer. A sequence that is only an IEnumerable<T> won't compile.",
+		},
+		Related: []string{"index-range", "linq-count-any"},
+	},
+	"nameof-expression": {
+		Rationale: "nameof keeps parameter names in exceptions correct when a parameter is renamed, because the compiler checks the name.",
+		Examples: []Example{{
+			Before: `if (value == null) throw new ArgumentNullException("value");
+`,
+			After: `if (value == null) throw new ArgumentNullException(nameof(value));
+`,
+		}},
+		Caveats: []string{
+			"The literal is assumed to be a parameter name. If it names something else, the resulting nameof does not compile, which the build reports.",
+		},
+		Related: []string{"throw-expression", "throw-helper"},
+	},
+	"null-coalescing-assignment": {
+		Rationale: "??= assigns only when the variable is null, replacing a common if statement with one operator.",
+		Examples: []Example{{
+			Before: `if (cache == null) cache = new Dictionary<string, int>();
+`,
+			After: `cache ??= new Dictionary<string, int>();
+`,
+		}},
+		Related: []string{"null-propagation", "pattern-matching-null"},
+	},
+	"null-propagation": {
+		Rationale: "The null-conditional operator replaces a null check and a member access with a single expression.",
+		Examples: []Example{{
+			Before: `var length = text != null ? text.Length : null;
+`,
+			After: `var length = text?.Length;
+`,
+		}},
+		Caveats: []string{
+			"When the member returns a non-nullable value type, the result becomes nullable, which can change overload resolution.",
+		},
+		Related: []string{"null-coalescing-assignment", "conditional-access-delegate", "pattern-matching-null"},
+	},
+	"pattern-matching": {
+		Rationale: "A type pattern checks the type without a separate as cast and null comparison.",
+		Examples: []Example{{
+			Before: `if ((shape as Circle) != null)
+{
+    Draw(shape);
+}
+`,
+			After: `if (shape is Circle)
+{
+    Draw(shape);
+}
+`,
+		}},
+		Caveats: []string{
+			"The rule rewrites the check only. It does not introduce a pattern variable, so casts inside the block stay as they are.",
+		},
+		Related: []string{"pattern-matching-null", "string-isnullorempty"},
+	},
+	"pattern-matching-null": {
+		Rationale: "is null and is not null always check for null, even when == or != are overloaded by the type.",
+		Examples: []Example{{
+			Before: `if (customer == null) return;
+if (order != null) Ship(order);
+`,
+			After: `if (customer is null) return;
+if (order is not null) Ship(order);
+`,
+		}},
+		Caveats: []string{
+			"Types with a custom == operator that treats other values as equal to null behave differently after the rewrite.",
+		},
+		Related: []string{"null-propagation", "string-isnullorempty"},
+	},
+	"primary-constructor": {
+		Rationale: "A primary constructor declares constructor parameters on the type itself and removes the assignment boilerplate.",
+		Examples: []Example{{
+			Before: `public class OrderService
+{
+    private readonly IRepository _repository;
+
+    public OrderService(IRepository repository)
+    {
+        _repository = repository;
+    }
+
+    public Order Find(int id) => _repository.Get(id);
+}
+`,
+			After: `public class OrderService(IRepository repository)
+{
+    private readonly IRepository _repository = repository;
+
+    public Order Find(int id) => _repository.Get(id);
+}
+`,
+		}},
+		Caveats: []string{
+			"Primary constructor parameters are not readonly fields. Code that assigned to the field elsewhere, or relied on readonly, needs a review.",
+			"Only constructors that do nothing but assign parameters to fields are converted.",
+		},
+		Related: []string{"record-type"},
+	},
+	"raw-string-literal": {
+		Rationale: "Raw string literals keep JSON, paths and multi-line text readable without escaping quotes and backslashes.",
+		Examples: []Example{{
+			Before: `var json = "{\"name\": \"Ada\", \"path\": \"C:\\\\temp\"}";
+`,
+			After: `var json = """{"name": "Ada", "path": "C:\\temp"}""";
+`,
+		}},
+		Caveats: []string{
+			"Strings with few escapes are left as they are, since a raw literal would not make them clearer.",
+		},
+		Related: []string{"string-interpolation"},
+	},
+	"record-type": {
+		Rationale: "A record gives an immutable data type value equality, a readable ToString and with-expressions without boilerplate.",
+		Examples: []Example{{
+			Before: `public class Point
+{
+    public int X { get; }
+    public int Y { get; }
+
+    public Point(int x, int y)
+    {
+        X = x;
+        Y = y;
+    }
+}
+`,
+			After: `public record Point(int X, int Y);
+`,
+		}},
+		Caveats: []string{
+			"Records compare by value instead of by reference. Code that relies on reference equality, for example as dictionary keys or in collections, can behave differently.",
+			"Classes with behavior, inheritance or mutable state are skipped.",
+			"Hand-written Equals, GetHashCode and == are only removed when they compare exactly every property. Otherwise the class is skipped.",
+			"Classes whose constructor is not public are skipped, since a positional record's constructor is always public.",
+			"If the constructor or properties have documentation comments, the class becomes a record but keeps its members, so the comments are not lost.",
+			"A positional record renames constructor parameters to the property names. If any scanned file passes one of the old names as a named argument, the class keeps its constructor instead.",
+		},
+		Related: []string{"init-property", "primary-constructor", "required-property"},
+	},
+	"required-property": {
+		Rationale: "required makes the compiler enforce that callers set non-nullable properties, instead of leaving them null at runtime.",
+		Examples: []Example{{
+			Before: `public class User
+{
+    public string Name { get; set; }
+}
+`,
+			After: `public class User
+{
+    public required string Name { get; set; }
+}
+`,
+		}},
+		Caveats: []string{
+			"Every object initializer and constructor call must now set the property. Code that creates the type through a constructor or a serializer needs [SetsRequiredMembers] or a review.",
+		},
+		Related: []string{"init-property", "record-type"},
+	},
+	"span-suggestion": {
+		Rationale: "Methods like StartsWith, EndsWith and case-insensitive Equals avoid allocating temporary substrings, lowered strings or char arrays.",
+		Examples: []Example{{
+			Before: `if (line.Substring(0, prefix.Length) == prefix)
+{
+    Parse(line);
+}
+`,
+			After: `if (line.StartsWith(prefix))
+{
+    Parse(line);
+}
+`,
+		}},
+		Caveats: []string{
+			"ToLower() == ToLower() comparisons become ordinal case-insensitive comparisons. That can differ from culture-sensitive lowering for some languages, such as Turkish.",
+		},
+		Related: []string{"string-isnullorempty"},
+	},
+	"spread-operator": {
+		Rationale: "The spread operator builds a collection from several sources in a single collection expression, without chaining Concat and ToArray or ToList.",
+		Examples: []Example{{
+			Before: `int[] all = first.Concat(second).ToArray();
+`,
+			After: `int[] all = [..first, ..second];
+`,
+		}},
+		Caveats: []string{
+			"The result is materialized eagerly. That matches ToArray and ToList, but the intermediate Concat enumerable is gone.",
+		},
+		Related: []string{"collection-expression"},
+	},
+	"stopwatch-start-new": {
+		Rationale: "Stopwatch.StartNew() creates and starts a stopwatch in one call, so the Start() call can't be forgotten or separated from the declaration.",
+		Examples: []Example{{
+			Before: `var watch = new Stopwatch();
+watch.Start();
+`,
+			After: `var watch = Stopwatch.StartNew();
+`,
+		}},
+	},
+	"string-concat-interpolation": {
+		Rationale: "Concatenating literals and values with + is hard to read and easy to get wrong around spaces. An interpolated string shows the final text at a glance.",
+		Examples: []Example{{
+			Before: `var greeting = "Hello " + name + "!";
+`,
+			After: `var greeting = $"Hello {name}" + "!";
+`,
+		}},
+		Caveats: []string{
+			"Numeric additions mixed into a concatenation change meaning once they are moved inside an interpolation, so review the result.",
+			"A literal after the last value is kept as a separate + operand.",
+		},
+		Related: []string{"string-interpolation"},
+	},
+	"string-interpolation": {
+		Rationale: "Interpolated strings put each value where it appears in the text, so placeholders can no longer get out of sync with their arguments.",
+		Examples: []Example{{
+			Before: `var message = string.Format("Hello {0}, you have {1} messages", name, count);
+`,
+			After: `var message = $"Hello {name}, you have {count} messages";
+`,
+		}},
+		Caveats: []string{
+			"Format strings that are not literals, or that use alignment or format specifiers the rule does not recognize, are left unchanged.",
+		},
+		Related: []string{"string-concat-interpolation", "raw-string-literal"},
+	},
+	"string-isnullorempty": {
+		Rationale: "A pattern makes null and empty checks on strings visible in the condition without a helper method call.",
+		Examples: []Example{{
+			Before: `if (string.IsNullOrEmpty(name)) return;
+`,
+			After: `if (name is null or "") return;
+`,
+		}},
+		Caveats: []string{
+			"Null-state analysis understands string.IsNullOrEmpty through attributes. A pattern is analyzed directly, so nullability warnings can change slightly.",
+		},
+		Related: []string{"pattern-matching-null"},
+	},
+	"switch-expression": {
+		Rationale: "A switch expression turns a switch where every case returns a value into one expression, and the compiler warns about missing cases.",
+		Examples: []Example{{
+			Before: `string Describe(int code)
+{
+    switch (code)
+    {
+        case 200:
+            return "OK";
+        case 404:
+            return "Not Found";
+        default:
+            return "Unknown";
+    }
+}
+`,
+			After: `string Describe(int code)
+{
+    return code switch
+    {
+        200 => "OK",
+        404 => "Not Found",
+        _ => "Unknown"
+    };
+}
+`,
+		}},
+		Caveats: []string{
+			"Only switches whose cases all return and that have a default case are converted.",
+			"A switch expression throws at runtime for unhandled values where the statement would fall through, so the default arm is required.",
+		},
+		Related: []string{"pattern-matching"},
+	},
+	"target-typed-new": {
+		Rationale: "When the declared type is already visible, new() avoids repeating long generic type names.",
+		Examples: []Example{{
+			Before: `private readonly Dictionary<string, int> _counts = new Dictionary<string, int>();
+`,
+			After: `private readonly Dictionary<string, int> _counts = new();
+`,
+		}},
+		Caveats: []string{
+			"new() needs a target type, so it can hurt readability where the declaration is far from the assignment.",
+		},
+		Related: []string{"var-pattern", "collection-expression"},
+	},
+	"throw-expression": {
+		Rationale: "A throw expression combines the null check with the assignment it guards.",
+		Examples: []Example{{
+			Before: `void SetName(string name)
+{
+    if (name == null) throw new ArgumentNullException(nameof(name));
+    _name = name;
+}
+`,
+			After: `void SetName(string name)
+{
+    _name = name ?? throw new ArgumentNullException(nameof(name));
+}
+`,
+		}},
+		Caveats: []string{
+			"Only a null check immediately followed by an assignment of the same variable is converted.",
+		},
+		Related: []string{"throw-helper", "nameof-expression"},
+	},
+	"throw-helper": {
+		Rationale: "ArgumentNullException.ThrowIfNull replaces the check and throw with one call and fills in the parameter name automatically.",
+		Examples: []Example{{
+			Before: `public class Shipping
+{
+    public void Ship(Order order)
+    {
+        if (order == null)
+            throw new ArgumentNullException(nameof(order));
+        Send(order);
+    }
+}
+`,
+			After: `public class Shipping
+{
+    public void Ship(Order order)
+    {
+        ArgumentNullException.ThrowIfNull(order);
+        Send(order);
+    }
+}
+`,
+		}},
+		Caveats: []string{
+			"Checks that pass a custom message are left unchanged because ThrowIfNull can't carry one.",
+		},
+		Related: []string{"throw-expression", "nameof-expression"},
+	},
+	"tuple-deconstruction": {
+		Rationale: "ValueTuple is a struct with named elements and language syntax, while Tuple<T1,T2> is a heap-allocated class with Item1 and Item2.",
+		Examples: []Example{{
+			Before: `Tuple<int, string> Find() => Tuple.Create(1, "one");
+`,
+			After: `(int, string) Find() => Tuple.Create(1, "one");
+`,
+		}},
+		Caveats: []string{
+			"Changing a public signature from Tuple to ValueTuple is a breaking change for callers.",
+		},
+		Related: []string{"tuple-swap"},
+	},
+	"tuple-swap": {
+		Rationale: "Swapping through a tuple needs no temporary variable and states the intent in one line.",
+		Examples: []Example{{
+			Before: `var temp = a;
+a = b;
+b = temp;
+`,
+			After: `(a, b) = (b, a);
+`,
+		}},
+		Related: []string{"tuple-deconstruction"},
+	},
+	"var-pattern": {
+		Rationale: "When the type is already spelled out on the right-hand side, repeating it on the left adds noise without adding information.",
+		Examples: []Example{{
+			Before: `void Load()
+{
+    List<string> names = new List<string>();
+}
+`,
+			After: `void Load()
+{
+    var names = new List<string>();
+}
+`,
+		}},
+		Caveats: []string{
+			"Fields and properties are skipped because var is only allowed for local variables.",
+			"Only declarations initialized with new are converted, so the type always stays visible.",
+		},
+		Related: []string{"target-typed-new"},
+	},
+}
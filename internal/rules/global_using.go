@@ -0,0 +1,169 @@
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type GlobalUsing struct {
+	BaseVersionedRule
+	threshold int
+	fileName  string
+}
+
+func NewGlobalUsing() *GlobalUsing {
+	return &GlobalUsing{
+		BaseVersionedRule: BaseVersionedRule{minVersion: CSharp10, safe: false},
+		threshold:         50,
+		fileName:          "GlobalUsings.cs",
+	}
+}
+
+func (r *GlobalUsing) Name() string {
+	return "global-using"
+}
+
+func (r *GlobalUsing) Description() string {
+	return "Move usings shared across a project into GlobalUsings.cs (C# 10+)"
+}
+
+type globalUsingOptions struct {
+	Threshold *int   `json:"threshold"`
+	FileName  string `json:"fileName"`
+}
+
+func (r *GlobalUsing) Configure(options json.RawMessage) error {
+	var opts globalUsingOptions
+	if err := json.Unmarshal(options, &opts); err != nil {
+		return fmt.Errorf("%s: %w", r.Name(), err)
+	}
+	if opts.Threshold != nil {
+		if *opts.Threshold < 0 || *opts.Threshold > 100 {
+			return fmt.Errorf("%s: threshold must be between 0 and 100, got %d", r.Name(), *opts.Threshold)
+		}
+		r.threshold = *opts.Threshold
+	}
+	if opts.FileName != "" {
+		r.fileName = opts.FileName
+	}
+	return nil
+}
+
+func (r *GlobalUsing) Apply(content string) (string, bool) {
+	return content, false
+}
+
+func (r *GlobalUsing) ApplyProject(p ProjectFiles) ([]SourceFile, bool) {
+	if p.Project == nil {
+		return nil, false
+	}
+	globalPath := filepath.Join(p.Project.Dir, r.fileName)
+
+	global := -1
+	directives := make([][]usingDirective, len(p.Files))
+	existing := make(map[string]bool)
+	counts := make(map[string]int)
+	candidates := 0
+	for i, f := range p.Files {
+		directives[i] = parseUsings(f.Content)
+		if strings.EqualFold(filepath.Clean(f.Path), filepath.Clean(globalPath)) {
+			global = i
+		} else {
+			candidates++
+		}
+
+		seen := make(map[string]bool)
+		for _, d := range directives[i] {
+			switch {
+			case d.conditional || d.kind == usingAlias:
+			case d.global:
+				existing[d.key()] = true
+			case i != global && !seen[d.key()]:
+				seen[d.key()] = true
+				counts[d.key()]++
+			}
+		}
+	}
+
+	var promoted []string
+	for key, n := range counts {
+		if !existing[key] && n >= 2 && n*100 > r.threshold*candidates {
+			promoted = append(promoted, key)
+		}
+	}
+	sort.Slice(promoted, func(i, j int) bool { return compareNamespaces(promoted[i], promoted[j]) })
+	for _, key := range promoted {
+		existing[key] = true
+	}
+
+	var changed []SourceFile
+	for i, f := range p.Files {
+		if i == global {
+			continue
+		}
+		var edits []textEdit
+		for _, d := range directives[i] {
+			if !d.global && !d.conditional && d.kind != usingAlias && existing[d.key()] {
+				edits = append(edits, textEdit{d.start, d.end, ""})
+			}
+		}
+		if len(edits) == 0 {
+			continue
+		}
+		content := applyEdits(f.Content, edits, 0)
+		if !strings.HasPrefix(f.Content, "\n") && !strings.HasPrefix(f.Content, "\r\n") {
+			content = strings.TrimLeft(content, "\r\n")
+		}
+		changed = append(changed, SourceFile{Path: f.Path, Content: content})
+	}
+
+	if len(promoted) > 0 {
+		var lines []string
+		for _, key := range promoted {
+			lines = append(lines, "global using "+key+";")
+		}
+		block := strings.Join(lines, "\n") + "\n"
+
+		if global == -1 {
+			changed = append(changed, SourceFile{Path: globalPath, Content: block})
+		} else {
+			content := p.Files[global].Content
+			at := 0
+			for _, d := range directives[global] {
+				if d.global && !d.conditional {
+					at = d.end
+				}
+			}
+			if at > 0 && !strings.HasSuffix(content[:at], "\n") {
+				block = "\n" + block
+			}
+			changed = append(changed, SourceFile{Path: p.Files[global].Path, Content: content[:at] + block + content[at:]})
+		}
+	}
+
+	return changed, len(changed) > 0
+}
+
+var commonUsingPattern = regexp.MustCompile(`(?m)^using\s+(System|System\.Collections\.Generic|System\.Linq|System\.Threading\.Tasks)\s*;`)
+
+func (r *GlobalUsing) Analyze(content string) []Finding {
+	matches := commonUsingPattern.FindAllStringSubmatchIndex(content, -1)
+	if len(matches) < 3 {
+		return nil
+	}
+
+	names := make([]string, 0, len(matches))
+	for _, m := range matches {
+		names = append(names, content[m[2]:m[3]])
+	}
+
+	return []Finding{{
+		Rule:    r.Name(),
+		Line:    LineAt(content, matches[0][0]),
+		Message: fmt.Sprintf("%d common usings (%s) could move to a global using file", len(names), strings.Join(names, ", ")),
+	}}
+}
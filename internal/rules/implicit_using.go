@@ -0,0 +1,159 @@
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+type ImplicitUsing struct {
+	BaseVersionedRule
+	threshold int
+	enable    bool
+}
+
+func NewImplicitUsing() *ImplicitUsing {
+	return &ImplicitUsing{
+		BaseVersionedRule: BaseVersionedRule{minVersion: CSharp10, safe: false},
+		threshold:         50,
+	}
+}
+
+func (r *ImplicitUsing) Name() string {
+	return "implicit-using"
+}
+
+func (r *ImplicitUsing) Description() string {
+	return "Remove usings covered by the project's implicit usings (.NET 6+)"
+}
+
+type implicitUsingOptions struct {
+	Threshold *int  `json:"threshold"`
+	Enable    *bool `json:"enable"`
+}
+
+func (r *ImplicitUsing) Configure(options json.RawMessage) error {
+	var opts implicitUsingOptions
+	if err := json.Unmarshal(options, &opts); err != nil {
+		return fmt.Errorf("%s: %w", r.Name(), err)
+	}
+	if opts.Threshold != nil {
+		if *opts.Threshold < 0 || *opts.Threshold > 100 {
+			return fmt.Errorf("%s: threshold must be between 0 and 100, got %d", r.Name(), *opts.Threshold)
+		}
+		r.threshold = *opts.Threshold
+	}
+	if opts.Enable != nil {
+		r.enable = *opts.Enable
+	}
+	return nil
+}
+
+func (r *ImplicitUsing) Apply(content string) (string, bool) {
+	return content, false
+}
+
+func (r *ImplicitUsing) ApplyProject(p ProjectFiles) ([]SourceFile, bool) {
+	if p.Project == nil {
+		return nil, false
+	}
+
+	var changed []SourceFile
+	covered := p.Project.ImplicitUsingSet()
+	if !p.Project.ImplicitUsingsEnabled() && !p.Project.ImplicitUsingsDisabled() && r.enable && r.wouldBenefit(p) {
+		if content, ok := enableImplicitUsings(p.Project.Content); ok {
+			changed = append(changed, SourceFile{Path: p.Project.Path, Content: content})
+			p.Project.ImplicitUsings = "enable"
+			p.Project.Content = content
+			covered = p.Project.ImplicitUsingSet()
+		}
+	}
+	if len(covered) == 0 {
+		return changed, len(changed) > 0
+	}
+
+	for _, f := range p.Files {
+		var edits []textEdit
+		for _, d := range parseUsings(f.Content) {
+			if !d.global && !d.conditional && d.kind != usingAlias && covered[d.key()] {
+				edits = append(edits, textEdit{d.start, d.end, ""})
+			}
+		}
+		if len(edits) == 0 {
+			continue
+		}
+		content := applyEdits(f.Content, edits, 0)
+		if !strings.HasPrefix(f.Content, "\n") && !strings.HasPrefix(f.Content, "\r\n") {
+			content = strings.TrimLeft(content, "\r\n")
+		}
+		changed = append(changed, SourceFile{Path: f.Path, Content: content})
+	}
+
+	return changed, len(changed) > 0
+}
+
+func (r *ImplicitUsing) AnalyzeProject(p ProjectFiles) []Finding {
+	if p.Project == nil || p.Project.ImplicitUsingsEnabled() || p.Project.ImplicitUsingsDisabled() || !r.wouldBenefit(p) {
+		return nil
+	}
+
+	benefit, directives := r.benefit(p)
+	return []Finding{{
+		Rule: r.Name(),
+		Line: LineAt(p.Project.Content, strings.Index(p.Project.Content, "<PropertyGroup")),
+		Message: fmt.Sprintf("%d of %d files in %s would drop %d using directive(s); consider <ImplicitUsings>enable</ImplicitUsings>",
+			benefit, len(p.Files), filepath.Base(p.Project.Path), directives),
+	}}
+}
+
+func (r *ImplicitUsing) wouldBenefit(p ProjectFiles) bool {
+	if !p.Project.SupportsImplicitUsings() || len(p.Files) == 0 {
+		return false
+	}
+	benefit, _ := r.benefit(p)
+	return benefit > 0 && benefit*100 > r.threshold*len(p.Files)
+}
+
+func (r *ImplicitUsing) benefit(p ProjectFiles) (files, directives int) {
+	defaults := make(map[string]bool)
+	for _, ns := range p.Project.SdkImplicitUsings() {
+		defaults[ns] = true
+	}
+	for _, u := range p.Project.Usings {
+		if u.Remove != "" {
+			delete(defaults, u.Remove)
+		}
+	}
+
+	for _, f := range p.Files {
+		n := 0
+		for _, d := range parseUsings(f.Content) {
+			if !d.global && !d.conditional && d.kind == usingNamespace && defaults[d.name] {
+				n++
+			}
+		}
+		if n > 0 {
+			files++
+			directives += n
+		}
+	}
+	return files, directives
+}
+
+var propertyGroupPattern = regexp.MustCompile(`(?s)<PropertyGroup>(.*?)\n([ \t]*)</PropertyGroup>`)
+var elementIndentPattern = regexp.MustCompile(`\n([ \t]*)<`)
+
+func enableImplicitUsings(csproj string) (string, bool) {
+	m := propertyGroupPattern.FindStringSubmatchIndex(csproj)
+	if m == nil {
+		return csproj, false
+	}
+	indent := "    "
+	if im := elementIndentPattern.FindStringSubmatch(csproj[m[2]:m[3]]); im != nil {
+		indent = im[1]
+	}
+	line := "\n" + indent + "<ImplicitUsings>enable</ImplicitUsings>"
+	return csproj[:m[3]] + line + csproj[m[3]:], true
+}
@@ -1,16 +1,30 @@
 package rules
 
 import (
+	"encoding/json"
+	"fmt"
 	"regexp"
+	"strings"
+
+	"github.com/andiq123/sharpify/internal/syntax"
 )
 
 type InitOnlyProperty struct {
 	BaseVersionedRule
+	writes         *PropertyWriteIndex
+	skipAttributes []string
+}
+
+var DefaultInitSkipAttributes = []string{
+	"Serializable", "DataContract", "DataMember", "JsonObject", "JsonProperty",
+	"XmlRoot", "XmlType", "XmlElement", "XmlAttribute", "ProtoContract", "ProtoMember",
+	"MessagePackObject", "Table", "Column", "Key",
 }
 
 func NewInitOnlyProperty() *InitOnlyProperty {
 	return &InitOnlyProperty{
 		BaseVersionedRule: BaseVersionedRule{minVersion: CSharp9, safe: false},
+		skipAttributes:    DefaultInitSkipAttributes,
 	}
 }
 
@@ -19,11 +33,108 @@ func (r *InitOnlyProperty) Name() string {
 }
 
 func (r *InitOnlyProperty) Description() string {
-	return "Suggest init-only properties for immutable types (C# 9+) [manual review]"
+	return "Convert setters only used in initializers and constructors to init (C# 9+)"
+}
+
+func (r *InitOnlyProperty) Prepare(files []SourceFile) {
+	r.writes = NewPropertyWriteIndex(files)
+}
+
+type initPropertyOptions struct {
+	SkipAttributes *[]string `json:"skipAttributes"`
+}
+
+func (r *InitOnlyProperty) Configure(options json.RawMessage) error {
+	var opts initPropertyOptions
+	if err := json.Unmarshal(options, &opts); err != nil {
+		return fmt.Errorf("%s: %w", r.Name(), err)
+	}
+	if opts.SkipAttributes != nil {
+		r.skipAttributes = *opts.SkipAttributes
+	}
+	return nil
 }
 
 func (r *InitOnlyProperty) Apply(content string) (string, bool) {
-	return content, false
+	if r.writes == nil {
+		return content, false
+	}
+
+	toks := syntax.Significant(syntax.Lex(content))
+	var edits []textEdit
+	for _, c := range findClasses(content, toks) {
+		if hasAnyAttribute(c.attributes, r.skipAttributes) {
+			continue
+		}
+		for _, m := range c.members {
+			if !r.convertible(m) {
+				continue
+			}
+			if setter, ok := setterToken(m); ok {
+				edits = append(edits, textEdit{setter.Pos, setter.End, "init"})
+			}
+		}
+	}
+	if len(edits) == 0 {
+		return content, false
+	}
+	return applyEdits(content, edits, 0), true
+}
+
+func (r *InitOnlyProperty) convertible(m classMember) bool {
+	if m.kind != memberProperty || !m.auto || m.static || len(m.accessors) != 2 {
+		return false
+	}
+	if m.accessors[0] != "get" || !strings.HasSuffix(m.accessors[1], "set") {
+		return false
+	}
+	for _, mod := range m.modifiers {
+		switch mod {
+		case "virtual", "override", "abstract", "new", "extern":
+			return false
+		}
+	}
+	if hasAnyAttribute(m.attributes, r.skipAttributes) {
+		return false
+	}
+	return r.writes.InitOnly(m.name)
+}
+
+func setterToken(m classMember) (syntax.Token, bool) {
+	open := -1
+	for i, t := range m.toks {
+		if t.Is("{") {
+			open = i
+			break
+		}
+	}
+	if open == -1 {
+		return syntax.Token{}, false
+	}
+	end := syntax.MatchingClose(m.toks, open)
+	if end == -1 {
+		return syntax.Token{}, false
+	}
+	for _, t := range m.toks[open+1 : end] {
+		if t.Is("set") {
+			return t, true
+		}
+	}
+	return syntax.Token{}, false
+}
+
+var privateSetterPattern = regexp.MustCompile(`(?m)^[ \t]*public\s+(?:virtual\s+)?[\w<>\[\],.?]+(?:\s*,\s*[\w<>\[\].?]+)*\s+(\w+)\s*\{\s*get;\s*private\s+set;\s*\}`)
+
+func (r *InitOnlyProperty) Analyze(content string) []Finding {
+	var findings []Finding
+	for _, m := range privateSetterPattern.FindAllStringSubmatchIndex(content, -1) {
+		findings = append(findings, Finding{
+			Rule:    r.Name(),
+			Line:    LineAt(content, m[2]),
+			Message: fmt.Sprintf("Property '%s' has a private setter; consider { get; init; }", content[m[2]:m[3]]),
+		})
+	}
+	return findings
 }
 
 type RequiredProperty struct {
@@ -47,7 +158,7 @@ func (r *RequiredProperty) Description() string {
 func (r *RequiredProperty) Apply(content string) (string, bool) {
 	changed := false
 
-	pattern := regexp.MustCompile(`(?m)^(\s*)(public\s+)(string|object|[A-Z][a-zA-Z0-9_]*(?:<[^>]+>)?)\s+(\w+)\s*\{\s*get;\s*(?:set|init);\s*\}(\s*)$`)
+	pattern := regexp.MustCompile(`(?m)^(\s*)(public\s+)(string|object|[A-Z][a-zA-Z0-9_]*(?:<[^>]+>)?)\s+(\w+)\s*\{\s*get;\s*(set|init);\s*\}(\s*)$`)
 
 	result := pattern.ReplaceAllStringFunc(content, func(match string) string {
 		if regexp.MustCompile(`public\s+required\s+`).MatchString(match) {
@@ -69,95 +180,8 @@ func (r *RequiredProperty) Apply(content string) (string, bool) {
 		}
 
 		changed = true
-		return submatches[1] + submatches[2] + "required " + submatches[3] + " " + submatches[4] + " { get; set; }" + submatches[5]
+		return submatches[1] + submatches[2] + "required " + submatches[3] + " " + submatches[4] + " { get; " + submatches[5] + "; }" + submatches[6]
 	})
 
 	return result, changed
 }
-
-type RecordType struct {
-	BaseVersionedRule
-}
-
-func NewRecordType() *RecordType {
-	return &RecordType{
-		BaseVersionedRule: BaseVersionedRule{minVersion: CSharp9, safe: false},
-	}
-}
-
-func (r *RecordType) Name() string {
-	return "record-type"
-}
-
-func (r *RecordType) Description() string {
-	return "Suggest converting simple classes to records (C# 9+) [manual review]"
-}
-
-func (r *RecordType) Apply(content string) (string, bool) {
-	return content, false
-}
-
-type GlobalUsing struct {
-	BaseVersionedRule
-}
-
-func NewGlobalUsing() *GlobalUsing {
-	return &GlobalUsing{
-		BaseVersionedRule: BaseVersionedRule{minVersion: CSharp10, safe: false},
-	}
-}
-
-func (r *GlobalUsing) Name() string {
-	return "global-using"
-}
-
-func (r *GlobalUsing) Description() string {
-	return "Suggest global usings for common namespaces (C# 10+) [manual review]"
-}
-
-func (r *GlobalUsing) Apply(content string) (string, bool) {
-	return content, false
-}
-
-type ImplicitUsing struct {
-	BaseVersionedRule
-}
-
-func NewImplicitUsing() *ImplicitUsing {
-	return &ImplicitUsing{
-		BaseVersionedRule: BaseVersionedRule{minVersion: CSharp10, safe: false},
-	}
-}
-
-func (r *ImplicitUsing) Name() string {
-	return "implicit-using"
-}
-
-func (r *ImplicitUsing) Description() string {
-	return "Remove usings covered by implicit usings (.NET 6+) [manual review]"
-}
-
-var implicitUsings = []string{
-	"System",
-	"System.Collections.Generic",
-	"System.IO",
-	"System.Linq",
-	"System.Net.Http",
-	"System.Threading",
-	"System.Threading.Tasks",
-}
-
-func (r *ImplicitUsing) Apply(content string) (string, bool) {
-	changed := false
-	result := content
-
-	for _, ns := range implicitUsings {
-		pattern := regexp.MustCompile(`(?m)^using\s+` + regexp.QuoteMeta(ns) + `\s*;\s*\n?`)
-		if pattern.MatchString(result) {
-			result = pattern.ReplaceAllString(result, "")
-			changed = true
-		}
-	}
-
-	return result, changed
-}
@@ -2,6 +2,9 @@ package rules
 
 import (
 	"regexp"
+	"strings"
+
+	"github.com/andiq123/sharpify/internal/syntax"
 )
 
 
@@ -24,9 +27,37 @@ func (r *LinqCountAny) Description() string {
 }
 
 func (r *LinqCountAny) Apply(content string) (string, bool) {
+	return r.ApplyContext(nil, content)
+}
+
+var countReceiverPattern = regexp.MustCompile(`\b(?:this\.)?(\w+)\.Count\(\)(\s*(?:>=?|!=|==|<)\s*[01]\b)`)
+
+func (r *LinqCountAny) ApplyContext(ctx *Context, content string) (string, bool) {
 	changed := false
 	result := content
 
+	matches := countReceiverPattern.FindAllStringSubmatchIndex(result, -1)
+	var binder *syntax.Binder
+	if len(matches) > 0 {
+		binder = syntax.Bind(result)
+	}
+	for i := len(matches) - 1; i >= 0; i-- {
+		m := matches[i]
+		if m[0] > 0 && strings.ContainsRune(".)]>?", rune(result[m[0]-1])) {
+			continue
+		}
+		typeName, ok := ctx.TypeOf(binder, result, m[2])
+		if !ok {
+			continue
+		}
+		prop := ctx.CountProperty(typeName)
+		if prop == "" {
+			continue
+		}
+		result = result[:m[3]] + "." + prop + result[m[4]:]
+		changed = true
+	}
+
 	
 	pattern1 := regexp.MustCompile(`\.Count\(\)\s*>\s*0`)
 	if pattern1.MatchString(result) {
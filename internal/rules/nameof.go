@@ -22,6 +22,10 @@ func (r *NameofExpression) Description() string {
 	return "Use nameof() for ArgumentNullException and similar (C# 6+)"
 }
 
+func (r *NameofExpression) RewritesLiterals() bool {
+	return true
+}
+
 func (r *NameofExpression) Apply(content string) (string, bool) {
 	changed := false
 	result := content
@@ -2,6 +2,8 @@ package rules
 
 import (
 	"regexp"
+
+	"github.com/andiq123/sharpify/internal/syntax"
 )
 
 type NullCoalescing struct {
@@ -23,19 +25,31 @@ func (r *NullCoalescing) Description() string {
 }
 
 func (r *NullCoalescing) Apply(content string) (string, bool) {
-	changed := false
-	result := content
-
-	pattern := regexp.MustCompile(`if\s*\(\s*(\w+)\s*==\s*null\s*\)\s*(\w+)\s*=\s*([^;]+);`)
-	matches := pattern.FindAllStringSubmatch(result, -1)
-	for _, m := range matches {
-		if len(m) >= 4 && m[1] == m[2] {
-			old := m[0]
-			replacement := m[1] + " ??= " + m[3] + ";"
-			result = regexp.MustCompile(regexp.QuoteMeta(old)).ReplaceAllString(result, replacement)
-			changed = true
+	var binder *syntax.Binder
+	var edits []textEdit
+	for _, m := range nullCheckPattern.FindAllStringSubmatchIndex(content, -1) {
+		if content[m[2]:m[3]] != content[m[4]:m[5]] || followedByElse(content[m[1]:]) {
+			continue
+		}
+		if binder == nil {
+			binder = syntax.Bind(content)
 		}
+		if binder.Resolve(m[2]) != binder.Resolve(m[4]) {
+			continue
+		}
+		replacement := content[m[2]:m[3]] + " ??= " + content[m[6]:m[7]] + ";"
+		edits = append(edits, textEdit{m[0], m[1], replacement})
+	}
+	if len(edits) == 0 {
+		return content, false
 	}
+	return applyEdits(content, edits, 0), true
+}
+
+var nullCheckPattern = regexp.MustCompile(`if\s*\(\s*(\w+)\s*==\s*null\s*\)\s*(\w+)\s*=\s*([^;]+);`)
+
+var elsePattern = regexp.MustCompile(`^\s*else\b`)
 
-	return result, changed
+func followedByElse(rest string) bool {
+	return elsePattern.MatchString(rest)
 }
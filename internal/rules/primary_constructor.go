@@ -3,6 +3,8 @@ package rules
 import (
 	"regexp"
 	"strings"
+
+	"github.com/andiq123/sharpify/internal/symbols"
 )
 
 type PrimaryConstructor struct {
@@ -24,6 +26,10 @@ func (r *PrimaryConstructor) Description() string {
 }
 
 func (r *PrimaryConstructor) Apply(content string) (string, bool) {
+	return r.ApplyContext(nil, content)
+}
+
+func (r *PrimaryConstructor) ApplyContext(ctx *Context, content string) (string, bool) {
 	changed := false
 
 	
@@ -45,6 +51,10 @@ func (r *PrimaryConstructor) Apply(content string) (string, bool) {
 		}
 		openBracePos := match[1] - 1
 
+		if regexp.MustCompile(`\bpartial\b`).MatchString(classNameAndKeyword) && !otherPartsAllowPrimaryConstructor(ctx, className) {
+			continue
+		}
+
 		classBodyStart := openBracePos + 1
 		classBodyEnd := findMatchingBrace(content, openBracePos)
 		if classBodyEnd == -1 {
@@ -214,3 +224,20 @@ func findMatchingBrace(content string, openPos int) int {
 	}
 	return -1
 }
+
+func otherPartsAllowPrimaryConstructor(ctx *Context, typeName string) bool {
+	if ctx == nil || ctx.Symbols == nil {
+		return false
+	}
+	for _, t := range ctx.Symbols.Types(typeName) {
+		if t.File == ctx.File {
+			continue
+		}
+		for _, m := range t.Members {
+			if m.Kind == symbols.Constructor {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,391 @@
+package rules
+
+import (
+	"github.com/andiq123/sharpify/internal/syntax"
+)
+
+type writeKind int
+
+const (
+	writeInitializer writeKind = iota
+	writeConstructor
+	writeOther
+)
+
+type PropertyWrites struct {
+	Initializer int
+	Constructor int
+	Other       int
+}
+
+type PropertyWriteIndex struct {
+	writes map[string]*PropertyWrites
+}
+
+func NewPropertyWriteIndex(files []SourceFile) *PropertyWriteIndex {
+	x := &PropertyWriteIndex{writes: make(map[string]*PropertyWrites)}
+	for _, f := range files {
+		x.Add(f.Content)
+	}
+	return x
+}
+
+func (x *PropertyWriteIndex) Writes(name string) PropertyWrites {
+	if w, ok := x.writes[name]; ok {
+		return *w
+	}
+	return PropertyWrites{}
+}
+
+func (x *PropertyWriteIndex) InitOnly(name string) bool {
+	return x.Writes(name).Other == 0
+}
+
+func (x *PropertyWriteIndex) record(name string, kind writeKind) {
+	w, ok := x.writes[name]
+	if !ok {
+		w = &PropertyWrites{}
+		x.writes[name] = w
+	}
+	switch kind {
+	case writeInitializer:
+		w.Initializer++
+	case writeConstructor:
+		w.Constructor++
+	default:
+		w.Other++
+	}
+}
+
+var assignmentOperators = map[string]bool{
+	"=": true, "+=": true, "-=": true, "*=": true, "/=": true, "%=": true,
+	"&=": true, "|=": true, "^=": true, "??=": true, "<<=": true,
+}
+
+var typeKeywords = map[string]bool{
+	"bool": true, "byte": true, "char": true, "decimal": true, "double": true, "float": true,
+	"int": true, "long": true, "object": true, "sbyte": true, "short": true, "string": true,
+	"uint": true, "ulong": true, "ushort": true, "void": true,
+}
+
+func (x *PropertyWriteIndex) Add(content string) {
+	toks := syntax.Significant(syntax.Lex(content))
+	enums := enumBodies(toks)
+	ctors := constructorBodies(content, toks)
+
+	for i, t := range toks {
+		if t.Kind != syntax.Identifier || inTokenRanges(enums, i) || !isWriteTarget(toks, i) {
+			continue
+		}
+		x.record(t.Text, classifyWrite(toks, i, ctors))
+	}
+
+	x.addDeconstructions(toks)
+	x.addInterfaceSetters(toks)
+}
+
+func isWriteTarget(toks []syntax.Token, i int) bool {
+	if i > 0 && (toks[i-1].Is("++") || toks[i-1].Is("--")) {
+		return true
+	}
+	if i+1 >= len(toks) {
+		return false
+	}
+	next := toks[i+1]
+	if next.Is("++") || next.Is("--") {
+		return true
+	}
+	if next.Kind != syntax.Punct || !assignmentOperators[next.Text] {
+		return false
+	}
+	if i == 0 || !next.Is("=") {
+		return true
+	}
+
+	prev := toks[i-1]
+	switch {
+	case prev.Kind == syntax.Identifier:
+		return false
+	case prev.Kind == syntax.Keyword && typeKeywords[prev.Text]:
+		return false
+	case prev.Is(">") || prev.Is("]") || prev.Is("?"):
+		return false
+	}
+	return true
+}
+
+func classifyWrite(toks []syntax.Token, i int, ctors []ctorBody) writeKind {
+	bare := true
+	if i > 0 && (toks[i-1].Is(".") || toks[i-1].Is("?.") || toks[i-1].Is("->") || toks[i-1].Is("::")) {
+		if !toks[i-1].Is(".") || i < 2 || !toks[i-2].Is("this") || (i > 2 && toks[i-3].Is(".")) {
+			return writeOther
+		}
+		bare = false
+	}
+
+	if bare && i > 0 && (toks[i-1].Is("{") || toks[i-1].Is(",")) {
+		if open := enclosingOpen(toks, i); open != -1 && toks[open].Is("{") && isInitializerBrace(toks, open) {
+			return writeInitializer
+		}
+	}
+
+	pos := toks[i].Pos
+	for _, c := range ctors {
+		if pos < c.start || pos >= c.end || !c.declares[toks[i].Text] {
+			continue
+		}
+		for _, r := range c.excluded {
+			if pos >= r[0] && pos < r[1] {
+				return writeOther
+			}
+		}
+		return writeConstructor
+	}
+	return writeOther
+}
+
+func isInitializerBrace(toks []syntax.Token, open int) bool {
+	j := open - 1
+	if j < 0 {
+		return false
+	}
+	switch {
+	case toks[j].Is("with") || toks[j].Is("new"):
+		return true
+	case toks[j].Is("="):
+		outer := enclosingOpen(toks, j)
+		return outer != -1 && toks[outer].Is("{") && isInitializerBrace(toks, outer)
+	case toks[j].Is(")"):
+		j = matchingOpen(toks, j) - 1
+	}
+
+	for ; j >= 0; j-- {
+		t := toks[j]
+		if t.Is("new") {
+			return true
+		}
+		typeLike := t.Kind == syntax.Identifier ||
+			(t.Kind == syntax.Keyword && typeKeywords[t.Text]) ||
+			t.Is(".") || t.Is("<") || t.Is(">") || t.Is(",") || t.Is("?") || t.Is("[") || t.Is("]") || t.Is("::")
+		if !typeLike {
+			return false
+		}
+	}
+	return false
+}
+
+type ctorBody struct {
+	start, end int
+	declares   map[string]bool
+	excluded   [][2]int
+}
+
+func constructorBodies(content string, toks []syntax.Token) []ctorBody {
+	var result []ctorBody
+	for _, c := range findClasses(content, toks) {
+		declares := make(map[string]bool)
+		for _, m := range c.members {
+			if m.kind == memberProperty && !m.static {
+				declares[m.name] = true
+			}
+		}
+
+		for _, m := range c.members {
+			if m.kind != memberConstructor || m.static {
+				continue
+			}
+			body := constructorBodyStart(m.toks)
+			if body == -1 {
+				continue
+			}
+			result = append(result, ctorBody{
+				start:    m.toks[body].End,
+				end:      m.end,
+				declares: declares,
+				excluded: nestedFunctions(m.toks[body+1:]),
+			})
+		}
+	}
+	return result
+}
+
+func constructorBodyStart(toks []syntax.Token) int {
+	open := 0
+	for open < len(toks) && !toks[open].Is("(") {
+		open++
+	}
+	if open == len(toks) {
+		return -1
+	}
+	k := syntax.MatchingClose(toks, open)
+	if k == -1 {
+		return -1
+	}
+	k++
+	if k < len(toks) && toks[k].Is(":") {
+		for k < len(toks) && !toks[k].Is("(") {
+			k++
+		}
+		if k == len(toks) {
+			return -1
+		}
+		if k = syntax.MatchingClose(toks, k); k == -1 {
+			return -1
+		}
+		k++
+	}
+	if k >= len(toks) || !(toks[k].Is("{") || toks[k].Is("=>")) {
+		return -1
+	}
+	return k
+}
+
+func nestedFunctions(toks []syntax.Token) [][2]int {
+	var ranges [][2]int
+	for i, t := range toks {
+		switch {
+		case t.Is("=>"):
+			if i+1 < len(toks) && toks[i+1].Is("{") {
+				if end := syntax.MatchingClose(toks, i+1); end != -1 {
+					ranges = append(ranges, [2]int{t.Pos, toks[end].End})
+				}
+				continue
+			}
+			end := i + 1
+			depth := 0
+		expression:
+			for ; end < len(toks); end++ {
+				switch {
+				case toks[end].Is("(") || toks[end].Is("[") || toks[end].Is("{"):
+					depth++
+				case toks[end].Is(")") || toks[end].Is("]") || toks[end].Is("}"):
+					if depth == 0 {
+						break expression
+					}
+					depth--
+				case depth == 0 && (toks[end].Is(";") || toks[end].Is(",")):
+					break expression
+				}
+			}
+			if end > i+1 {
+				ranges = append(ranges, [2]int{t.Pos, toks[end-1].End})
+			}
+		case t.Is("{") && i > 0 && isFunctionBody(toks, i):
+			if end := syntax.MatchingClose(toks, i); end != -1 {
+				ranges = append(ranges, [2]int{t.Pos, toks[end].End})
+			}
+		}
+	}
+	return ranges
+}
+
+func isFunctionBody(toks []syntax.Token, open int) bool {
+	prev := toks[open-1]
+	if prev.Is("delegate") {
+		return true
+	}
+	if !prev.Is(")") {
+		return false
+	}
+	paren := matchingOpen(toks, open-1)
+	if paren < 1 {
+		return false
+	}
+	if toks[paren-1].Is("delegate") {
+		return true
+	}
+	return paren >= 2 && toks[paren-1].Kind == syntax.Identifier &&
+		(toks[paren-2].Kind == syntax.Identifier || toks[paren-2].Kind == syntax.Keyword ||
+			toks[paren-2].Is(">") || toks[paren-2].Is("]") || toks[paren-2].Is("?"))
+}
+
+func (x *PropertyWriteIndex) addDeconstructions(toks []syntax.Token) {
+	for i := 1; i < len(toks); i++ {
+		if !toks[i].Is("=") || !toks[i-1].Is(")") {
+			continue
+		}
+		open := matchingOpen(toks, i-1)
+		if open == -1 || (open > 0 && toks[open-1].Kind == syntax.Identifier) {
+			continue
+		}
+		depth := 0
+		for j := open + 1; j < i-1; j++ {
+			t := toks[j]
+			switch {
+			case t.Is("(") || t.Is("["):
+				depth++
+			case t.Is(")") || t.Is("]"):
+				depth--
+			case depth == 0 && t.Kind == syntax.Identifier && (toks[j+1].Is(",") || toks[j+1].Is(")")):
+				prev := toks[j-1]
+				if prev.Is("(") || prev.Is(",") || prev.Is(".") {
+					x.record(t.Text, writeOther)
+				}
+			}
+		}
+	}
+}
+
+func (x *PropertyWriteIndex) addInterfaceSetters(toks []syntax.Token) {
+	for i, t := range toks {
+		if !t.Is("interface") || i+1 >= len(toks) || toks[i+1].Kind != syntax.Identifier {
+			continue
+		}
+		open := i + 2
+		for open < len(toks) && !toks[open].Is("{") && !toks[open].Is(";") {
+			open++
+		}
+		if open == len(toks) || !toks[open].Is("{") {
+			continue
+		}
+		closeIdx := syntax.MatchingClose(toks, open)
+		if closeIdx == -1 {
+			continue
+		}
+		for j := open + 1; j < closeIdx-1; j++ {
+			if toks[j].Kind != syntax.Identifier || !toks[j+1].Is("{") {
+				continue
+			}
+			end := syntax.MatchingClose(toks, j+1)
+			if end == -1 {
+				break
+			}
+			for _, a := range toks[j+2 : end] {
+				if a.Is("set") {
+					x.record(toks[j].Text, writeOther)
+					break
+				}
+			}
+			j = end
+		}
+	}
+}
+
+func enumBodies(toks []syntax.Token) [][2]int {
+	var ranges [][2]int
+	for i, t := range toks {
+		if !t.Is("enum") {
+			continue
+		}
+		open := i + 1
+		for open < len(toks) && !toks[open].Is("{") && !toks[open].Is(";") {
+			open++
+		}
+		if open == len(toks) || !toks[open].Is("{") {
+			continue
+		}
+		if end := syntax.MatchingClose(toks, open); end != -1 {
+			ranges = append(ranges, [2]int{open, end})
+		}
+	}
+	return ranges
+}
+
+func inTokenRanges(ranges [][2]int, i int) bool {
+	for _, r := range ranges {
+		if i > r[0] && i < r[1] {
+			return true
+		}
+	}
+	return false
+}
@@ -1,12 +1,27 @@
 package rules
 
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/andiq123/sharpify/internal/syntax"
+)
+
 type RawStringLiteral struct {
 	BaseVersionedRule
+	minEscapes  int
+	minNewlines int
 }
 
 func NewRawStringLiteral() *RawStringLiteral {
 	return &RawStringLiteral{
 		BaseVersionedRule: BaseVersionedRule{minVersion: CSharp11, safe: false},
+		minEscapes:        2,
+		minNewlines:       2,
 	}
 }
 
@@ -15,9 +30,273 @@ func (r *RawStringLiteral) Name() string {
 }
 
 func (r *RawStringLiteral) Description() string {
-	return "Use raw string literals for complex strings (C# 11+) [manual review]"
+	return "Convert heavily escaped and multi-line strings to raw string literals (C# 11+)"
+}
+
+func (r *RawStringLiteral) RewritesLiterals() bool {
+	return true
+}
+
+type rawStringOptions struct {
+	MinEscapes  *int `json:"minEscapes"`
+	MinNewlines *int `json:"minNewlines"`
+}
+
+func (r *RawStringLiteral) Configure(options json.RawMessage) error {
+	var opts rawStringOptions
+	if err := json.Unmarshal(options, &opts); err != nil {
+		return fmt.Errorf("%s: %w", r.Name(), err)
+	}
+	if opts.MinEscapes != nil {
+		r.minEscapes = *opts.MinEscapes
+	}
+	if opts.MinNewlines != nil {
+		r.minNewlines = *opts.MinNewlines
+	}
+	return nil
 }
 
 func (r *RawStringLiteral) Apply(content string) (string, bool) {
-	return content, false
+	var edits []textEdit
+	for _, c := range r.candidates(content) {
+		if c.reason == "" {
+			edits = append(edits, textEdit{c.tok.Pos, c.tok.End, c.raw})
+		}
+	}
+	if len(edits) == 0 {
+		return content, false
+	}
+	return applyEdits(content, edits, 0), true
+}
+
+func (r *RawStringLiteral) Analyze(content string) []Finding {
+	var findings []Finding
+	for _, c := range r.candidates(content) {
+		what := fmt.Sprintf("String with %d escape(s)", c.escapes)
+		if c.verbatim {
+			what = fmt.Sprintf("Verbatim string with %d doubled quote(s)", c.escapes)
+		}
+		message := what + "; consider a raw string literal"
+		if c.reason != "" {
+			message = what + " was not converted to a raw string literal: " + c.reason
+		}
+		findings = append(findings, Finding{
+			Rule:    r.Name(),
+			Line:    LineAt(content, c.tok.Pos),
+			Message: message,
+		})
+	}
+	return findings
+}
+
+type stringSegment struct {
+	text string
+	hole bool
+}
+
+type rawCandidate struct {
+	tok      syntax.Token
+	verbatim bool
+	escapes  int
+	raw      string
+	reason   string
+}
+
+func (r *RawStringLiteral) candidates(content string) []rawCandidate {
+	var result []rawCandidate
+	for _, t := range syntax.Lex(content) {
+		if t.Kind != syntax.String || t.Unterminated {
+			continue
+		}
+
+		prefix := strings.IndexByte(t.Text, '"')
+		if strings.HasPrefix(t.Text[prefix:], `"""`) {
+			continue
+		}
+		verbatim := strings.Contains(t.Text[:prefix], "@")
+		interpolated := strings.Contains(t.Text[:prefix], "$")
+
+		segments, escapes, reason := decodeString(t.Text[prefix+1:len(t.Text)-1], verbatim, interpolated)
+		newlines := 0
+		for _, s := range segments {
+			if !s.hole {
+				newlines += strings.Count(s.text, "\n")
+			}
+		}
+
+		meets := (r.minEscapes > 0 && escapes >= r.minEscapes) || (r.minNewlines > 0 && newlines >= r.minNewlines)
+		if !meets {
+			continue
+		}
+
+		c := rawCandidate{tok: t, verbatim: verbatim, escapes: escapes, reason: reason}
+		if c.reason == "" {
+			c.raw = rawLiteral(segments, lineIndent(content, t.Pos))
+		}
+		result = append(result, c)
+	}
+	return result
+}
+
+func decodeString(body string, verbatim, interpolated bool) ([]stringSegment, int, string) {
+	var segments []stringSegment
+	var text strings.Builder
+	escapes := 0
+	reason := ""
+	fail := func(why string) {
+		if reason == "" {
+			reason = why
+		}
+	}
+
+	flush := func() {
+		if text.Len() > 0 {
+			segments = append(segments, stringSegment{text: text.String()})
+			text.Reset()
+		}
+	}
+
+	for i := 0; i < len(body); {
+		c := body[i]
+		switch {
+		case verbatim && c == '"' && i+1 < len(body) && body[i+1] == '"':
+			text.WriteByte('"')
+			escapes++
+			i += 2
+		case !verbatim && c == '\\' && i+1 < len(body):
+			r, size, why := decodeEscape(body[i:])
+			if why != "" {
+				fail(why)
+				i += 2
+				continue
+			}
+			if r == '"' || r == '\\' {
+				escapes++
+			}
+			text.WriteRune(r)
+			i += size
+		case interpolated && (c == '{' || c == '}') && i+1 < len(body) && body[i+1] == c:
+			text.WriteByte(c)
+			i += 2
+		case interpolated && c == '{':
+			end := strings.IndexByte(body[i:], '}')
+			if end == -1 {
+				fail("unbalanced interpolation")
+				i++
+				continue
+			}
+			hole := body[i+1 : i+end]
+			if strings.ContainsAny(hole, "{\"'\\\n\r") {
+				fail("interpolation holes contain literals or braces")
+			}
+			flush()
+			segments = append(segments, stringSegment{text: hole, hole: true})
+			i += end + 1
+		case c == '\r':
+			fail("contains a carriage return")
+			i++
+		default:
+			text.WriteByte(c)
+			i++
+		}
+	}
+	flush()
+	return segments, escapes, reason
+}
+
+func decodeEscape(s string) (rune, int, string) {
+	switch s[1] {
+	case '"', '\\', '\'':
+		return rune(s[1]), 2, ""
+	case 'n':
+		return '\n', 2, ""
+	case 'u', 'U', 'x':
+		digits := 4
+		if s[1] == 'U' {
+			digits = 8
+		}
+		end := 2
+		for end < len(s) && end < 2+digits && isHexDigit(s[end]) {
+			end++
+		}
+		if end == 2 || (s[1] != 'x' && end != 2+digits) {
+			return 0, 0, "malformed unicode escape"
+		}
+		v, err := strconv.ParseUint(s[2:end], 16, 32)
+		if err != nil || !utf8.ValidRune(rune(v)) || !unicode.IsPrint(rune(v)) {
+			return 0, 0, "contains escaped control or invisible characters"
+		}
+		return rune(v), end, ""
+	}
+	return 0, 0, "contains escaped control or invisible characters"
+}
+
+func isHexDigit(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+func rawLiteral(segments []stringSegment, indent string) string {
+	quotes, braces, multiline := 0, 0, false
+	holes := false
+	var all strings.Builder
+	for _, s := range segments {
+		if s.hole {
+			holes = true
+			all.WriteString("\x00")
+			continue
+		}
+		all.WriteString(s.text)
+		quotes = max(quotes, longestRun(s.text, '"'))
+		braces = max(braces, longestRun(s.text, '{'), longestRun(s.text, '}'))
+	}
+	value := all.String()
+	if strings.Contains(value, "\n") || strings.HasPrefix(value, `"`) || strings.HasSuffix(value, `"`) {
+		multiline = true
+	}
+
+	dollars := 0
+	if holes {
+		dollars = braces + 1
+	}
+	delimiter := strings.Repeat(`"`, max(3, quotes+1))
+	open, close := strings.Repeat("{", dollars), strings.Repeat("}", dollars)
+
+	var body strings.Builder
+	for _, s := range segments {
+		if s.hole {
+			body.WriteString(open + s.text + close)
+		} else {
+			body.WriteString(s.text)
+		}
+	}
+
+	prefix := strings.Repeat("$", dollars) + delimiter
+	if !multiline {
+		return prefix + body.String() + delimiter
+	}
+
+	inner := indent + "    "
+	if strings.HasPrefix(indent, "\t") {
+		inner = indent + "\t"
+	}
+	lines := strings.Split(body.String(), "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = inner + line
+		}
+	}
+	return prefix + "\n" + strings.Join(lines, "\n") + "\n" + inner + delimiter
+}
+
+func longestRun(s string, c byte) int {
+	longest, run := 0, 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == c {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return longest
 }
@@ -0,0 +1,418 @@
+package rules
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/andiq123/sharpify/internal/syntax"
+)
+
+type equalityProof struct {
+	className string
+	props     map[string]bool
+	vars      map[string]bool
+	compared  map[string]bool
+	delegates bool
+}
+
+func provenEquality(className string, members []classMember, props map[string]bool) bool {
+	var typed, untyped, others []*classMember
+	for i := range members {
+		m := &members[i]
+		if m.kind != memberEquality {
+			continue
+		}
+		switch {
+		case m.name != "Equals":
+			others = append(others, m)
+		case equalsParamType(m) == className:
+			typed = append(typed, m)
+		default:
+			untyped = append(untyped, m)
+		}
+	}
+	if len(typed)+len(untyped)+len(others) == 0 {
+		return true
+	}
+	if len(typed) > 1 || len(untyped) > 1 || len(typed)+len(untyped) == 0 {
+		return false
+	}
+
+	typedProven := false
+	for _, m := range typed {
+		proof, ok := proveEquals(className, m, props)
+		if !ok || proof.delegates || !proof.comparesAll() {
+			return false
+		}
+		typedProven = true
+	}
+	for _, m := range untyped {
+		proof, ok := proveEquals(className, m, props)
+		if !ok || !(proof.comparesAll() && !proof.delegates || typedProven && proof.delegates && len(proof.compared) == 0) {
+			return false
+		}
+	}
+	for _, m := range others {
+		if m.name != "GetHashCode" && !proveOperator(m) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *equalityProof) comparesAll() bool {
+	if len(p.compared) != len(p.props) {
+		return false
+	}
+	for name := range p.props {
+		if !p.compared[name] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalsParamType(m *classMember) string {
+	params, types, _, ok := equalityBody(m)
+	if !ok || len(params) != 1 {
+		return ""
+	}
+	return strings.TrimSuffix(types[0], "?")
+}
+
+func proveEquals(className string, m *classMember, props map[string]bool) (*equalityProof, bool) {
+	params, _, stmts, ok := equalityBody(m)
+	if !ok || len(params) != 1 || len(stmts) == 0 {
+		return nil, false
+	}
+	p := &equalityProof{
+		className: className,
+		props:     props,
+		vars:      map[string]bool{params[0]: true},
+		compared:  make(map[string]bool),
+	}
+
+	for _, stmt := range stmts[:len(stmts)-1] {
+		if !p.guardStatement(stmt) {
+			return nil, false
+		}
+	}
+	last := stmts[len(stmts)-1]
+	if len(last) < 3 || last[0] != "return" || last[len(last)-1] != ";" {
+		return nil, false
+	}
+	expr := stripParens(last[1 : len(last)-1])
+	if c, ok := captureShape(expr, "Equals ( $o as $C )"); ok && p.isVar(c["$o"]) && c["$C"] == className {
+		p.delegates = true
+		return p, true
+	}
+	for _, part := range splitTop(expr, "&&") {
+		if !p.conjunct(stripParens(part)) {
+			return nil, false
+		}
+	}
+	return p, true
+}
+
+func (p *equalityProof) guardStatement(stmt []string) bool {
+	for _, shape := range []string{
+		"if ( ReferenceEquals ( this , $o ) ) return true ;",
+		"if ( ReferenceEquals ( $o , this ) ) return true ;",
+	} {
+		if c, ok := captureShape(stmt, shape); ok && p.isVar(c["$o"]) {
+			return true
+		}
+	}
+	for _, shape := range []string{"var $v = $o as $C ;", "$C $v = $o as $C ;"} {
+		if c, ok := captureShape(stmt, shape); ok && p.isVar(c["$o"]) && c["$C"] == p.className {
+			p.vars[c["$v"]] = true
+			return true
+		}
+	}
+
+	n := len(stmt)
+	if n < 8 || stmt[0] != "if" || stmt[1] != "(" || !(stmt[n-3] == "return" && stmt[n-2] == "false" && stmt[n-1] == ";") {
+		return false
+	}
+	cond := stmt[1 : n-3]
+	if syntaxClose(cond, 0) != len(cond)-1 {
+		return false
+	}
+	for _, part := range splitTop(cond[1:len(cond)-1], "||") {
+		if !p.rejectsMismatch(stripParens(part)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *equalityProof) rejectsMismatch(toks []string) bool {
+	for _, shape := range []string{
+		"$o is null", "$o == null", "ReferenceEquals ( $o , null )", "object . ReferenceEquals ( $o , null )",
+		"$o is not $C", "GetType ( ) != $o . GetType ( )", "$o . GetType ( ) != GetType ( )",
+	} {
+		if c, ok := captureShape(toks, shape); ok && p.isVar(c["$o"]) && p.isClass(c) {
+			return true
+		}
+	}
+	if c, ok := captureShape(toks, "! ( $o is $C $v )"); ok && p.isVar(c["$o"]) && c["$C"] == p.className {
+		p.vars[c["$v"]] = true
+		return true
+	}
+	return false
+}
+
+func (p *equalityProof) conjunct(toks []string) bool {
+	if c, ok := captureShape(toks, "$o is $C $v"); ok && p.isVar(c["$o"]) && c["$C"] == p.className {
+		p.vars[c["$v"]] = true
+		return true
+	}
+	for _, shape := range []string{
+		"$o is $C", "$o is not null", "$o != null", "! ( $o is null )", "! ( $o == null )",
+		"! ReferenceEquals ( $o , null )", "! object . ReferenceEquals ( $o , null )",
+		"GetType ( ) == $o . GetType ( )", "$o . GetType ( ) == GetType ( )",
+	} {
+		if c, ok := captureShape(toks, shape); ok && p.isVar(c["$o"]) && p.isClass(c) {
+			return true
+		}
+	}
+	if c, ok := captureShape(toks, "Equals ( $v )"); ok && p.isVar(c["$v"]) {
+		p.delegates = true
+		return true
+	}
+
+	for _, shape := range []string{
+		"$P == $v . $P", "$v . $P == $P", "$P . Equals ( $v . $P )",
+		"Equals ( $P , $v . $P )", "Equals ( $v . $P , $P )",
+	} {
+		if c, ok := captureShape(staticEqualsCall(toks), shape); ok && p.isVar(c["$v"]) && p.props[c["$P"]] && !p.compared[c["$P"]] {
+			p.compared[c["$P"]] = true
+			return true
+		}
+	}
+	return false
+}
+
+func (p *equalityProof) isVar(name string) bool {
+	return p.vars[name]
+}
+
+func (p *equalityProof) isClass(c map[string]string) bool {
+	typ, ok := c["$C"]
+	return !ok || typ == p.className
+}
+
+func proveOperator(m *classMember) bool {
+	params, _, stmts, ok := equalityBody(m)
+	if !ok || len(params) != 2 || len(stmts) != 1 {
+		return false
+	}
+	stmt := stmts[0]
+	if len(stmt) < 3 || stmt[0] != "return" || stmt[len(stmt)-1] != ";" {
+		return false
+	}
+	expr := stripParens(stmt[1 : len(stmt)-1])
+	l, r := params[0], params[1]
+
+	if m.name == "operator !=" {
+		if len(expr) < 2 || expr[0] != "!" {
+			return false
+		}
+		expr = stripParens(expr[1:])
+		if c, ok := captureShape(expr, "$l == $r"); ok && c["$l"] == l && c["$r"] == r {
+			return true
+		}
+	}
+	for _, shape := range []string{
+		"Equals ( $l , $r )", "$l . Equals ( $r )",
+		"$l ?. Equals ( $r ) ?? $r is null", "$l ?. Equals ( $r ) ?? ( $r is null )",
+		"$l is null ? $r is null : $l . Equals ( $r )",
+	} {
+		if c, ok := captureShape(staticEqualsCall(expr), shape); ok && c["$l"] == l && c["$r"] == r {
+			return true
+		}
+	}
+	return false
+}
+
+func equalityBody(m *classMember) ([]string, []string, [][]string, bool) {
+	toks := m.toks
+	open := 0
+	for open < len(toks) && !toks[open].Is("(") {
+		open++
+	}
+	if open >= len(toks) {
+		return nil, nil, nil, false
+	}
+	close := syntax.MatchingClose(toks, open)
+	if close == -1 || close+1 >= len(toks) {
+		return nil, nil, nil, false
+	}
+
+	var params, types []string
+	for _, param := range splitTop(tokenTexts(toks[open+1:close]), ",") {
+		if len(param) < 2 {
+			return nil, nil, nil, false
+		}
+		params = append(params, param[len(param)-1])
+		types = append(types, strings.Join(param[:len(param)-1], ""))
+	}
+
+	body := tokenTexts(toks[close+1:])
+	switch {
+	case len(body) > 2 && body[0] == "=>":
+		return params, types, [][]string{append([]string{"return"}, body[1:]...)}, true
+	case len(body) > 1 && body[0] == "{" && syntaxClose(body, 0) == len(body)-1:
+		stmts, ok := splitStatements(body[1 : len(body)-1])
+		return params, types, stmts, ok
+	}
+	return nil, nil, nil, false
+}
+
+func splitStatements(block []string) ([][]string, bool) {
+	var toks []string
+	for _, t := range block {
+		if t != "{" && t != "}" {
+			toks = append(toks, t)
+		}
+	}
+
+	var stmts [][]string
+	for len(toks) > 0 {
+		end := -1
+		depth := 0
+		for i, t := range toks {
+			switch t {
+			case "(", "[":
+				depth++
+			case ")", "]":
+				depth--
+			case ";":
+				if depth == 0 && end == -1 {
+					end = i
+				}
+			}
+			if end != -1 {
+				break
+			}
+		}
+		if end == -1 {
+			return nil, false
+		}
+		stmts = append(stmts, toks[:end+1])
+		toks = toks[end+1:]
+	}
+	return stmts, true
+}
+
+func tokenTexts(toks []syntax.Token) []string {
+	var result []string
+	for i := 0; i < len(toks); i++ {
+		if toks[i].Is("this") && i+1 < len(toks) && toks[i+1].Is(".") {
+			i++
+			continue
+		}
+		result = append(result, toks[i].Text)
+	}
+	return result
+}
+
+func splitTop(toks []string, sep string) [][]string {
+	var parts [][]string
+	depth, from := 0, 0
+	for i, t := range toks {
+		switch t {
+		case "(", "[", "{":
+			depth++
+		case ")", "]", "}":
+			depth--
+		case sep:
+			if depth == 0 {
+				parts = append(parts, toks[from:i])
+				from = i + 1
+			}
+		}
+	}
+	return append(parts, toks[from:])
+}
+
+func stripParens(toks []string) []string {
+	for len(toks) > 2 && toks[0] == "(" && syntaxClose(toks, 0) == len(toks)-1 {
+		toks = toks[1 : len(toks)-1]
+	}
+	return toks
+}
+
+func syntaxClose(toks []string, open int) int {
+	closer := map[string]string{"(": ")", "[": "]", "{": "}"}[toks[open]]
+	depth := 0
+	for i := open; i < len(toks); i++ {
+		switch toks[i] {
+		case "(", "[", "{":
+			depth++
+		case ")", "]", "}":
+			depth--
+			if depth == 0 {
+				if toks[i] != closer {
+					return -1
+				}
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func staticEqualsCall(toks []string) []string {
+	if len(toks) > 2 && toks[1] == "." {
+		switch toks[0] {
+		case "object", "Object", "string", "String":
+			return toks[2:]
+		}
+	}
+	if len(toks) > 2 && toks[0] == "EqualityComparer" && toks[1] == "<" {
+		depth := 0
+		for i, t := range toks {
+			switch t {
+			case "<":
+				depth++
+			case ">":
+				depth--
+				if depth == 0 {
+					rest := toks[i+1:]
+					if len(rest) > 3 && rest[0] == "." && rest[1] == "Default" && rest[2] == "." {
+						return rest[3:]
+					}
+					return toks
+				}
+			}
+		}
+	}
+	return toks
+}
+
+var shapeIdentifier = regexp.MustCompile(`^@?[A-Za-z_]\w*$`)
+
+func captureShape(toks []string, shape string) (map[string]string, bool) {
+	pattern := strings.Fields(shape)
+	if len(pattern) != len(toks) {
+		return nil, false
+	}
+	captures := make(map[string]string)
+	for i, want := range pattern {
+		if !strings.HasPrefix(want, "$") {
+			if toks[i] != want {
+				return nil, false
+			}
+			continue
+		}
+		if !shapeIdentifier.MatchString(toks[i]) || syntax.IsKeyword(toks[i]) {
+			return nil, false
+		}
+		if prev, ok := captures[want]; ok && prev != toks[i] {
+			return nil, false
+		}
+		captures[want] = toks[i]
+	}
+	return captures, true
+}
@@ -0,0 +1,391 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/andiq123/sharpify/internal/syntax"
+)
+
+type RecordType struct {
+	BaseVersionedRule
+}
+
+func NewRecordType() *RecordType {
+	return &RecordType{
+		BaseVersionedRule: BaseVersionedRule{minVersion: CSharp9, safe: false},
+	}
+}
+
+func (r *RecordType) Name() string {
+	return "record-type"
+}
+
+func (r *RecordType) Description() string {
+	return "Convert immutable DTO classes to records (C# 9+)"
+}
+
+func (r *RecordType) Apply(content string) (string, bool) {
+	return r.ApplyContext(nil, content)
+}
+
+func (r *RecordType) ApplyContext(ctx *Context, content string) (string, bool) {
+	classes := recordCandidates(content)
+
+	changed := false
+	for i := len(classes) - 1; i >= 0; i-- {
+		c := classes[i]
+		if len(c.reasons) > 0 || !c.candidate {
+			continue
+		}
+		content = c.convert(ctx, content)
+		changed = true
+	}
+
+	return content, changed
+}
+
+func (r *RecordType) Analyze(content string) []Finding {
+	var findings []Finding
+	for _, c := range recordCandidates(content) {
+		if !c.candidate || len(c.reasons) == 0 || len(c.reasons) > 2 || c.hasMethods {
+			continue
+		}
+		findings = append(findings, Finding{
+			Rule:    r.Name(),
+			Line:    LineAt(content, c.start),
+			Message: fmt.Sprintf("Class '%s' is almost a record: %s", c.name, strings.Join(c.reasons, "; ")),
+		})
+	}
+	return findings
+}
+
+type recordCandidate struct {
+	*classDecl
+	reasons    []string
+	hasMethods bool
+	candidate  bool
+}
+
+func recordCandidates(content string) []*recordCandidate {
+	toks := syntax.Significant(syntax.Lex(content))
+
+	var result []*recordCandidate
+	for _, decl := range findClasses(content, toks) {
+		c := &recordCandidate{classDecl: decl, candidate: true}
+		if c.hasModifier("static") || c.hasModifier("abstract") {
+			c.candidate = false
+		}
+		if c.hasModifier("partial") {
+			c.reasons = append(c.reasons, "is partial, other parts may add members")
+		}
+
+		var kept []string
+		for _, base := range c.bases {
+			switch {
+			case base == "object" || base == "System.Object" || base == "Object":
+			case base == "IEquatable<"+c.name+">" || base == "System.IEquatable<"+c.name+">":
+			case interfaceNamePattern.MatchString(base):
+				kept = append(kept, base)
+			default:
+				c.reasons = append(c.reasons, fmt.Sprintf("inherits from %s", base))
+			}
+		}
+		c.bases = kept
+
+		c.classify(content)
+		result = append(result, c)
+	}
+	return result
+}
+
+var interfaceNamePattern = regexp.MustCompile(`^(?:[\w.]+\.)?I[A-Z]\w*(?:<.*>)?$`)
+
+func (c *recordCandidate) classify(content string) {
+	properties, ctors := 0, 0
+	props := make(map[string]bool)
+	equality := false
+	for _, m := range c.members {
+		switch m.kind {
+		case memberProperty:
+			if m.static {
+				continue
+			}
+			if !m.auto {
+				if len(m.accessors) > 0 {
+					c.reasons = append(c.reasons, fmt.Sprintf("property '%s' has accessor bodies", m.name))
+				}
+				continue
+			}
+			for _, a := range m.accessors {
+				if strings.HasSuffix(a, "set") {
+					c.reasons = append(c.reasons, fmt.Sprintf("property '%s' has a setter", m.name))
+				}
+			}
+			properties++
+			props[m.name] = true
+		case memberConstructor:
+			ctors++
+			if m.static {
+				c.reasons = append(c.reasons, "has a static constructor")
+			} else if !m.hasModifier("public") {
+				c.reasons = append(c.reasons, "constructor is not public")
+			}
+		case memberEquality:
+			equality = true
+			if start, end := memberLines(content, m.start, m.end); hasComments(content[start:end]) {
+				c.reasons = append(c.reasons, fmt.Sprintf("'%s' has comments that would be lost", m.name))
+			}
+		case memberField:
+			if !m.static {
+				c.reasons = append(c.reasons, fmt.Sprintf("has field '%s'", m.name))
+			}
+		case memberMethod:
+			if m.name == "ToString" || m.static {
+				continue
+			}
+			c.hasMethods = true
+			c.reasons = append(c.reasons, fmt.Sprintf("has method '%s'", m.name))
+		case memberOther:
+			c.reasons = append(c.reasons, fmt.Sprintf("declares a nested %s", m.name))
+		}
+	}
+
+	if properties == 0 {
+		c.candidate = false
+	}
+	if equality && !provenEquality(c.name, c.members, props) {
+		c.reasons = append(c.reasons, "custom equality does not compare exactly every property")
+	}
+	if ctors > 1 {
+		c.reasons = append(c.reasons, "has more than one constructor")
+	}
+	if ctors == 1 {
+		if _, ok := c.constructorMapping(); !ok {
+			c.reasons = append(c.reasons, "constructor does more than assign properties")
+		}
+	}
+}
+
+type recordParam struct {
+	name         string
+	property     *classMember
+	defaultValue string
+}
+
+func (c *recordCandidate) constructorMapping() ([]recordParam, bool) {
+	var ctor *classMember
+	props := make(map[string]*classMember)
+	for i := range c.members {
+		m := &c.members[i]
+		switch {
+		case m.kind == memberConstructor && !m.static:
+			ctor = m
+		case m.kind == memberProperty && m.auto && !m.static:
+			props[m.name] = m
+		}
+	}
+	if ctor == nil {
+		return nil, true
+	}
+
+	open := 0
+	for open < len(ctor.toks) && !ctor.toks[open].Is("(") {
+		open++
+	}
+	closeParen := syntax.MatchingClose(ctor.toks, open)
+	if closeParen == -1 || closeParen+1 >= len(ctor.toks) || !ctor.toks[closeParen+1].Is("{") {
+		return nil, false
+	}
+
+	type param struct{ name, def string }
+	var params []param
+	var current []syntax.Token
+	depth := 0
+	flush := func() bool {
+		if len(current) == 0 {
+			return true
+		}
+		eq := len(current)
+		for j, t := range current {
+			if t.Is("=") {
+				eq = j
+				break
+			}
+		}
+		if eq < 2 {
+			return false
+		}
+		p := param{name: current[eq-1].Text}
+		if eq < len(current) {
+			p.def = joinTokens(current[eq+1:])
+		}
+		for _, t := range current[:eq-1] {
+			if t.Is("ref") || t.Is("out") || t.Is("in") || t.Is("params") || t.Is("this") {
+				return false
+			}
+		}
+		params = append(params, p)
+		current = nil
+		return true
+	}
+	for _, t := range ctor.toks[open+1 : closeParen] {
+		switch {
+		case t.Is("<") || t.Is("(") || t.Is("["):
+			depth++
+		case t.Is(">") || t.Is(")") || t.Is("]"):
+			depth--
+		case t.Is(",") && depth == 0:
+			if !flush() {
+				return nil, false
+			}
+			continue
+		}
+		current = append(current, t)
+	}
+	if !flush() {
+		return nil, false
+	}
+
+	body := ctor.toks[closeParen+2 : len(ctor.toks)-1]
+	assigned := make(map[string]string)
+	for len(body) > 0 {
+		stmt := body
+		for j, t := range body {
+			if t.Is(";") {
+				stmt = body[:j+1]
+				break
+			}
+		}
+		body = body[len(stmt):]
+
+		if len(stmt) >= 5 && stmt[0].Is("this") && stmt[1].Is(".") {
+			stmt = stmt[2:]
+		}
+		if len(stmt) != 4 || !stmt[1].Is("=") || !stmt[3].Is(";") {
+			return nil, false
+		}
+		prop, value := stmt[0].Text, stmt[2].Text
+		if _, ok := props[prop]; !ok {
+			return nil, false
+		}
+		if _, dup := assigned[value]; dup {
+			return nil, false
+		}
+		assigned[value] = prop
+	}
+
+	if len(assigned) != len(params) {
+		return nil, false
+	}
+	result := make([]recordParam, 0, len(params))
+	for _, p := range params {
+		prop, ok := assigned[p.name]
+		if !ok {
+			return nil, false
+		}
+		result = append(result, recordParam{name: p.name, property: props[prop], defaultValue: p.def})
+	}
+	return result, true
+}
+
+func (c *recordCandidate) convert(ctx *Context, content string) string {
+	var edits []textEdit
+	for _, m := range c.members {
+		if m.kind == memberEquality {
+			start, end := memberLines(content, m.start, m.end)
+			edits = append(edits, textEdit{start, end, ""})
+		}
+	}
+
+	if params, ok := c.positionalParams(ctx, content); ok {
+		var decl []string
+		for _, p := range params {
+			param := p.property.typeText + " " + p.property.name
+			if p.defaultValue != "" {
+				param += " = " + p.defaultValue
+			}
+			decl = append(decl, param)
+		}
+		header := "record " + c.name + c.typeParams + "(" + strings.Join(decl, ", ") + ")"
+		if len(c.bases) > 0 {
+			header += " : " + strings.Join(c.bases, ", ")
+		}
+
+		for _, m := range c.members {
+			if m.kind == memberConstructor || (m.kind == memberProperty && m.auto && !m.static) {
+				start, end := memberLines(content, m.start, m.end)
+				edits = append(edits, textEdit{start, end, ""})
+			}
+		}
+
+		body := applyEdits(content[c.open.End:c.close.Pos], edits, c.open.End)
+		if strings.TrimSpace(body) == "" {
+			return content[:c.keyword.Pos] + header + ";" + content[c.close.End:]
+		}
+		indent := lineIndent(content, c.close.Pos)
+		return content[:c.keyword.Pos] + header + "\n" + indent + "{" + tidyBody(body) + "}" + content[c.close.End:]
+	}
+
+	header := "record"
+	headerEnd := c.keyword.End
+	if c.baseStart > 0 {
+		header = "record " + c.name + c.typeParams
+		if len(c.bases) > 0 {
+			header += " : " + strings.Join(c.bases, ", ")
+		}
+		headerEnd = c.baseEnd
+	}
+
+	body := applyEdits(content[c.open.End:c.close.Pos], edits, c.open.End)
+	return content[:c.keyword.Pos] + header + content[headerEnd:c.open.End] + tidyBody(body) + content[c.close.Pos:]
+}
+
+func (c *recordCandidate) positionalParams(ctx *Context, content string) ([]recordParam, bool) {
+	params, ok := c.constructorMapping()
+	if !ok || params == nil {
+		return nil, false
+	}
+	for _, p := range params {
+		if p.name != p.property.name && ctx.UsesNamedArgument(content, p.name) {
+			return nil, false
+		}
+	}
+	for _, m := range c.members {
+		if m.kind == memberConstructor || (m.kind == memberProperty && m.auto && !m.static) {
+			if start, end := memberLines(content, m.start, m.end); hasComments(content[start:end]) {
+				return nil, false
+			}
+		}
+	}
+
+	props := 0
+	for _, m := range c.members {
+		if m.kind != memberProperty || !m.auto || m.static {
+			continue
+		}
+		props++
+		if len(m.attributes) > 0 || m.hasInit || !strings.HasPrefix(joinTokens(m.toks), "public ") {
+			return nil, false
+		}
+		for _, a := range m.accessors {
+			if a != "get" && a != "init" {
+				return nil, false
+			}
+		}
+	}
+	if props != len(params) {
+		return nil, false
+	}
+	return params, true
+}
+
+func hasComments(text string) bool {
+	for _, t := range syntax.Lex(text) {
+		if t.IsTrivia() {
+			return true
+		}
+	}
+	return false
+}
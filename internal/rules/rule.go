@@ -1,5 +1,11 @@
 package rules
 
+import (
+	"encoding/json"
+
+	"github.com/andiq123/sharpify/internal/project"
+)
+
 
 type Rule interface {
 	Name() string
@@ -13,3 +19,48 @@ type RuleResult struct {
 	Applied     bool
 	Description string
 }
+
+
+type SourceFile struct {
+	Path    string
+	Content string
+}
+
+
+type Preparer interface {
+	Prepare(files []SourceFile)
+}
+
+
+type Configurable interface {
+	Configure(options json.RawMessage) error
+}
+
+
+type ProjectFiles struct {
+	Project *project.Project
+	Files   []SourceFile
+}
+
+
+type ProjectRule interface {
+	Rule
+	ApplyProject(p ProjectFiles) ([]SourceFile, bool)
+}
+
+
+type ProjectAnalyzer interface {
+	AnalyzeProject(p ProjectFiles) []Finding
+}
+
+
+type LiteralRule interface {
+	Rule
+	RewritesLiterals() bool
+}
+
+
+type FallibleRule interface {
+	Rule
+	TryApply(path, content string) (string, bool, error)
+}
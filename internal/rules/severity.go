@@ -0,0 +1,86 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Severity int
+
+const (
+	SeverityFix Severity = iota
+	SeveritySuggest
+	SeverityInfo
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityFix:
+		return "fix"
+	case SeveritySuggest:
+		return "suggest"
+	case SeverityInfo:
+		return "info"
+	default:
+		return "unknown"
+	}
+}
+
+func ParseSeverity(s string) (Severity, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "fix":
+		return SeverityFix, nil
+	case "suggest", "suggestion":
+		return SeveritySuggest, nil
+	case "info":
+		return SeverityInfo, nil
+	default:
+		return SeverityFix, fmt.Errorf("unknown severity %q (want fix, suggest or info)", s)
+	}
+}
+
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s *Severity) UnmarshalText(text []byte) error {
+	parsed, err := ParseSeverity(string(text))
+	if err != nil {
+		return err
+	}
+	*s = parsed
+	return nil
+}
+
+type Finding struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Line     int      `json:"line"`
+	Message  string   `json:"message"`
+}
+
+type Analyzer interface {
+	Rule
+	Analyze(content string) []Finding
+}
+
+type SeverityRule interface {
+	DefaultSeverity() Severity
+}
+
+func DefaultSeverityOf(rule Rule) Severity {
+	if sr, ok := rule.(SeverityRule); ok {
+		return sr.DefaultSeverity()
+	}
+	return SeverityFix
+}
+
+func LineAt(content string, offset int) int {
+	if offset > len(content) {
+		offset = len(content)
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return strings.Count(content[:offset], "\n") + 1
+}
@@ -11,7 +11,7 @@ type SpreadOperator struct {
 
 func NewSpreadOperator() *SpreadOperator {
 	return &SpreadOperator{
-		BaseVersionedRule: BaseVersionedRule{minVersion: CSharp12, safe: true},
+		BaseVersionedRule: BaseVersionedRule{minVersion: CSharp12, safe: false},
 	}
 }
 
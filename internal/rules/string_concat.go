@@ -24,6 +24,10 @@ func (r *StringConcatToInterpolation) Description() string {
 	return "Convert string concatenation to interpolation (C# 6+)"
 }
 
+func (r *StringConcatToInterpolation) RewritesLiterals() bool {
+	return true
+}
+
 func (r *StringConcatToInterpolation) Apply(content string) (string, bool) {
 	changed := false
 
@@ -24,6 +24,10 @@ func (r *StringInterpolation) Description() string {
 	return "Convert string.Format to interpolated strings (C# 6+)"
 }
 
+func (r *StringInterpolation) RewritesLiterals() bool {
+	return true
+}
+
 func (r *StringInterpolation) Apply(content string) (string, bool) {
 	pattern := regexp.MustCompile(`string\.Format\s*\(\s*"([^"]+)"\s*,\s*([^)]+)\)`)
 
@@ -28,31 +28,30 @@ func (r *StringIsNullOrEmpty) Apply(content string) (string, bool) {
 	result := content
 
 	
-	pattern1 := regexp.MustCompile(`string\.IsNullOrEmpty\s*\(\s*(\w+)\s*\)`)
+	pattern1 := regexp.MustCompile(`!\s*string\.IsNullOrEmpty\s*\(\s*(\w+)\s*\)`)
 	if pattern1.MatchString(result) {
-		result = pattern1.ReplaceAllString(result, "${1} is null or \"\"")
+		result = pattern1.ReplaceAllString(result, "${1} is not (null or \"\")")
 		changed = true
 	}
 
 	
-	
-	pattern2 := regexp.MustCompile(`!\s*string\.IsNullOrEmpty\s*\(\s*(\w+)\s*\)`)
+	pattern2 := regexp.MustCompile(`string\.IsNullOrEmpty\s*\(\s*(\w+)\s*\)`)
 	if pattern2.MatchString(result) {
-		result = pattern2.ReplaceAllString(result, "${1} is not (null or \"\")")
+		result = pattern2.ReplaceAllString(result, "${1} is null or \"\"")
 		changed = true
 	}
 
 	
-	pattern3 := regexp.MustCompile(`String\.IsNullOrEmpty\s*\(\s*(\w+)\s*\)`)
+	pattern3 := regexp.MustCompile(`!\s*String\.IsNullOrEmpty\s*\(\s*(\w+)\s*\)`)
 	if pattern3.MatchString(result) {
-		result = pattern3.ReplaceAllString(result, "${1} is null or \"\"")
+		result = pattern3.ReplaceAllString(result, "${1} is not (null or \"\")")
 		changed = true
 	}
 
 	
-	pattern4 := regexp.MustCompile(`!\s*String\.IsNullOrEmpty\s*\(\s*(\w+)\s*\)`)
+	pattern4 := regexp.MustCompile(`String\.IsNullOrEmpty\s*\(\s*(\w+)\s*\)`)
 	if pattern4.MatchString(result) {
-		result = pattern4.ReplaceAllString(result, "${1} is not (null or \"\")")
+		result = pattern4.ReplaceAllString(result, "${1} is null or \"\"")
 		changed = true
 	}
 
@@ -39,12 +39,16 @@ func (r *SwitchExpression) Apply(content string) (string, bool) {
 		indent := result[match[2]:match[3]]
 		varName := result[match[4]:match[5]]
 		body := result[match[6]:match[7]]
+		if strings.Contains(body, "{") {
+			continue
+		}
 
 		
-		expr, ok := r.convertToSwitchExpr(varName, body, indent)
+		lineIndent := indent[strings.LastIndex(indent, "\n")+1:]
+		expr, ok := r.convertToSwitchExpr(varName, body, lineIndent)
 		if ok {
 			
-			result = result[:match[0]] + expr + result[match[1]:]
+			result = result[:match[0]] + indent[:len(indent)-len(lineIndent)] + expr + result[match[1]:]
 			changed = true
 		}
 	}
@@ -0,0 +1,339 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/andiq123/sharpify/internal/syntax"
+)
+
+type PlaceholderKind int
+
+const (
+	PlaceholderExpr PlaceholderKind = iota
+	PlaceholderIdentifier
+	PlaceholderType
+)
+
+func (k PlaceholderKind) String() string {
+	switch k {
+	case PlaceholderIdentifier:
+		return "identifier"
+	case PlaceholderType:
+		return "type"
+	default:
+		return "expr"
+	}
+}
+
+func parsePlaceholderKind(s string) (PlaceholderKind, bool) {
+	switch s {
+	case "expr":
+		return PlaceholderExpr, true
+	case "identifier":
+		return PlaceholderIdentifier, true
+	case "type":
+		return PlaceholderType, true
+	}
+	return 0, false
+}
+
+type templateElem struct {
+	text        string
+	placeholder int
+}
+
+type Template struct {
+	elems []templateElem
+	names []string
+	kinds []PlaceholderKind
+}
+
+func ParseTemplate(src string) (*Template, error) {
+	toks := syntax.Significant(syntax.Lex(src))
+	if len(toks) == 0 {
+		return nil, fmt.Errorf("empty match template")
+	}
+
+	t := &Template{}
+	index := make(map[string]int)
+	for i := 0; i < len(toks); i++ {
+		tok := toks[i]
+		if tok.Unterminated {
+			return nil, fmt.Errorf("unterminated %s in match template", tok.Kind)
+		}
+		if !tok.Is("$") || i+1 >= len(toks) || toks[i+1].Kind != syntax.Identifier || toks[i+1].Pos != tok.End {
+			t.elems = append(t.elems, templateElem{text: tok.Text, placeholder: -1})
+			continue
+		}
+
+		name := toks[i+1].Text
+		kind := PlaceholderExpr
+		explicit := false
+		i++
+		if i+2 < len(toks) && toks[i+1].Is(":") && toks[i+1].Pos == toks[i].End && toks[i+2].Pos == toks[i+1].End {
+			k, ok := parsePlaceholderKind(toks[i+2].Text)
+			if !ok {
+				return nil, fmt.Errorf("placeholder $%s has unknown kind %q (want identifier, expr or type)", name, toks[i+2].Text)
+			}
+			kind, explicit = k, true
+			i += 2
+		}
+
+		n, seen := index[name]
+		if !seen {
+			n = len(t.names)
+			index[name] = n
+			t.names = append(t.names, name)
+			t.kinds = append(t.kinds, kind)
+		} else if explicit && t.kinds[n] != kind {
+			return nil, fmt.Errorf("placeholder $%s is used as both %s and %s", name, t.kinds[n], kind)
+		}
+		t.elems = append(t.elems, templateElem{placeholder: n})
+	}
+	return t, nil
+}
+
+type templateMatch struct {
+	start, end int
+	captures   [][2]int
+}
+
+func (t *Template) matches(toks []syntax.Token) []templateMatch {
+	var matches []templateMatch
+	for i := 0; i < len(toks); i++ {
+		if i > 0 && isMemberAccess(toks[i-1]) {
+			continue
+		}
+		m := &matcher{t: t, toks: toks, captures: make([][2]int, len(t.names))}
+		for j := range m.captures {
+			m.captures[j] = [2]int{-1, -1}
+		}
+		end, ok := m.match(0, i)
+		if !ok || end == i {
+			continue
+		}
+		matches = append(matches, templateMatch{start: i, end: end, captures: m.captures})
+		i = end - 1
+	}
+	return matches
+}
+
+func isMemberAccess(tok syntax.Token) bool {
+	return tok.Is(".") || tok.Is("?.") || tok.Is("::") || tok.Is("->")
+}
+
+type matcher struct {
+	t        *Template
+	toks     []syntax.Token
+	captures [][2]int
+}
+
+func (m *matcher) match(ei, ti int) (int, bool) {
+	if ei == len(m.t.elems) {
+		return ti, true
+	}
+	if ti >= len(m.toks) {
+		return 0, false
+	}
+
+	e := m.t.elems[ei]
+	if e.placeholder == -1 {
+		if m.toks[ti].Text != e.text {
+			return 0, false
+		}
+		return m.match(ei+1, ti+1)
+	}
+
+	n := e.placeholder
+	if prev := m.captures[n]; prev[0] != -1 {
+		end, ok := m.sameTokens(prev, ti)
+		if !ok {
+			return 0, false
+		}
+		return m.match(ei+1, end)
+	}
+
+	for _, end := range m.extents(m.t.kinds[n], ti, ei == len(m.t.elems)-1) {
+		m.captures[n] = [2]int{ti, end}
+		if result, ok := m.match(ei+1, end); ok {
+			return result, true
+		}
+	}
+	m.captures[n] = [2]int{-1, -1}
+	return 0, false
+}
+
+func (m *matcher) sameTokens(span [2]int, ti int) (int, bool) {
+	length := span[1] - span[0]
+	if ti+length > len(m.toks) {
+		return 0, false
+	}
+	for k := 0; k < length; k++ {
+		if m.toks[span[0]+k].Text != m.toks[ti+k].Text {
+			return 0, false
+		}
+	}
+	return ti + length, true
+}
+
+func (m *matcher) extents(kind PlaceholderKind, ti int, last bool) []int {
+	switch kind {
+	case PlaceholderIdentifier:
+		if m.toks[ti].Kind == syntax.Identifier {
+			return []int{ti + 1}
+		}
+		return nil
+	case PlaceholderType:
+		if end := typeExtent(m.toks, ti); end > ti {
+			return []int{end}
+		}
+		return nil
+	}
+
+	var ends []int
+	depth := 0
+	for k := ti; k < len(m.toks); k++ {
+		tok := m.toks[k]
+		switch {
+		case tok.Is("(") || tok.Is("[") || tok.Is("{"):
+			depth++
+		case tok.Is(")") || tok.Is("]") || tok.Is("}"):
+			if depth == 0 {
+				return lastExtent(ends, last)
+			}
+			depth--
+		case depth == 0 && (tok.Is(",") || tok.Is(";")):
+			return lastExtent(ends, last)
+		}
+		if depth == 0 {
+			ends = append(ends, k+1)
+		}
+	}
+	return lastExtent(ends, last)
+}
+
+func lastExtent(ends []int, last bool) []int {
+	if last && len(ends) > 0 {
+		return ends[len(ends)-1:]
+	}
+	return ends
+}
+
+func typeExtent(toks []syntax.Token, i int) int {
+	if i >= len(toks) || (toks[i].Kind != syntax.Identifier && toks[i].Kind != syntax.Keyword) {
+		return i
+	}
+	k := i + 1
+	for k+1 < len(toks) && (toks[k].Is(".") || toks[k].Is("::")) && toks[k+1].Kind == syntax.Identifier {
+		k += 2
+	}
+	if k < len(toks) && toks[k].Is("<") {
+		depth, j := 0, k
+		for ; j < len(toks) && depth >= 0; j++ {
+			tok := toks[j]
+			switch {
+			case tok.Is("<"):
+				depth++
+			case tok.Is(">"):
+				depth--
+			case tok.Is(">>"):
+				depth -= 2
+			case tok.Kind != syntax.Identifier && tok.Kind != syntax.Keyword &&
+				!tok.Is(",") && !tok.Is(".") && !tok.Is("?") && !tok.Is("[") && !tok.Is("]"):
+				depth = -1
+			}
+			if depth == 0 {
+				k = j + 1
+				break
+			}
+		}
+	}
+	if k < len(toks) && toks[k].Is("?") {
+		k++
+	}
+	for k+1 < len(toks) && toks[k].Is("[") {
+		j := k + 1
+		for j < len(toks) && toks[j].Is(",") {
+			j++
+		}
+		if j >= len(toks) || !toks[j].Is("]") {
+			break
+		}
+		k = j + 1
+	}
+	return k
+}
+
+var replacementRef = regexp.MustCompile(`\$([A-Za-z_][A-Za-z0-9_]*)`)
+
+type TemplateRule struct {
+	BaseVersionedRule
+	name        string
+	description string
+	match       *Template
+	replace     string
+}
+
+func NewTemplateRule(name, description string, minVersion CSharpVersion, safe bool, match, replace string) (*TemplateRule, error) {
+	t, err := ParseTemplate(match)
+	if err != nil {
+		return nil, err
+	}
+	defined := make(map[string]bool)
+	for _, n := range t.names {
+		defined[n] = true
+	}
+	for _, ref := range replacementRef.FindAllStringSubmatch(replace, -1) {
+		if !defined[ref[1]] {
+			return nil, fmt.Errorf("replacement uses $%s, which the match template does not define", ref[1])
+		}
+	}
+	if description == "" {
+		description = fmt.Sprintf("Rewrite %s", strings.Join(strings.Fields(match), " "))
+	}
+	return &TemplateRule{
+		BaseVersionedRule: BaseVersionedRule{minVersion: minVersion, safe: safe},
+		name:              name,
+		description:       description,
+		match:             t,
+		replace:           replace,
+	}, nil
+}
+
+func (r *TemplateRule) Name() string {
+	return r.name
+}
+
+func (r *TemplateRule) Description() string {
+	return r.description
+}
+
+func (r *TemplateRule) RewritesLiterals() bool {
+	return true
+}
+
+func (r *TemplateRule) Apply(content string) (string, bool) {
+	toks := syntax.Significant(syntax.Lex(content))
+	var edits []textEdit
+	for _, m := range r.match.matches(toks) {
+		text := replacementRef.ReplaceAllStringFunc(r.replace, func(ref string) string {
+			for n, name := range r.match.names {
+				if "$"+name == ref {
+					span := m.captures[n]
+					return content[toks[span[0]].Pos:toks[span[1]-1].End]
+				}
+			}
+			return ref
+		})
+		start, end := toks[m.start].Pos, toks[m.end-1].End
+		if content[start:end] != text {
+			edits = append(edits, textEdit{start, end, text})
+		}
+	}
+	if len(edits) == 0 {
+		return content, false
+	}
+	return applyEdits(content, edits, 0), true
+}
@@ -0,0 +1,106 @@
+package rules
+
+import "testing"
+
+func TestTemplateRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		match   string
+		replace string
+		input   string
+		want    string
+	}{
+		{
+			name:    "expression argument",
+			match:   "LegacyLog.Write($msg:expr)",
+			replace: "_logger.LogInformation($msg)",
+			input:   `LegacyLog.Write("saved " + Format(id, 2));`,
+			want:    `_logger.LogInformation("saved " + Format(id, 2));`,
+		},
+		{
+			name:    "several placeholders",
+			match:   "Helpers.IsEmpty($s:expr, $trim:expr)",
+			replace: "string.IsNullOrWhiteSpace($s)",
+			input:   "if (Helpers.IsEmpty(user.Name, true)) return;",
+			want:    "if (string.IsNullOrWhiteSpace(user.Name)) return;",
+		},
+		{
+			name:    "type placeholder",
+			match:   "ServiceLocator.Get<$T:type>()",
+			replace: "_services.GetRequiredService<$T>()",
+			input:   "var repo = ServiceLocator.Get<IRepository<Dictionary<string, int>>>();",
+			want:    "var repo = _services.GetRequiredService<IRepository<Dictionary<string, int>>>();",
+		},
+		{
+			name:    "identifier placeholder",
+			match:   "$x:identifier = $x:identifier + 1;",
+			replace: "$x++;",
+			input:   "count = count + 1;\nother = count + 1;",
+			want:    "count++;\nother = count + 1;",
+		},
+		{
+			name:    "whitespace and comments are ignored",
+			match:   "LegacyLog.Write($msg)",
+			replace: "_logger.LogInformation($msg)",
+			input:   "LegacyLog . Write ( message /* why */ + suffix );",
+			want:    "_logger.LogInformation(message /* why */ + suffix);",
+		},
+		{
+			name:    "member access prefix is not matched",
+			match:   "LegacyLog.Write($msg)",
+			replace: "_logger.LogInformation($msg)",
+			input:   "Old.LegacyLog.Write(message);",
+			want:    "Old.LegacyLog.Write(message);",
+		},
+		{
+			name:    "string contents are not matched",
+			match:   "LegacyLog.Write($msg)",
+			replace: "_logger.LogInformation($msg)",
+			input:   `var s = "LegacyLog.Write(message)";`,
+			want:    `var s = "LegacyLog.Write(message)";`,
+		},
+		{
+			name:    "trailing expression runs to the end of the statement",
+			match:   "return $e;",
+			replace: "return Wrap($e);",
+			input:   "return a ? b : Call(c, d);",
+			want:    "return Wrap(a ? b : Call(c, d));",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewTemplateRule("test", "", CSharp6, true, tt.match, tt.replace)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, changed := r.Apply(tt.input)
+			if got != tt.want {
+				t.Errorf("got  %q\nwant %q", got, tt.want)
+			}
+			if changed != (tt.input != tt.want) {
+				t.Errorf("changed = %v", changed)
+			}
+		})
+	}
+}
+
+func TestTemplateRuleErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		match   string
+		replace string
+	}{
+		{"empty match", "  ", ""},
+		{"unknown kind", "Foo($x:statement)", "Bar($x)"},
+		{"conflicting kinds", "Foo($x:expr, $x:type)", "Bar($x)"},
+		{"undefined placeholder", "Foo($x)", "Bar($y)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewTemplateRule("test", "", CSharp6, true, tt.match, tt.replace); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
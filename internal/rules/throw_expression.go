@@ -23,6 +23,10 @@ func (r *ThrowExpression) Description() string {
 	return "Use throw expressions for null checks (C# 7+)"
 }
 
+func (r *ThrowExpression) RewritesLiterals() bool {
+	return true
+}
+
 func (r *ThrowExpression) Apply(content string) (string, bool) {
 	changed := false
 	result := content
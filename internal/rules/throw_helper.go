@@ -30,34 +30,38 @@ func (r *ThrowHelper) Apply(content string) (string, bool) {
 
 	
 	
-	pattern1 := regexp.MustCompile(`(?m)^\s*if\s*\(\s*(\w+)\s+is\s+null\s*\)\s*\{?\s*\n?\s*throw\s+new\s+ArgumentNullException\s*\(\s*nameof\s*\(\s*(\w+)\s*\)\s*\)\s*;\s*\}?`)
+	pattern1 := regexp.MustCompile(`(?m)^\s*if\s*\(\s*(\w+)\s+is\s+null\s*\)\s*(\{)?\s*\n?\s*throw\s+new\s+ArgumentNullException\s*\(\s*nameof\s*\(\s*(\w+)\s*\)\s*\)\s*;(\s*\})?`)
 	matches := pattern1.FindAllStringSubmatchIndex(result, -1)
 
 	for i := len(matches) - 1; i >= 0; i-- {
 		match := matches[i]
 		varName1 := result[match[2]:match[3]]
-		varName2 := result[match[4]:match[5]]
+		varName2 := result[match[6]:match[7]]
+		end, ok := guardEnd(match)
+		if !ok {
+			continue
+		}
 
 		
 		if varName1 == varName2 {
 			replacement := "        ArgumentNullException.ThrowIfNull(" + varName1 + ");"
-			result = result[:match[0]] + replacement + result[match[1]:]
+			result = result[:match[0]] + replacement + result[end:]
 			changed = true
 		}
 	}
 
 	
-	pattern2 := regexp.MustCompile(`(?m)^\s*if\s*\(\s*(\w+)\s*==\s*null\s*\)\s*\{?\s*\n?\s*throw\s+new\s+ArgumentNullException\s*\(\s*nameof\s*\(\s*(\w+)\s*\)\s*\)\s*;\s*\}?`)
+	pattern2 := regexp.MustCompile(`(?m)^\s*if\s*\(\s*(\w+)\s*==\s*null\s*\)\s*(\{)?\s*\n?\s*throw\s+new\s+ArgumentNullException\s*\(\s*nameof\s*\(\s*(\w+)\s*\)\s*\)\s*;(\s*\})?`)
 	matches2 := pattern2.FindAllStringSubmatchIndex(result, -1)
 
 	for i := len(matches2) - 1; i >= 0; i-- {
 		match := matches2[i]
 		varName1 := result[match[2]:match[3]]
-		varName2 := result[match[4]:match[5]]
-		matchEnd := match[1]
+		varName2 := result[match[6]:match[7]]
+		matchEnd, ok := guardEnd(match)
 
 		
-		if varName1 != varName2 {
+		if varName1 != varName2 || !ok {
 			continue
 		}
 
@@ -66,23 +70,24 @@ func (r *ThrowHelper) Apply(content string) (string, bool) {
 		assignmentPattern := regexp.MustCompile(`^\s*\n\s*\w+\s*=\s*` + regexp.QuoteMeta(varName1))
 		if !assignmentPattern.MatchString(restOfContent) {
 			replacement := "        ArgumentNullException.ThrowIfNull(" + varName1 + ");"
-			result = result[:match[0]] + replacement + result[match[1]:]
+			result = result[:match[0]] + replacement + result[matchEnd:]
 			changed = true
 		}
 	}
 
 	
-	pattern3 := regexp.MustCompile(`(?m)^\s*if\s*\(\s*string\.IsNullOrEmpty\s*\(\s*(\w+)\s*\)\s*\)\s*\{?\s*\n?\s*throw\s+new\s+Argument(?:Null)?Exception\s*\([^)]*nameof\s*\(\s*(\w+)\s*\)[^)]*\)\s*;\s*\}?`)
+	pattern3 := regexp.MustCompile(`(?m)^\s*if\s*\(\s*string\.IsNullOrEmpty\s*\(\s*(\w+)\s*\)\s*\)\s*(\{)?\s*\n?\s*throw\s+new\s+Argument(?:Null)?Exception\s*\([^)]*nameof\s*\(\s*(\w+)\s*\)[^)]*\)\s*;(\s*\})?`)
 	matches3 := pattern3.FindAllStringSubmatchIndex(result, -1)
 
 	for i := len(matches3) - 1; i >= 0; i-- {
 		match := matches3[i]
 		varName1 := result[match[2]:match[3]]
-		varName2 := result[match[4]:match[5]]
+		varName2 := result[match[6]:match[7]]
 
-		if varName1 == varName2 {
+		end, ok := guardEnd(match)
+		if varName1 == varName2 && ok {
 			replacement := "        ArgumentException.ThrowIfNullOrEmpty(" + varName1 + ");"
-			result = result[:match[0]] + replacement + result[match[1]:]
+			result = result[:match[0]] + replacement + result[end:]
 			changed = true
 		}
 	}
@@ -91,3 +96,13 @@ func (r *ThrowHelper) Apply(content string) (string, bool) {
 
 	return result, changed
 }
+
+func guardEnd(match []int) (int, bool) {
+	switch {
+	case match[4] != -1:
+		return match[1], match[8] != -1
+	case match[8] != -1:
+		return match[8], true
+	}
+	return match[1], true
+}
@@ -2,6 +2,8 @@ package rules
 
 import (
 	"regexp"
+
+	"github.com/andiq123/sharpify/internal/syntax"
 )
 
 type TupleDeconstruction struct {
@@ -60,40 +62,19 @@ func (r *DiscardVariable) Description() string {
 }
 
 func (r *DiscardVariable) Apply(content string) (string, bool) {
-	changed := false
-	result := content
-
-	
-	
-	
-
-	
-	outVarPattern := regexp.MustCompile(`out\s+var\s+(\w+)`)
-	matches := outVarPattern.FindAllStringSubmatchIndex(result, -1)
-
-	
-	for i := len(matches) - 1; i >= 0; i-- {
-		match := matches[i]
-		varName := result[match[2]:match[3]]
-
-		
-		if varName == "_" {
+	binder := syntax.Bind(content)
+	var edits []textEdit
+	for _, match := range outVarPattern.FindAllStringSubmatchIndex(content, -1) {
+		decl := binder.Declaration(match[2])
+		if decl == nil || len(binder.References(decl)) > 0 {
 			continue
 		}
-
-		
-		afterDecl := result[match[1]:]
-		
-		usagePattern := regexp.MustCompile(`\b` + regexp.QuoteMeta(varName) + `\b`)
-		usageMatches := usagePattern.FindAllStringIndex(afterDecl, -1)
-
-		
-		if len(usageMatches) == 0 {
-			
-			result = result[:match[0]] + "out _" + result[match[1]:]
-			changed = true
-		}
+		edits = append(edits, textEdit{match[0], match[1], "out _"})
 	}
-
-	return result, changed
+	if len(edits) == 0 {
+		return content, false
+	}
+	return applyEdits(content, edits, 0), true
 }
+
+var outVarPattern = regexp.MustCompile(`out\s+var\s+(\w+)`)
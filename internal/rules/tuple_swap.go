@@ -3,8 +3,9 @@ package rules
 import (
 	"regexp"
 	"strings"
-)
 
+	"github.com/andiq123/sharpify/internal/syntax"
+)
 
 type TupleSwap struct {
 	BaseVersionedRule
@@ -25,88 +26,65 @@ func (r *TupleSwap) Description() string {
 }
 
 func (r *TupleSwap) Apply(content string) (string, bool) {
-	changed := false
-	result := content
-
-	
-	
-	
-	
-	
-	
-	lines := strings.Split(result, "\n")
+	lines := strings.Split(content, "\n")
+	offsets := make([]int, len(lines))
+	for i, pos := 1, 0; i < len(lines); i++ {
+		pos += len(lines[i-1]) + 1
+		offsets[i] = pos
+	}
 
+	var binder *syntax.Binder
+	var edits []textEdit
 	for i := 0; i < len(lines)-2; i++ {
-		
-		line1Pattern := regexp.MustCompile(`^(\s*)(var\s+)?(\w+)\s*=\s*(\w+)\s*;\s*$`)
-		match1 := line1Pattern.FindStringSubmatch(lines[i])
+		match1 := swapTempPattern.FindStringSubmatchIndex(lines[i])
 		if match1 == nil {
 			continue
 		}
-
-		indent := match1[1]
-		tempVar := match1[3]
-		varA := match1[4]
-
-		
-		line2Pattern := regexp.MustCompile(`^\s*(\w+)\s*=\s*(\w+)\s*;\s*$`)
-		match2 := line2Pattern.FindStringSubmatch(lines[i+1])
-		if match2 == nil {
+		match2 := swapAssignPattern.FindStringSubmatch(lines[i+1])
+		match3 := swapAssignPattern.FindStringSubmatchIndex(lines[i+2])
+		if match2 == nil || match3 == nil {
 			continue
 		}
 
-		
-		if match2[1] != varA {
-			continue
-		}
+		indent := lines[i][match1[2]:match1[3]]
+		tempVar := lines[i][match1[6]:match1[7]]
+		varA := lines[i][match1[8]:match1[9]]
 		varB := match2[2]
-
-		
-		match3 := line2Pattern.FindStringSubmatch(lines[i+2])
-		if match3 == nil {
+		if match2[1] != varA || lines[i+2][match3[2]:match3[3]] != varB || lines[i+2][match3[4]:match3[5]] != tempVar {
 			continue
 		}
 
-		
-		if match3[1] != varB || match3[2] != tempVar {
-			continue
+		if binder == nil {
+			binder = syntax.Bind(content)
 		}
-
-		
-		lowerTemp := strings.ToLower(tempVar)
-		isTempVar := lowerTemp == "temp" || lowerTemp == "tmp" || lowerTemp == "t" ||
-			lowerTemp == "swap" || strings.HasPrefix(lowerTemp, "temp") ||
-			strings.HasPrefix(lowerTemp, "tmp") || lowerTemp == "aux"
-
-		if !isTempVar {
+		tempPos := offsets[i] + match1[6]
+		if !isSwapTemp(binder, tempPos, match1[4] != -1, offsets[i], offsets[i+2]+len(lines[i+2])) {
 			continue
 		}
 
-		
 		replacement := indent + "(" + varA + ", " + varB + ") = (" + varB + ", " + varA + ");"
-		lines[i] = replacement
-		lines[i+1] = "" 
-		lines[i+2] = "" 
-		changed = true
-		i += 2 
+		edits = append(edits, textEdit{offsets[i], offsets[i+2] + len(lines[i+2]), replacement})
+		i += 2
 	}
 
-	if changed {
-		
-		result = ""
-		first := true
-		for _, line := range lines {
-			if line == "" {
-				
-				continue
-			}
-			if !first {
-				result += "\n"
-			}
-			result += line
-			first = false
-		}
+	if len(edits) == 0 {
+		return content, false
 	}
+	return applyEdits(content, edits, 0), true
+}
 
-	return result, changed
+var swapTempPattern = regexp.MustCompile(`^(\s*)(\w+\s+)?(\w+)\s*=\s*(\w+)\s*;\s*$`)
+var swapAssignPattern = regexp.MustCompile(`^\s*(\w+)\s*=\s*(\w+)\s*;\s*$`)
+
+func isSwapTemp(binder *syntax.Binder, pos int, declared bool, from, to int) bool {
+	decl := binder.Resolve(pos)
+	if decl == nil || decl.Kind != syntax.LocalDecl || declared != (decl.Pos == pos) {
+		return false
+	}
+	for _, ref := range binder.References(decl) {
+		if ref < from || ref >= to {
+			return false
+		}
+	}
+	return true
 }
@@ -0,0 +1,150 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/andiq123/sharpify/internal/syntax"
+)
+
+type usingKind int
+
+const (
+	usingNamespace usingKind = iota
+	usingStatic
+	usingAlias
+)
+
+type usingDirective struct {
+	kind        usingKind
+	global      bool
+	conditional bool
+	name        string
+	alias       string
+	start, end  int
+}
+
+func (d usingDirective) key() string {
+	if d.kind == usingStatic {
+		return "static " + d.name
+	}
+	return d.name
+}
+
+func (d usingDirective) text() string {
+	prefix := "using "
+	if d.global {
+		prefix = "global using "
+	}
+	switch d.kind {
+	case usingStatic:
+		return prefix + "static " + d.name + ";"
+	case usingAlias:
+		return prefix + d.alias + " = " + d.name + ";"
+	}
+	return prefix + d.name + ";"
+}
+
+func parseUsings(content string) []usingDirective {
+	toks := syntax.Lex(content)
+
+	var result []usingDirective
+	depth := 0
+	for i := 0; i < len(toks); i++ {
+		t := toks[i]
+		switch {
+		case t.Kind == syntax.Comment:
+			continue
+		case t.Kind == syntax.Preprocessor:
+			switch directive := preprocessorDirective(t.Text); directive {
+			case "if":
+				depth++
+			case "endif":
+				if depth > 0 {
+					depth--
+				}
+			}
+			continue
+		case t.Is("extern"):
+			for i < len(toks) && !toks[i].Is(";") {
+				i++
+			}
+			continue
+		}
+
+		d := usingDirective{conditional: depth > 0}
+		first := i
+		if t.Is("global") && i+1 < len(toks) && toks[i+1].Is("using") {
+			d.global = true
+			i++
+		}
+		if !toks[i].Is("using") {
+			return result
+		}
+
+		var body []syntax.Token
+		j := i + 1
+		for ; j < len(toks) && !toks[j].Is(";"); j++ {
+			if !toks[j].IsTrivia() {
+				body = append(body, toks[j])
+			}
+		}
+		if j == len(toks) || !parseUsingBody(&d, body) {
+			return result
+		}
+
+		d.start, d.end = memberLines(content, toks[first].Pos, toks[j].End)
+		result = append(result, d)
+		i = j
+	}
+	return result
+}
+
+func parseUsingBody(d *usingDirective, body []syntax.Token) bool {
+	switch {
+	case len(body) > 1 && body[0].Is("static"):
+		d.kind = usingStatic
+		d.name = joinTokens(body[1:])
+		return true
+	case len(body) > 2 && body[0].Kind == syntax.Identifier && body[1].Is("="):
+		d.kind = usingAlias
+		d.alias = body[0].Text
+		d.name = joinTokens(body[2:])
+		return true
+	}
+
+	if len(body) == 0 {
+		return false
+	}
+	for k, t := range body {
+		if k%2 == 0 && t.Kind != syntax.Identifier {
+			return false
+		}
+		if k%2 == 1 && !t.Is(".") && !t.Is("::") {
+			return false
+		}
+	}
+	d.kind = usingNamespace
+	d.name = joinTokens(body)
+	return true
+}
+
+func preprocessorDirective(text string) string {
+	text = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(text), "#"))
+	if i := strings.IndexAny(text, " \t("); i != -1 {
+		text = text[:i]
+	}
+	return text
+}
+
+func compareNamespaces(a, b string) bool {
+	staticA, staticB := strings.HasPrefix(a, "static "), strings.HasPrefix(b, "static ")
+	if staticA != staticB {
+		return staticB
+	}
+	systemA := a == "System" || strings.HasPrefix(a, "System.")
+	systemB := b == "System" || strings.HasPrefix(b, "System.")
+	if systemA != systemB {
+		return systemA
+	}
+	return a < b
+}
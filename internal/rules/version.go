@@ -1,5 +1,12 @@
 package rules
 
+import (
+	"strconv"
+	"strings"
+
+	"github.com/andiq123/sharpify/internal/project"
+)
+
 
 type CSharpVersion int
 
@@ -63,6 +70,64 @@ func (v CSharpVersion) DotNetVersion() string {
 }
 
 
+func ParseVersion(s string) (CSharpVersion, bool) {
+	s = strings.TrimSpace(strings.ToLower(s))
+	s = strings.TrimSpace(strings.TrimPrefix(s, "c#"))
+	s = strings.TrimSuffix(strings.TrimSuffix(s, ".0"), ".x")
+	n, err := strconv.Atoi(s)
+	if err != nil || n < int(CSharp6) || n > int(CSharp13) {
+		return 0, false
+	}
+	return CSharpVersion(n), true
+}
+
+
+func ProjectVersion(p *project.Project) (CSharpVersion, bool) {
+	switch lang := strings.ToLower(strings.TrimSpace(p.LangVersion)); lang {
+	case "", "default":
+		return FrameworkVersion(p.TargetFramework)
+	case "latest", "latestmajor", "preview":
+		return CSharp13, true
+	default:
+		major, _, _ := strings.Cut(lang, ".")
+		return ParseVersion(major)
+	}
+}
+
+
+func FrameworkVersion(tfm string) (CSharpVersion, bool) {
+	tfm = strings.ToLower(strings.TrimSpace(tfm))
+	tfm, _, _ = strings.Cut(tfm, "-")
+	switch {
+	case strings.HasPrefix(tfm, "netcoreapp"):
+		if strings.HasPrefix(tfm, "netcoreapp3") {
+			return CSharp8, true
+		}
+		return CSharp7, true
+	case strings.HasPrefix(tfm, "netstandard"):
+		if tfm == "netstandard2.1" {
+			return CSharp8, true
+		}
+		return CSharp7, true
+	case strings.HasPrefix(tfm, "net"):
+		version := strings.TrimPrefix(tfm, "net")
+		major, _, dotted := strings.Cut(version, ".")
+		n, err := strconv.Atoi(major)
+		if err != nil {
+			return 0, false
+		}
+		if !dotted {
+			return CSharp7, true
+		}
+		if n+4 > int(CSharp13) {
+			return CSharp13, true
+		}
+		return CSharpVersion(n + 4), n >= 5
+	}
+	return 0, false
+}
+
+
 type VersionedRule interface {
 	Rule
 	MinVersion() CSharpVersion
@@ -73,6 +138,7 @@ type VersionedRule interface {
 type BaseVersionedRule struct {
 	minVersion CSharpVersion
 	safe       bool
+	severity   Severity
 }
 
 func (r *BaseVersionedRule) MinVersion() CSharpVersion {
@@ -82,3 +148,7 @@ func (r *BaseVersionedRule) MinVersion() CSharpVersion {
 func (r *BaseVersionedRule) IsSafe() bool {
 	return r.safe
 }
+
+func (r *BaseVersionedRule) DefaultSeverity() Severity {
+	return r.severity
+}
@@ -0,0 +1,33 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/andiq123/sharpify/internal/project"
+)
+
+func TestProjectVersion(t *testing.T) {
+	tests := []struct {
+		lang, tfm string
+		want      CSharpVersion
+		ok        bool
+	}{
+		{"", "net8.0", CSharp12, true},
+		{"", "net6.0-windows", CSharp10, true},
+		{"", "net48", CSharp7, true},
+		{"", "netcoreapp3.1", CSharp8, true},
+		{"", "netstandard2.0", CSharp7, true},
+		{"", "net10.0", CSharp13, true},
+		{"10.0", "net8.0", CSharp10, true},
+		{"7.3", "net48", CSharp7, true},
+		{"latest", "net6.0", CSharp13, true},
+		{"default", "net7.0", CSharp11, true},
+		{"", "", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := ProjectVersion(&project.Project{LangVersion: tt.lang, TargetFramework: tt.tfm})
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ProjectVersion(%q, %q) = %v, %v; want %v, %v", tt.lang, tt.tfm, got, ok, tt.want, tt.ok)
+		}
+	}
+}
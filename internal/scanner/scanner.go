@@ -1,6 +1,7 @@
 package scanner
 
 import (
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -39,9 +40,7 @@ func (s *CSharpScanner) Scan(root string) ([]FileInfo, error) {
 		}
 
 		if info.IsDir() {
-			
-			name := info.Name()
-			if name == "bin" || name == "obj" || name == ".git" || name == "node_modules" {
+			if skipDir(info.Name()) {
 				return filepath.SkipDir
 			}
 			return nil
@@ -64,6 +63,62 @@ func (s *CSharpScanner) Scan(root string) ([]FileInfo, error) {
 	return files, err
 }
 
+func (s *CSharpScanner) ScanFS(fsys fs.FS) ([]FileInfo, error) {
+	var files []FileInfo
+
+	err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if path != "." && skipDir(d.Name()) {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		if s.isCSharpFile(path) {
+			content, err := fs.ReadFile(fsys, path)
+			if err != nil {
+				return err
+			}
+			files = append(files, FileInfo{
+				Path:    path,
+				Content: string(content),
+			})
+		}
+
+		return nil
+	})
+
+	return files, err
+}
+
+func (s *CSharpScanner) Includes(root, path string) bool {
+	if !s.isCSharpFile(path) {
+		return false
+	}
+	rel, err := filepath.Rel(root, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return false
+	}
+	for _, dir := range strings.Split(filepath.Dir(rel), string(filepath.Separator)) {
+		if skipDir(dir) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *CSharpScanner) SkipDir(name string) bool {
+	return skipDir(name)
+}
+
+func skipDir(name string) bool {
+	return name == "bin" || name == "obj" || name == ".git" || name == "node_modules"
+}
+
 func (s *CSharpScanner) isCSharpFile(path string) bool {
 	ext := strings.ToLower(filepath.Ext(path))
 	for _, e := range s.extensions {
@@ -0,0 +1,144 @@
+package stats
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+var featureLabels = map[string]string{
+	FeatureClean:          "Files without legacy patterns",
+	FeatureFileScoped:     "File-scoped namespaces",
+	FeatureNullable:       "Nullable reference types",
+	FeatureImplicitUsings: "Implicit usings",
+}
+
+func Write(w io.Writer, r *Report, format string) error {
+	switch strings.ToLower(format) {
+	case "", "text":
+		return WriteText(w, r)
+	case "json":
+		return WriteJSON(w, r)
+	case "markdown", "md":
+		return WriteMarkdown(w, r)
+	}
+	return fmt.Errorf("unknown format %q (want text, json or markdown)", format)
+}
+
+func WriteJSON(w io.Writer, r *Report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+func WriteText(w io.Writer, r *Report) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Modernization score: %.1f/100\n", r.Score)
+	fmt.Fprintf(&b, "%d C# file(s), %d line(s), %d legacy pattern(s)\n", r.Files, r.Lines, r.Findings)
+
+	b.WriteString("\nAdoption:\n")
+	for _, f := range r.Features {
+		fmt.Fprintf(&b, "  %-32s %s\n", featureLabels[f.Name], share(f))
+	}
+
+	if len(r.Rules) > 0 {
+		b.WriteString("\nLegacy patterns by rule:\n")
+		for _, c := range r.Rules {
+			fmt.Fprintf(&b, "  %-28s %5d in %d file(s)\n", c.Rule, c.Count, c.Files)
+		}
+	}
+
+	if len(r.Projects) > 0 {
+		b.WriteString("\nProjects:\n")
+		for _, p := range r.Projects {
+			fmt.Fprintf(&b, "  %-28s score %5.1f  %d file(s), %d pattern(s)", p.Name, p.Score, p.Files, p.Findings)
+			if p.TargetFramework != "" {
+				fmt.Fprintf(&b, "  %s", p.TargetFramework)
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	if len(r.Hotspots) > 0 {
+		b.WriteString("\nHotspots:\n")
+		for _, f := range r.Hotspots {
+			fmt.Fprintf(&b, "  %5d  %s (%s)\n", f.Findings, f.Path, topRules(f.Rules, 3))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func WriteMarkdown(w io.Writer, r *Report) error {
+	var b strings.Builder
+	b.WriteString("# Modernization report\n\n")
+	fmt.Fprintf(&b, "**Score: %.1f/100** · %d C# file(s) · %d line(s) · %d legacy pattern(s) · %s\n",
+		r.Score, r.Files, r.Lines, r.Findings, r.Generated.Format("2006-01-02"))
+
+	b.WriteString("\n## Adoption\n\n| Feature | Files | Share | Weight |\n|---|---|---|---|\n")
+	for _, f := range r.Features {
+		fmt.Fprintf(&b, "| %s | %d/%d | %s | %.0f |\n", featureLabels[f.Name], f.Adopted, f.Total, percent(f), f.Weight)
+	}
+
+	if len(r.Rules) > 0 {
+		b.WriteString("\n## Legacy patterns\n\n| Rule | Count | Files |\n|---|---|---|\n")
+		for _, c := range r.Rules {
+			fmt.Fprintf(&b, "| `%s` | %d | %d |\n", c.Rule, c.Count, c.Files)
+		}
+	}
+
+	if len(r.Projects) > 0 {
+		b.WriteString("\n## Projects\n\n| Project | Framework | Score | Files | Patterns |\n|---|---|---|---|---|\n")
+		for _, p := range r.Projects {
+			fmt.Fprintf(&b, "| %s | %s | %.1f | %d | %d |\n", p.Name, p.TargetFramework, p.Score, p.Files, p.Findings)
+		}
+	}
+
+	if len(r.Hotspots) > 0 {
+		b.WriteString("\n## Hotspots\n\n| File | Patterns | Top rules |\n|---|---|---|\n")
+		for _, f := range r.Hotspots {
+			fmt.Fprintf(&b, "| `%s` | %d | %s |\n", f.Path, f.Findings, topRules(f.Rules, 3))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func share(f Feature) string {
+	if f.Total == 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%d/%d  %s", f.Adopted, f.Total, percent(f))
+}
+
+func percent(f Feature) string {
+	if f.Total == 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%.0f%%", float64(f.Adopted)*100/float64(f.Total))
+}
+
+func topRules(counts map[string]int, limit int) string {
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	if len(names) > limit {
+		names = names[:limit]
+	}
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s %d", name, counts[name])
+	}
+	return strings.Join(parts, ", ")
+}
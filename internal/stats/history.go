@@ -0,0 +1,109 @@
+package stats
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const HistoryFile = ".sharpify/history.jsonl"
+
+type Snapshot struct {
+	Time     time.Time                  `json:"time"`
+	Commit   string                     `json:"commit,omitempty"`
+	Score    float64                    `json:"score"`
+	Files    int                        `json:"files"`
+	Findings int                        `json:"findings"`
+	Rules    map[string]int             `json:"rules"`
+	Projects map[string]ProjectSnapshot `json:"projects,omitempty"`
+}
+
+type ProjectSnapshot struct {
+	Score    float64        `json:"score"`
+	Files    int            `json:"files"`
+	Findings int            `json:"findings"`
+	Rules    map[string]int `json:"rules,omitempty"`
+}
+
+func NewSnapshot(r *Report, commit string) Snapshot {
+	s := Snapshot{
+		Time:     r.Generated,
+		Commit:   commit,
+		Score:    r.Score,
+		Files:    r.Files,
+		Findings: r.Findings,
+		Rules:    counts(r.Rules),
+		Projects: make(map[string]ProjectSnapshot, len(r.Projects)),
+	}
+	for _, p := range r.Projects {
+		key := p.Path
+		if key == "" {
+			key = p.Name
+		}
+		s.Projects[key] = ProjectSnapshot{Score: p.Score, Files: p.Files, Findings: p.Findings, Rules: counts(p.Rules)}
+	}
+	return s
+}
+
+func counts(rules []RuleCount) map[string]int {
+	result := make(map[string]int, len(rules))
+	for _, c := range rules {
+		result[c.Rule] = c.Count
+	}
+	return result
+}
+
+func HistoryPath(root string) string {
+	return filepath.Join(root, HistoryFile)
+}
+
+func AppendHistory(path string, s Snapshot) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func LoadHistory(path string) ([]Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var history []Snapshot
+	lines := bufio.NewScanner(bytes.NewReader(data))
+	lines.Buffer(make([]byte, 0, 64*1024), len(data)+1)
+	for n := 1; lines.Scan(); n++ {
+		line := bytes.TrimSpace(lines.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var s Snapshot
+		if err := json.Unmarshal(line, &s); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, n, err)
+		}
+		history = append(history, s)
+	}
+	return history, lines.Err()
+}
@@ -0,0 +1,355 @@
+package stats
+
+import (
+	"math"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/andiq123/sharpify/internal/diff"
+	"github.com/andiq123/sharpify/internal/project"
+	"github.com/andiq123/sharpify/internal/rules"
+	"github.com/andiq123/sharpify/internal/scanner"
+	"github.com/andiq123/sharpify/internal/syntax"
+	"github.com/andiq123/sharpify/internal/transformer"
+)
+
+const (
+	FeatureClean          = "no-legacy-patterns"
+	FeatureFileScoped     = "file-scoped-namespaces"
+	FeatureNullable       = "nullable-enabled"
+	FeatureImplicitUsings = "implicit-usings"
+
+	hotspotLimit = 10
+)
+
+var weights = map[string]float64{
+	FeatureClean:          50,
+	FeatureFileScoped:     20,
+	FeatureNullable:       20,
+	FeatureImplicitUsings: 10,
+}
+
+var featureOrder = []string{FeatureClean, FeatureFileScoped, FeatureNullable, FeatureImplicitUsings}
+
+type Report struct {
+	Root      string         `json:"root"`
+	Generated time.Time      `json:"generated"`
+	Score     float64        `json:"score"`
+	Files     int            `json:"files"`
+	Lines     int            `json:"lines"`
+	Findings  int            `json:"findings"`
+	Features  []Feature      `json:"features"`
+	Rules     []RuleCount    `json:"rules"`
+	Projects  []ProjectStats `json:"projects"`
+	Hotspots  []FileStats    `json:"hotspots"`
+}
+
+type Feature struct {
+	Name    string  `json:"name"`
+	Adopted int     `json:"adopted"`
+	Total   int     `json:"total"`
+	Share   float64 `json:"share"`
+	Weight  float64 `json:"weight"`
+}
+
+type RuleCount struct {
+	Rule        string `json:"rule"`
+	Description string `json:"description"`
+	Count       int    `json:"count"`
+	Files       int    `json:"files"`
+}
+
+type ProjectStats struct {
+	Name            string      `json:"name"`
+	Path            string      `json:"path,omitempty"`
+	TargetFramework string      `json:"targetFramework,omitempty"`
+	LangVersion     string      `json:"langVersion,omitempty"`
+	Score           float64     `json:"score"`
+	Files           int         `json:"files"`
+	Findings        int         `json:"findings"`
+	Features        []Feature   `json:"features"`
+	Rules           []RuleCount `json:"rules"`
+}
+
+type FileStats struct {
+	Path     string         `json:"path"`
+	Project  string         `json:"project,omitempty"`
+	Lines    int            `json:"lines"`
+	Findings int            `json:"findings"`
+	Rules    map[string]int `json:"rules,omitempty"`
+
+	namespace  bool
+	fileScoped bool
+	nullable   bool
+	implicit   bool
+}
+
+func Collect(root string, files []scanner.FileInfo, ruleList []rules.Rule) *Report {
+	locator := project.NewLocator()
+	stats := make([]*FileStats, len(files))
+	byPath := make(map[string]*FileStats, len(files))
+	owners := make(map[*FileStats]*project.Project, len(files))
+	for i, f := range files {
+		p := locator.Owner(f.Path)
+		s := &FileStats{Path: rel(root, f.Path), Lines: len(diff.Lines(f.Content)), Rules: make(map[string]int)}
+		s.namespace, s.fileScoped = namespaceStyle(f.Content)
+		s.nullable = nullableDirective(f.Content)
+		if p != nil {
+			s.Project = p.Name()
+			s.nullable = s.nullable || strings.EqualFold(p.Nullable, "enable")
+			s.implicit = p.ImplicitUsingsEnabled()
+		}
+		stats[i] = s
+		byPath[absPath(f.Path)] = s
+		owners[s] = p
+	}
+
+	descriptions := make(map[string]string, len(ruleList))
+	for _, rule := range ruleList {
+		descriptions[rule.Name()] = rule.Description()
+		t := transformer.New([]rules.Rule{rule})
+		t.SetSeverities(map[string]rules.Severity{rule.Name(): rules.SeverityFix})
+		for _, res := range t.TransformAll(files) {
+			s, ok := byPath[absPath(res.File.Path)]
+			if !ok {
+				continue
+			}
+			count := 0
+			if res.Changed {
+				count = sites(res.File.Content, res.NewContent)
+			}
+			for _, f := range res.Findings {
+				if f.Rule == rule.Name() {
+					count++
+				}
+			}
+			if count > 0 {
+				s.Rules[rule.Name()] += count
+				s.Findings += count
+			}
+		}
+	}
+
+	report := &Report{Root: root, Generated: time.Now().UTC(), Files: len(stats)}
+	for _, s := range stats {
+		report.Lines += s.Lines
+		report.Findings += s.Findings
+	}
+	report.Features = features(stats)
+	report.Score = score(report.Features)
+	report.Rules = ruleCounts(stats, descriptions)
+	report.Projects = projectStats(root, stats, owners, descriptions)
+	report.Hotspots = hotspots(stats)
+	return report
+}
+
+func projectStats(root string, stats []*FileStats, owners map[*FileStats]*project.Project, descriptions map[string]string) []ProjectStats {
+	groups := make(map[*project.Project][]*FileStats)
+	var order []*project.Project
+	for _, s := range stats {
+		p := owners[s]
+		if _, ok := groups[p]; !ok {
+			order = append(order, p)
+		}
+		groups[p] = append(groups[p], s)
+	}
+
+	result := make([]ProjectStats, 0, len(order))
+	for _, p := range order {
+		files := groups[p]
+		ps := ProjectStats{Name: "(no project)", Files: len(files)}
+		if p != nil {
+			ps.Name = p.Name()
+			ps.Path = rel(root, p.Path)
+			ps.TargetFramework = p.TargetFramework
+			ps.LangVersion = p.LangVersion
+		}
+		for _, s := range files {
+			ps.Findings += s.Findings
+		}
+		ps.Features = features(files)
+		ps.Score = score(ps.Features)
+		ps.Rules = ruleCounts(files, descriptions)
+		result = append(result, ps)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Path != result[j].Path {
+			return result[i].Path < result[j].Path
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+func features(stats []*FileStats) []Feature {
+	counts := make(map[string]*Feature, len(featureOrder))
+	for _, name := range featureOrder {
+		counts[name] = &Feature{Name: name, Weight: weights[name]}
+	}
+	adopt := func(name string, applicable, adopted bool) {
+		if !applicable {
+			return
+		}
+		counts[name].Total++
+		if adopted {
+			counts[name].Adopted++
+		}
+	}
+	for _, s := range stats {
+		adopt(FeatureClean, true, s.Findings == 0)
+		adopt(FeatureFileScoped, s.namespace, s.fileScoped)
+		adopt(FeatureNullable, true, s.nullable)
+		adopt(FeatureImplicitUsings, s.Project != "", s.implicit)
+	}
+
+	result := make([]Feature, 0, len(featureOrder))
+	for _, name := range featureOrder {
+		f := counts[name]
+		if f.Total > 0 {
+			f.Share = round(float64(f.Adopted) / float64(f.Total))
+		}
+		result = append(result, *f)
+	}
+	return result
+}
+
+func score(features []Feature) float64 {
+	var total, weight float64
+	for _, f := range features {
+		if f.Total == 0 {
+			continue
+		}
+		total += f.Weight * float64(f.Adopted) / float64(f.Total)
+		weight += f.Weight
+	}
+	if weight == 0 {
+		return 100
+	}
+	return math.Round(total/weight*1000) / 10
+}
+
+func ruleCounts(stats []*FileStats, descriptions map[string]string) []RuleCount {
+	counts := make(map[string]*RuleCount)
+	for _, s := range stats {
+		for name, n := range s.Rules {
+			c, ok := counts[name]
+			if !ok {
+				c = &RuleCount{Rule: name, Description: descriptions[name]}
+				counts[name] = c
+			}
+			c.Count += n
+			c.Files++
+		}
+	}
+
+	result := make([]RuleCount, 0, len(counts))
+	for _, c := range counts {
+		result = append(result, *c)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Rule < result[j].Rule
+	})
+	return result
+}
+
+func hotspots(stats []*FileStats) []FileStats {
+	var result []FileStats
+	for _, s := range stats {
+		if s.Findings > 0 {
+			result = append(result, *s)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Findings != result[j].Findings {
+			return result[i].Findings > result[j].Findings
+		}
+		return result[i].Path < result[j].Path
+	})
+	if len(result) > hotspotLimit {
+		result = result[:hotspotLimit]
+	}
+	return result
+}
+
+func namespaceStyle(content string) (bool, bool) {
+	tokens := significant(syntax.Lex(content))
+	for i, t := range tokens {
+		if t.Kind != syntax.Keyword || t.Text != "namespace" {
+			continue
+		}
+		for _, next := range tokens[i+1:] {
+			if next.Kind == syntax.Identifier || next.Text == "." {
+				continue
+			}
+			return true, next.Text == ";"
+		}
+		return true, false
+	}
+	return false, false
+}
+
+func nullableDirective(content string) bool {
+	for _, t := range syntax.Lex(content) {
+		if t.Kind != syntax.Preprocessor {
+			continue
+		}
+		fields := strings.Fields(strings.TrimPrefix(t.Text, "#"))
+		if len(fields) > 1 && fields[0] == "nullable" && fields[1] == "enable" {
+			return true
+		}
+	}
+	return false
+}
+
+func sites(before, after string) int {
+	count := 0
+	for _, h := range diff.Hunks(trimLines(before), trimLines(after)) {
+		if len(h.New) == 0 && strings.Trim(h.OldText(), "{} \t\r\n") == "" {
+			continue
+		}
+		count++
+	}
+	return count
+}
+
+func trimLines(content string) string {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func significant(tokens []syntax.Token) []syntax.Token {
+	result := tokens[:0:0]
+	for _, t := range tokens {
+		if t.Kind != syntax.Comment && t.Kind != syntax.Preprocessor {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+func rel(root, path string) string {
+	r, err := filepath.Rel(root, path)
+	if err != nil || strings.HasPrefix(r, "..") {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(r)
+}
+
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
+func round(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
@@ -0,0 +1,114 @@
+package stats
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andiq123/sharpify/internal/rules"
+	"github.com/andiq123/sharpify/internal/scanner"
+)
+
+type renameRule struct{}
+
+func (renameRule) Name() string        { return "rename-foo" }
+func (renameRule) Description() string { return "Rename Foo to Bar" }
+
+func (renameRule) Apply(content string) (string, bool) {
+	return strings.ReplaceAll(content, "Foo", "Bar"), strings.Contains(content, "Foo")
+}
+
+const csproj = `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <TargetFramework>net8.0</TargetFramework>
+    <ImplicitUsings>enable</ImplicitUsings>
+  </PropertyGroup>
+</Project>
+`
+
+func collect(t *testing.T) *Report {
+	t.Helper()
+	root := t.TempDir()
+	sources := map[string]string{
+		"App/App.csproj": csproj,
+		"App/A.cs":       "namespace App;\n\nclass A\n{\n    Foo a;\n    int x;\n    int y;\n    int z;\n    Foo b;\n}\n",
+		"App/B.cs":       "#nullable enable\nnamespace App\n{\n    class B { }\n}\n",
+		"Loose.cs":       "class C { Foo c; }\n",
+	}
+	var files []scanner.FileInfo
+	for name, content := range sources {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if strings.HasSuffix(name, ".cs") {
+			files = append(files, scanner.FileInfo{Path: path, Content: content})
+		}
+	}
+	return Collect(root, files, []rules.Rule{renameRule{}})
+}
+
+func TestCollectCountsPatternsAndFeatures(t *testing.T) {
+	r := collect(t)
+	if r.Files != 3 || r.Findings != 3 {
+		t.Fatalf("files = %d, findings = %d", r.Files, r.Findings)
+	}
+	if len(r.Rules) != 1 || r.Rules[0].Count != 3 || r.Rules[0].Files != 2 {
+		t.Fatalf("rules = %+v", r.Rules)
+	}
+	if len(r.Hotspots) != 2 || r.Hotspots[0].Path != "App/A.cs" || r.Hotspots[0].Findings != 2 {
+		t.Fatalf("hotspots = %+v", r.Hotspots)
+	}
+
+	want := map[string][2]int{
+		FeatureClean:          {1, 3},
+		FeatureFileScoped:     {1, 2},
+		FeatureNullable:       {1, 3},
+		FeatureImplicitUsings: {2, 2},
+	}
+	for _, f := range r.Features {
+		if got := [2]int{f.Adopted, f.Total}; got != want[f.Name] {
+			t.Errorf("%s = %v, want %v", f.Name, got, want[f.Name])
+		}
+	}
+
+	if r.Score != 43.3 {
+		t.Errorf("score = %v", r.Score)
+	}
+	if len(r.Projects) != 2 || r.Projects[1].Name != "App" || r.Projects[1].Files != 2 {
+		t.Fatalf("projects = %+v", r.Projects)
+	}
+}
+
+func TestWriteFormats(t *testing.T) {
+	r := collect(t)
+
+	var out bytes.Buffer
+	if err := Write(&out, r, "json"); err != nil {
+		t.Fatal(err)
+	}
+	var decoded Report
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil || decoded.Score != r.Score {
+		t.Fatalf("json round trip: %v, %+v", err, decoded)
+	}
+
+	out.Reset()
+	if err := Write(&out, r, "markdown"); err != nil || !strings.Contains(out.String(), "| `rename-foo` | 3 | 2 |") {
+		t.Fatalf("markdown: %v\n%s", err, out.String())
+	}
+
+	out.Reset()
+	if err := Write(&out, r, "text"); err != nil || !strings.Contains(out.String(), "Modernization score: 43.3/100") {
+		t.Fatalf("text: %v\n%s", err, out.String())
+	}
+
+	if err := Write(&out, r, "xml"); err == nil {
+		t.Fatal("expected an error for an unknown format")
+	}
+}
@@ -0,0 +1,184 @@
+package stats
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+type Regression struct {
+	Kind   string  `json:"kind"`
+	Name   string  `json:"name"`
+	Before float64 `json:"before"`
+	After  float64 `json:"after"`
+}
+
+func (r Regression) String() string {
+	switch r.Kind {
+	case "score":
+		return fmt.Sprintf("score dropped %.1f → %.1f", r.Before, r.After)
+	case "project":
+		return fmt.Sprintf("%s: %.0f → %.0f legacy pattern(s) (+%.0f)", r.Name, r.Before, r.After, r.After-r.Before)
+	}
+	return fmt.Sprintf("%s: %.0f → %.0f (+%.0f)", r.Name, r.Before, r.After, r.After-r.Before)
+}
+
+func Regressions(prev, cur Snapshot) []Regression {
+	var result []Regression
+	if cur.Score < prev.Score {
+		result = append(result, Regression{Kind: "score", Name: "score", Before: prev.Score, After: cur.Score})
+	}
+	for _, name := range sortedNames(cur.Rules) {
+		if cur.Rules[name] > prev.Rules[name] {
+			result = append(result, Regression{Kind: "rule", Name: name, Before: float64(prev.Rules[name]), After: float64(cur.Rules[name])})
+		}
+	}
+	projects := make([]string, 0, len(cur.Projects))
+	for name := range cur.Projects {
+		projects = append(projects, name)
+	}
+	sort.Strings(projects)
+	for _, name := range projects {
+		before, ok := prev.Projects[name]
+		if ok && cur.Projects[name].Findings > before.Findings {
+			result = append(result, Regression{Kind: "project", Name: name, Before: float64(before.Findings), After: float64(cur.Projects[name].Findings)})
+		}
+	}
+	return result
+}
+
+func WriteTrend(w io.Writer, history []Snapshot, limit int) error {
+	if len(history) == 0 {
+		_, err := fmt.Fprintf(w, "No history yet; run `sharpify stats` to record a snapshot in %s\n", HistoryFile)
+		return err
+	}
+	if limit > 0 && len(history) > limit {
+		history = history[len(history)-limit:]
+	}
+	first, last := history[0], history[len(history)-1]
+	prev := last
+	if len(history) > 1 {
+		prev = history[len(history)-2]
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Modernization trend: %d snapshot(s) from %s to %s\n\n",
+		len(history), first.Time.Local().Format("2006-01-02"), last.Time.Local().Format("2006-01-02"))
+
+	width := len(history)
+	if width < 5 {
+		width = 5
+	}
+	row := func(name string, values []float64, now, change string) {
+		spark := sparkline(values)
+		spark += strings.Repeat(" ", width-utf8.RuneCountInString(spark))
+		fmt.Fprintf(&b, "  %-32s %s %8s %8s\n", name, spark, now, change)
+	}
+
+	scores := series(history, func(s Snapshot) float64 { return s.Score })
+	findings := series(history, func(s Snapshot) float64 { return float64(s.Findings) })
+	fmt.Fprintf(&b, "  %-32s %-*s %8s %8s\n", "", width, "Trend", "Now", "Change")
+	row("Score", scores, fmt.Sprintf("%.1f", last.Score), signed(last.Score-prev.Score, "%+.1f"))
+	row("Legacy patterns", findings, fmt.Sprint(last.Findings), signed(float64(last.Findings-prev.Findings), "%+.0f"))
+
+	rules := make(map[string]int)
+	for _, s := range history {
+		for name := range s.Rules {
+			rules[name] = last.Rules[name]
+		}
+	}
+	if len(rules) > 0 {
+		b.WriteString("\nRules\n")
+		for _, name := range sortedNames(rules) {
+			values := series(history, func(s Snapshot) float64 { return float64(s.Rules[name]) })
+			row(name, values, fmt.Sprint(last.Rules[name]), signed(float64(last.Rules[name]-prev.Rules[name]), "%+.0f"))
+		}
+	}
+
+	projects := make(map[string]int)
+	for _, s := range history {
+		for name := range s.Projects {
+			projects[name] = last.Projects[name].Findings
+		}
+	}
+	if len(projects) > 1 {
+		b.WriteString("\nProjects (legacy patterns)\n")
+		for _, name := range sortedNames(projects) {
+			values := series(history, func(s Snapshot) float64 { return float64(s.Projects[name].Findings) })
+			now := last.Projects[name].Findings
+			row(name, values, fmt.Sprint(now), signed(float64(now-prev.Projects[name].Findings), "%+.0f"))
+		}
+	}
+
+	if len(history) > 1 {
+		since := prev.Time.Local().Format("2006-01-02 15:04")
+		if prev.Commit != "" {
+			since += " (" + prev.Commit + ")"
+		}
+		regressions := Regressions(prev, last)
+		if len(regressions) == 0 {
+			fmt.Fprintf(&b, "\n✓ No regressions since %s\n", since)
+		} else {
+			fmt.Fprintf(&b, "\n▲ %d regression(s) since %s:\n", len(regressions), since)
+			for _, r := range regressions {
+				fmt.Fprintf(&b, "  ✗ %s\n", r)
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func series(history []Snapshot, value func(Snapshot) float64) []float64 {
+	values := make([]float64, len(history))
+	for i, s := range history {
+		values[i] = value(s)
+	}
+	return values
+}
+
+func sparkline(values []float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo = min(lo, v)
+		hi = max(hi, v)
+	}
+	var b strings.Builder
+	for _, v := range values {
+		i := 0
+		if hi > lo {
+			i = int((v - lo) / (hi - lo) * float64(len(sparks)-1))
+		}
+		b.WriteRune(sparks[i])
+	}
+	return b.String()
+}
+
+func signed(delta float64, format string) string {
+	if delta == 0 {
+		return "="
+	}
+	return fmt.Sprintf(format, delta)
+}
+
+func sortedNames(counts map[string]int) []string {
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
@@ -0,0 +1,63 @@
+package stats
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHistoryRoundTripAndRegressions(t *testing.T) {
+	path := HistoryPath(t.TempDir())
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	snapshots := []Snapshot{
+		{Time: start, Commit: "abc1234", Score: 60, Findings: 5, Rules: map[string]int{"var-pattern": 3, "nameof": 2},
+			Projects: map[string]ProjectSnapshot{"App/App.csproj": {Findings: 5}}},
+		{Time: start.Add(24 * time.Hour), Score: 55, Findings: 6, Rules: map[string]int{"var-pattern": 1, "nameof": 5},
+			Projects: map[string]ProjectSnapshot{"App/App.csproj": {Findings: 6}}},
+	}
+	for _, s := range snapshots {
+		if err := AppendHistory(path, s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	history, err := LoadHistory(path)
+	if err != nil || len(history) != 2 || history[0].Commit != "abc1234" || history[1].Rules["nameof"] != 5 {
+		t.Fatalf("history = %+v, %v", history, err)
+	}
+
+	regressions := Regressions(history[0], history[1])
+	var names []string
+	for _, r := range regressions {
+		names = append(names, r.Kind+":"+r.Name)
+	}
+	if got := strings.Join(names, ","); got != "score:score,rule:nameof,project:App/App.csproj" {
+		t.Fatalf("regressions = %s", got)
+	}
+
+	var out bytes.Buffer
+	if err := WriteTrend(&out, history, 0); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"2 snapshot(s)", "▁█", "3 regression(s)", "(abc1234)", "nameof: 2 → 5 (+3)"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("trend output is missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestLoadHistoryReportsBadLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	if history, err := LoadHistory(path); err != nil || history != nil {
+		t.Fatalf("missing history = %v, %v", history, err)
+	}
+	if err := os.WriteFile(path, []byte("{\"score\":1}\nnot json\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadHistory(path); err == nil || !strings.Contains(err.Error(), ":2:") {
+		t.Fatalf("err = %v", err)
+	}
+}
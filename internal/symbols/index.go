@@ -0,0 +1,216 @@
+package symbols
+
+import (
+	"sort"
+	"strings"
+)
+
+type TypeKind int
+
+const (
+	Class TypeKind = iota
+	Struct
+	Interface
+	Record
+	Enum
+)
+
+func (k TypeKind) String() string {
+	switch k {
+	case Class:
+		return "class"
+	case Struct:
+		return "struct"
+	case Interface:
+		return "interface"
+	case Record:
+		return "record"
+	case Enum:
+		return "enum"
+	default:
+		return "unknown"
+	}
+}
+
+type MemberKind int
+
+const (
+	Field MemberKind = iota
+	Property
+	Method
+	Constructor
+	Event
+)
+
+type Using struct {
+	Namespace string
+	Alias     string
+	Static    bool
+	Global    bool
+}
+
+type Member struct {
+	Name   string
+	Kind   MemberKind
+	Type   string
+	Static bool
+	Line   int
+}
+
+type Type struct {
+	Name      string
+	Namespace string
+	Outer     string
+	Kind      TypeKind
+	Partial   bool
+	Bases     []string
+	Members   []Member
+	File      string
+	Line      int
+}
+
+func (t *Type) FullName() string {
+	name := t.Name
+	if t.Outer != "" {
+		name = t.Outer + "." + name
+	}
+	if t.Namespace != "" {
+		name = t.Namespace + "." + name
+	}
+	return name
+}
+
+type File struct {
+	Path       string
+	Namespaces []string
+	Usings     []Using
+	Types      []*Type
+
+	namedArguments map[string]bool
+}
+
+type Index struct {
+	files map[string]*File
+	types map[string][]*Type
+}
+
+func New() *Index {
+	return &Index{
+		files: make(map[string]*File),
+		types: make(map[string][]*Type),
+	}
+}
+
+func (x *Index) Add(path, content string) {
+	if old, ok := x.files[path]; ok {
+		x.remove(old)
+	}
+	f := parseFile(path, content)
+	x.files[path] = f
+	for _, t := range f.Types {
+		x.types[t.Name] = append(x.types[t.Name], t)
+	}
+}
+
+func (x *Index) remove(f *File) {
+	for _, t := range f.Types {
+		parts := x.types[t.Name]
+		kept := parts[:0]
+		for _, p := range parts {
+			if p != t {
+				kept = append(kept, p)
+			}
+		}
+		x.types[t.Name] = kept
+	}
+}
+
+func (x *Index) File(path string) *File {
+	return x.files[path]
+}
+
+func (x *Index) Files() []*File {
+	files := make([]*File, 0, len(x.files))
+	for _, f := range x.files {
+		files = append(files, f)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files
+}
+
+func (x *Index) UsesNamedArgument(name string) bool {
+	for _, f := range x.files {
+		if f.namedArguments[name] {
+			return true
+		}
+	}
+	return false
+}
+
+func (x *Index) Types(name string) []*Type {
+	return x.types[baseName(name)]
+}
+
+func (x *Index) Parts(t *Type) []*Type {
+	var parts []*Type
+	for _, p := range x.types[t.Name] {
+		if p.FullName() == t.FullName() {
+			parts = append(parts, p)
+		}
+	}
+	return parts
+}
+
+func (x *Index) Member(typeName, member string) (Member, bool) {
+	seen := make(map[string]bool)
+	queue := []string{typeName}
+	for len(queue) > 0 {
+		name := baseName(queue[0])
+		queue = queue[1:]
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		for _, t := range x.types[name] {
+			for _, m := range t.Members {
+				if m.Name == member {
+					return m, true
+				}
+			}
+			queue = append(queue, t.Bases...)
+		}
+	}
+	return Member{}, false
+}
+
+func (x *Index) Inherits(typeName, base string) bool {
+	base = baseName(base)
+	seen := make(map[string]bool)
+	queue := []string{typeName}
+	for len(queue) > 0 {
+		name := baseName(queue[0])
+		queue = queue[1:]
+		if name == base {
+			return true
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		for _, t := range x.types[name] {
+			queue = append(queue, t.Bases...)
+		}
+	}
+	return false
+}
+
+func baseName(name string) string {
+	if i := strings.IndexByte(name, '<'); i != -1 {
+		name = name[:i]
+	}
+	if i := strings.LastIndexAny(name, ".:"); i != -1 {
+		name = name[i+1:]
+	}
+	return strings.TrimSpace(name)
+}
@@ -0,0 +1,165 @@
+package symbols
+
+import "testing"
+
+func TestIndexMergesPartialTypes(t *testing.T) {
+	x := New()
+	x.Add("Order.cs", `namespace Shop;
+
+public partial class Order : Entity
+{
+    public int Id { get; set; }
+}
+`)
+	x.Add("Order.Totals.cs", `namespace Shop
+{
+    public partial class Order : IComparable<Order>
+    {
+        private decimal _total;
+        public decimal Total() => _total;
+    }
+}
+`)
+	x.Add("Other/Order.cs", `namespace Archive;
+
+public partial class Order
+{
+    public string Legacy { get; set; }
+}
+`)
+	x.Add("Entity.cs", `namespace Shop;
+
+public abstract class Entity
+{
+    public DateTime Created { get; init; }
+}
+`)
+
+	types := x.Types("Order")
+	if len(types) != 3 {
+		t.Fatalf("Types(Order) = %d, want 3", len(types))
+	}
+	var shop *Type
+	for _, typ := range types {
+		if typ.File == "Order.cs" {
+			shop = typ
+		}
+	}
+	if shop == nil || shop.FullName() != "Shop.Order" || !shop.Partial {
+		t.Fatalf("Order.cs type = %+v", shop)
+	}
+
+	parts := x.Parts(shop)
+	if len(parts) != 2 {
+		t.Fatalf("Parts(Shop.Order) = %d, want 2", len(parts))
+	}
+	for _, p := range parts {
+		if p.Namespace != "Shop" {
+			t.Errorf("part from %s has namespace %q", p.File, p.Namespace)
+		}
+	}
+
+	tests := []struct {
+		member string
+		kind   MemberKind
+		found  bool
+	}{
+		{"Id", Property, true},
+		{"_total", Field, true},
+		{"Total", Method, true},
+		{"Created", Property, true},
+		{"Missing", Field, false},
+	}
+	for _, tt := range tests {
+		m, ok := x.Member("Shop.Order", tt.member)
+		if ok != tt.found || (ok && m.Kind != tt.kind) {
+			t.Errorf("Member(Order, %s) = %+v, %v", tt.member, m, ok)
+		}
+	}
+
+	if !x.Inherits("Order", "Entity") || !x.Inherits("Order", "System.IComparable<Order>") {
+		t.Error("Order should inherit bases declared in either part")
+	}
+	if x.Inherits("Entity", "Order") {
+		t.Error("Entity should not inherit from Order")
+	}
+}
+
+func TestIndexReplacesFile(t *testing.T) {
+	x := New()
+	x.Add("A.cs", "class A { int Old; }")
+	x.Add("A.cs", "class A { int New; }")
+
+	if n := len(x.Types("A")); n != 1 {
+		t.Fatalf("Types(A) = %d after re-adding the file, want 1", n)
+	}
+	if _, ok := x.Member("A", "Old"); ok {
+		t.Error("member from the replaced content is still indexed")
+	}
+	if _, ok := x.Member("A", "New"); !ok {
+		t.Error("member from the new content is missing")
+	}
+}
+
+func TestIndexNamedArguments(t *testing.T) {
+	x := New()
+	x.Add("A.cs", "class A { void M() { var p = new Point(x: 1, @y: 2); var q = ok ? a : b; } }")
+
+	for name, want := range map[string]bool{"x": true, "y": true, "a": false, "ok": false} {
+		if got := x.UsesNamedArgument(name); got != want {
+			t.Errorf("UsesNamedArgument(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestParseFile(t *testing.T) {
+	f := parseFile("A.cs", `global using System;
+using Json = System.Text.Json;
+using static System.Math;
+
+namespace App.Core
+{
+    public class Outer
+    {
+        public record Inner(int X, string Name);
+        public event EventHandler Changed;
+        public Outer() { }
+        public const int A = 1, B = 2;
+    }
+
+    public enum Color { Red, Green }
+}
+`)
+	if len(f.Usings) != 3 || !f.Usings[0].Global || f.Usings[1].Alias != "Json" || !f.Usings[2].Static {
+		t.Fatalf("usings = %+v", f.Usings)
+	}
+	if len(f.Namespaces) != 1 || f.Namespaces[0] != "App.Core" {
+		t.Fatalf("namespaces = %v", f.Namespaces)
+	}
+
+	byName := make(map[string]*Type)
+	for _, typ := range f.Types {
+		byName[typ.Name] = typ
+	}
+	inner := byName["Inner"]
+	if inner == nil || inner.Kind != Record || inner.FullName() != "App.Core.Outer.Inner" {
+		t.Fatalf("Inner = %+v", inner)
+	}
+	if len(inner.Members) != 2 || inner.Members[0].Name != "X" || inner.Members[0].Kind != Property {
+		t.Errorf("primary constructor members = %+v", inner.Members)
+	}
+	if byName["Color"] == nil || byName["Color"].Kind != Enum {
+		t.Errorf("Color = %+v", byName["Color"])
+	}
+
+	kinds := make(map[string]MemberKind)
+	for _, m := range byName["Outer"].Members {
+		kinds[m.Name] = m.Kind
+	}
+	want := map[string]MemberKind{"Changed": Event, "Outer": Constructor, "A": Field, "B": Field}
+	for name, kind := range want {
+		if got, ok := kinds[name]; !ok || got != kind {
+			t.Errorf("Outer.%s kind = %v (found %v), want %v", name, got, ok, kind)
+		}
+	}
+}
@@ -0,0 +1,431 @@
+package symbols
+
+import (
+	"strings"
+
+	"github.com/andiq123/sharpify/internal/syntax"
+)
+
+var typeKinds = map[string]TypeKind{
+	"class":     Class,
+	"struct":    Struct,
+	"interface": Interface,
+	"record":    Record,
+	"enum":      Enum,
+}
+
+var modifiers = map[string]bool{
+	"public": true, "internal": true, "private": true, "protected": true,
+	"static": true, "readonly": true, "virtual": true, "override": true,
+	"sealed": true, "abstract": true, "new": true, "const": true,
+	"required": true, "extern": true, "unsafe": true, "volatile": true,
+	"async": true, "partial": true, "file": true, "ref": true,
+}
+
+type parser struct {
+	content string
+	toks    []syntax.Token
+	file    *File
+}
+
+func parseFile(path, content string) *File {
+	p := &parser{
+		content: content,
+		toks:    syntax.Significant(syntax.Lex(content)),
+		file:    &File{Path: path},
+	}
+	p.scope(0, len(p.toks), "", nil)
+	p.file.namedArguments = NamedArguments(p.toks)
+	return p.file
+}
+
+func NamedArguments(toks []syntax.Token) map[string]bool {
+	names := make(map[string]bool)
+	for i := 1; i+1 < len(toks); i++ {
+		if toks[i].Kind == syntax.Identifier && toks[i+1].Is(":") && (toks[i-1].Is("(") || toks[i-1].Is(",")) {
+			names[strings.TrimPrefix(toks[i].Text, "@")] = true
+		}
+	}
+	return names
+}
+
+func (p *parser) line(i int) int {
+	return strings.Count(p.content[:p.toks[i].Pos], "\n") + 1
+}
+
+func (p *parser) scope(from, to int, ns string, outer *Type) {
+	toks := p.toks
+	for i := from; i < to; {
+		t := toks[i]
+		switch {
+		case outer == nil && (t.Is("using") || (t.Is("global") && i+1 < to && toks[i+1].Is("using"))):
+			i = p.using(i, to)
+		case outer == nil && t.Is("namespace"):
+			j := i + 1
+			for j < to && !toks[j].Is(";") && !toks[j].Is("{") {
+				j++
+			}
+			if j >= to {
+				return
+			}
+			name := joinText(toks[i+1 : j])
+			if ns != "" {
+				name = ns + "." + name
+			}
+			p.file.Namespaces = append(p.file.Namespaces, name)
+			if toks[j].Is(";") {
+				ns = name
+				i = j + 1
+				continue
+			}
+			end := syntax.MatchingClose(toks, j)
+			if end == -1 || end > to {
+				return
+			}
+			p.scope(j+1, end, name, nil)
+			i = end + 1
+		default:
+			end := p.declaration(i, to, ns, outer)
+			if end <= i {
+				end = i + 1
+			}
+			i = end
+		}
+	}
+}
+
+func (p *parser) using(i, to int) int {
+	toks := p.toks
+	u := Using{}
+	if toks[i].Is("global") {
+		u.Global = true
+		i++
+	}
+	end := i + 1
+	for end < to && !toks[end].Is(";") {
+		if toks[end].Is("(") || toks[end].Is("{") {
+			return end
+		}
+		end++
+	}
+	body := toks[i+1 : end]
+	switch {
+	case len(body) > 1 && body[0].Is("static"):
+		u.Static = true
+		u.Namespace = joinText(body[1:])
+	case len(body) > 2 && body[1].Is("="):
+		u.Alias = body[0].Text
+		u.Namespace = joinText(body[2:])
+	case len(body) > 0:
+		u.Namespace = joinText(body)
+	}
+	if u.Namespace != "" {
+		p.file.Usings = append(p.file.Usings, u)
+	}
+	return end + 1
+}
+
+func (p *parser) declaration(i, to int, ns string, outer *Type) int {
+	toks := p.toks
+	start := i
+	for i < to && toks[i].Is("[") {
+		end := syntax.MatchingClose(toks, i)
+		if end == -1 {
+			return to
+		}
+		i = end + 1
+	}
+	partial := false
+	for i < to && toks[i].Kind != syntax.Punct && modifiers[toks[i].Text] {
+		partial = partial || toks[i].Text == "partial"
+		i++
+	}
+	if i >= to {
+		return to
+	}
+
+	kind, ok := typeKinds[toks[i].Text]
+	if !ok || toks[i].Kind == syntax.Punct {
+		if start == i {
+			return i + 1
+		}
+		return i
+	}
+	if kind == Record && i+1 < to && (toks[i+1].Is("struct") || toks[i+1].Is("class")) {
+		i++
+	}
+	if i+1 >= to || toks[i+1].Kind != syntax.Identifier {
+		return i + 1
+	}
+
+	t := &Type{
+		Name:      toks[i+1].Text,
+		Namespace: ns,
+		Kind:      kind,
+		Partial:   partial,
+		File:      p.file.Path,
+		Line:      p.line(i + 1),
+	}
+	if outer != nil {
+		t.Outer = outer.Name
+		if outer.Outer != "" {
+			t.Outer = outer.Outer + "." + outer.Name
+		}
+	}
+	p.file.Types = append(p.file.Types, t)
+
+	i += 2
+	if i < to && toks[i].Is("<") {
+		i = skipAngles(toks, i, to)
+	}
+	if i < to && toks[i].Is("(") {
+		end := syntax.MatchingClose(toks, i)
+		if end == -1 {
+			return to
+		}
+		p.primaryParameters(t, i, end)
+		i = end + 1
+	}
+	if i < to && toks[i].Is(":") {
+		i++
+		from := i
+		depth := 0
+		for ; i < to; i++ {
+			tk := toks[i]
+			if depth == 0 && (tk.Is("{") || tk.Is(";") || tk.Is("where")) {
+				break
+			}
+			switch {
+			case tk.Is("<"):
+				depth++
+			case tk.Is(">"):
+				depth--
+			case tk.Is("("):
+				if end := syntax.MatchingClose(toks, i); end != -1 {
+					if from < i {
+						t.Bases = append(t.Bases, joinText(toks[from:i]))
+					}
+					from = end + 1
+					i = end
+				}
+			case tk.Is(",") && depth == 0:
+				if from < i {
+					t.Bases = append(t.Bases, joinText(toks[from:i]))
+				}
+				from = i + 1
+			}
+		}
+		if from < i {
+			t.Bases = append(t.Bases, joinText(toks[from:i]))
+		}
+	}
+	for i < to && !toks[i].Is("{") && !toks[i].Is(";") {
+		i++
+	}
+	if i >= to || toks[i].Is(";") {
+		return i + 1
+	}
+
+	end := syntax.MatchingClose(toks, i)
+	if end == -1 {
+		return to
+	}
+	if kind != Enum {
+		p.members(t, i+1, end, ns)
+	}
+	return end + 1
+}
+
+func (p *parser) primaryParameters(t *Type, open, close int) {
+	toks := p.toks
+	from := open + 1
+	depth := 0
+	for i := open + 1; i <= close; i++ {
+		switch {
+		case toks[i].Is("<") || toks[i].Is("(") || toks[i].Is("["):
+			depth++
+		case (toks[i].Is(">") || toks[i].Is(")") || toks[i].Is("]")) && i != close:
+			depth--
+		case (toks[i].Is(",") && depth == 0) || i == close:
+			param := toks[from:i]
+			for k, tk := range param {
+				if tk.Is("=") {
+					param = param[:k]
+					break
+				}
+			}
+			for len(param) > 0 && param[0].Is("[") {
+				end := syntax.MatchingClose(param, 0)
+				if end == -1 {
+					break
+				}
+				param = param[end+1:]
+			}
+			if len(param) >= 2 {
+				kind := Field
+				if t.Kind == Record {
+					kind = Property
+				}
+				t.Members = append(t.Members, Member{
+					Name: param[len(param)-1].Text,
+					Kind: kind,
+					Type: joinText(param[:len(param)-1]),
+					Line: p.line(from),
+				})
+			}
+			from = i + 1
+		}
+	}
+}
+
+func (p *parser) members(t *Type, from, to int, ns string) {
+	toks := p.toks
+	for _, span := range syntax.MemberSpans(toks, from, to) {
+		i := span.Header
+		static := false
+		for i < span.End && toks[i].Kind != syntax.Punct && modifiers[toks[i].Text] {
+			static = static || toks[i].Text == "static" || toks[i].Text == "const"
+			i++
+		}
+		if i >= span.End {
+			continue
+		}
+		if _, nested := typeKinds[toks[i].Text]; nested && toks[i].Kind != syntax.Punct {
+			p.declaration(span.Start, span.End, ns, t)
+			continue
+		}
+		if toks[i].Is("delegate") || toks[i].Is("operator") || toks[i].Is("implicit") || toks[i].Is("explicit") {
+			continue
+		}
+
+		event := toks[i].Is("event")
+		if event {
+			i++
+		}
+
+		header := span.End
+		depth := 0
+		typeFrom := i
+		if toks[i].Is("(") {
+			if end := syntax.MatchingClose(toks, i); end != -1 && end < span.End {
+				i = end
+			}
+		}
+		for j := i; j < span.End; j++ {
+			tk := toks[j]
+			if tk.Is("<") {
+				depth++
+			} else if tk.Is(">") {
+				depth--
+			}
+			if depth == 0 && (tk.Is("(") || tk.Is("{") || tk.Is("=") || tk.Is(";") || tk.Is("=>") || tk.Is(",")) {
+				header = j
+				break
+			}
+		}
+		if header == span.End || header == typeFrom {
+			continue
+		}
+
+		nameAt := header - 1
+		if toks[nameAt].Is(">") {
+			for depth := 0; nameAt > typeFrom; nameAt-- {
+				if toks[nameAt].Is(">") {
+					depth++
+				} else if toks[nameAt].Is("<") {
+					depth--
+					if depth == 0 {
+						break
+					}
+				}
+			}
+			nameAt--
+		}
+		if nameAt < typeFrom || toks[nameAt].Kind != syntax.Identifier {
+			continue
+		}
+		typeEnd := nameAt
+		for typeEnd-2 >= typeFrom && toks[typeEnd-1].Is(".") && toks[typeEnd-2].Kind == syntax.Identifier {
+			typeEnd -= 2
+		}
+
+		m := Member{Name: toks[nameAt].Text, Static: static, Line: p.line(nameAt)}
+		if typeEnd > typeFrom {
+			m.Type = joinText(toks[typeFrom:typeEnd])
+		}
+
+		switch {
+		case event:
+			m.Kind = Event
+		case toks[header].Is("("):
+			m.Kind = Method
+			if m.Type == "" && m.Name == t.Name {
+				m.Kind = Constructor
+			}
+		case toks[header].Is("{") || toks[header].Is("=>"):
+			m.Kind = Property
+		default:
+			m.Kind = Field
+		}
+		if m.Type == "" && m.Kind != Constructor {
+			continue
+		}
+		t.Members = append(t.Members, m)
+
+		if m.Kind == Field || m.Kind == Event {
+			p.declarators(t, m, header, span.End)
+		}
+	}
+}
+
+func (p *parser) declarators(t *Type, first Member, from, to int) {
+	toks := p.toks
+	depth := 0
+	for i := from; i < to; i++ {
+		tk := toks[i]
+		switch {
+		case tk.Is("(") || tk.Is("[") || tk.Is("{"):
+			depth++
+		case tk.Is(")") || tk.Is("]") || tk.Is("}"):
+			depth--
+		case tk.Is(",") && depth == 0 && i+1 < to && toks[i+1].Kind == syntax.Identifier:
+			m := first
+			m.Name = toks[i+1].Text
+			m.Line = p.line(i + 1)
+			t.Members = append(t.Members, m)
+		}
+	}
+}
+
+func skipAngles(toks []syntax.Token, i, to int) int {
+	depth := 0
+	for ; i < to; i++ {
+		if toks[i].Is("<") {
+			depth++
+		} else if toks[i].Is(">") {
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return to
+}
+
+func joinText(toks []syntax.Token) string {
+	var b strings.Builder
+	for i, t := range toks {
+		if i > 0 && needsSpace(toks[i-1], t) {
+			b.WriteByte(' ')
+		}
+		b.WriteString(t.Text)
+	}
+	return b.String()
+}
+
+func needsSpace(prev, next syntax.Token) bool {
+	if prev.Kind == syntax.Punct || next.Kind == syntax.Punct {
+		return prev.Is(",")
+	}
+	return true
+}
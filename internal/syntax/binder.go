@@ -0,0 +1,899 @@
+package syntax
+
+import (
+	"sort"
+	"strings"
+)
+
+type DeclKind int
+
+const (
+	LocalDecl DeclKind = iota
+	ParameterDecl
+	FieldDecl
+	PropertyDecl
+)
+
+func (k DeclKind) String() string {
+	switch k {
+	case LocalDecl:
+		return "local"
+	case ParameterDecl:
+		return "parameter"
+	case FieldDecl:
+		return "field"
+	case PropertyDecl:
+		return "property"
+	default:
+		return "unknown"
+	}
+}
+
+type Declaration struct {
+	Name  string
+	Kind  DeclKind
+	Type  string
+	Pos   int
+	Scope *Scope
+}
+
+type Scope struct {
+	Parent   *Scope
+	Start    int
+	End      int
+	TypeBody bool
+	Decls    []*Declaration
+	Children []*Scope
+}
+
+type Binder struct {
+	src    string
+	toks   []Token
+	parent []int
+	root   *Scope
+	decls  map[int]*Declaration
+	refs   map[int]*Declaration
+}
+
+var builtinTypes = map[string]bool{
+	"bool": true, "byte": true, "char": true, "decimal": true, "double": true, "float": true,
+	"int": true, "long": true, "object": true, "sbyte": true, "short": true, "string": true,
+	"uint": true, "ulong": true, "ushort": true, "void": true, "nint": true, "nuint": true,
+}
+
+var declModifiers = map[string]bool{
+	"public": true, "internal": true, "private": true, "protected": true,
+	"static": true, "readonly": true, "virtual": true, "override": true,
+	"sealed": true, "abstract": true, "new": true, "const": true,
+	"required": true, "extern": true, "unsafe": true, "volatile": true,
+	"async": true, "partial": true, "file": true, "event": true,
+}
+
+var paramModifiers = map[string]bool{
+	"ref": true, "out": true, "in": true, "params": true, "this": true, "scoped": true, "readonly": true,
+}
+
+var typeKeywords = map[string]bool{
+	"class": true, "struct": true, "interface": true, "record": true, "enum": true,
+}
+
+func Bind(src string) *Binder {
+	toks := Significant(Lex(src))
+	b := &Binder{
+		src:    src,
+		toks:   toks,
+		parent: enclosingOpens(toks),
+		root:   &Scope{Start: 0, End: len(src)},
+		decls:  make(map[int]*Declaration),
+		refs:   make(map[int]*Declaration),
+	}
+	b.walk(0, len(toks), b.root)
+	b.resolve()
+	return b
+}
+
+func enclosingOpens(toks []Token) []int {
+	parent := make([]int, len(toks))
+	var stack []int
+	for i, t := range toks {
+		if len(stack) > 0 {
+			parent[i] = stack[len(stack)-1]
+		} else {
+			parent[i] = -1
+		}
+		if t.Kind != Punct {
+			continue
+		}
+		switch t.Text {
+		case "(", "[", "{":
+			stack = append(stack, i)
+		case ")", "]", "}":
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+	return parent
+}
+
+func (b *Binder) Declaration(pos int) *Declaration {
+	return b.decls[pos]
+}
+
+func (b *Binder) Resolve(pos int) *Declaration {
+	if d, ok := b.decls[pos]; ok {
+		return d
+	}
+	return b.refs[pos]
+}
+
+func (b *Binder) References(d *Declaration) []int {
+	var refs []int
+	for pos, target := range b.refs {
+		if target == d {
+			refs = append(refs, pos)
+		}
+	}
+	sort.Ints(refs)
+	return refs
+}
+
+func (b *Binder) Lookup(name string, pos int) *Declaration {
+	return b.lookup(b.scopeAt(pos), name, pos, false)
+}
+
+func (b *Binder) Declarations() []*Declaration {
+	result := make([]*Declaration, 0, len(b.decls))
+	for _, d := range b.decls {
+		result = append(result, d)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Pos < result[j].Pos })
+	return result
+}
+
+func (b *Binder) newScope(parent *Scope, from, to int) *Scope {
+	s := &Scope{Parent: parent, Start: b.toks[from].Pos, End: b.toks[to].End}
+	parent.Children = append(parent.Children, s)
+	return s
+}
+
+func (b *Binder) declare(s *Scope, i int, kind DeclKind, typ string) {
+	t := b.toks[i]
+	if t.Kind != Identifier || t.Text == "_" {
+		return
+	}
+	if _, dup := b.decls[t.Pos]; dup {
+		return
+	}
+	d := &Declaration{Name: t.Text, Kind: kind, Type: typ, Pos: t.Pos, Scope: s}
+	s.Decls = append(s.Decls, d)
+	b.decls[t.Pos] = d
+}
+
+func (b *Binder) walk(from, to int, s *Scope) {
+	toks := b.toks
+	for i := from; i < to; {
+		t := toks[i]
+		if !s.TypeBody && b.statementStart(i, from) {
+			b.localDeclaration(i, to, s)
+		}
+
+		switch {
+		case t.Is("{"):
+			end := MatchingClose(toks, i)
+			if end == -1 || end >= to {
+				return
+			}
+			child := b.newScope(s, i, end)
+			child.TypeBody = b.isTypeBody(i)
+			if b.isAccessor(i - 1) {
+				b.declareValue(child, i-1)
+			}
+			if child.TypeBody {
+				if open, close := b.primaryParameters(i); open != -1 {
+					b.parameters(open, close, child)
+				}
+				b.walkType(i+1, end, child)
+			} else {
+				b.walk(i+1, end, child)
+			}
+			i = end + 1
+		case t.Is("("):
+			i = b.paren(i, to, s)
+		case t.Is("=>") && b.isAccessor(i-1):
+			end := b.expressionEnd(i+1, to)
+			child := b.newScope(s, i, end)
+			b.declareValue(child, i-1)
+			b.walk(i+1, end+1, child)
+			i = end + 1
+		case t.Kind == Identifier && i+1 < to && toks[i+1].Is("=>") && !b.isAccessor(i) && b.isLambdaParam(i):
+			end := b.expressionEnd(i+2, to)
+			if i+2 < to && toks[i+2].Is("{") {
+				end = MatchingClose(toks, i+2)
+			}
+			if end == -1 || end >= to {
+				return
+			}
+			child := b.newScope(s, i, end)
+			b.declare(child, i, ParameterDecl, "")
+			b.walk(i+2, end+1, child)
+			i = end + 1
+		case t.Is("out") && i+2 < to:
+			if end := b.typeEnd(i+1, to); end != -1 && end < to && toks[end].Kind == Identifier &&
+				end+1 < len(toks) && (toks[end+1].Is(")") || toks[end+1].Is(",")) {
+				b.declare(s, end, LocalDecl, joinText(toks[i+1:end]))
+			}
+			i++
+		case (t.Is("is") || t.Is("case")) && i+2 < to:
+			if end := b.typeEnd(i+1, to); end != -1 && end < to && toks[end].Kind == Identifier {
+				switch toks[end].Text {
+				case "and", "or", "not", "when":
+				default:
+					b.declare(s, end, LocalDecl, joinText(toks[i+1:end]))
+				}
+			}
+			i++
+		default:
+			i++
+		}
+	}
+}
+
+func (b *Binder) walkType(from, to int, s *Scope) {
+	for _, span := range MemberSpans(b.toks, from, to) {
+		b.member(span, s)
+		b.walk(span.Header, span.End, s)
+	}
+}
+
+func (b *Binder) statementStart(i, from int) bool {
+	if i == 0 {
+		return true
+	}
+	prev := b.toks[i-1]
+	switch {
+	case prev.Is("{") || prev.Is("}") || prev.Is(";"):
+		return true
+	case prev.Is(":"):
+		return i >= 3 && (b.toks[i-3].Is("case") || b.toks[i-2].Is("default"))
+	case prev.Is("(") && i == from && i >= 2:
+		switch b.toks[i-2].Text {
+		case "for", "foreach", "using", "catch", "fixed":
+			return b.toks[i-2].Kind == Keyword
+		}
+	}
+	return false
+}
+
+func (b *Binder) member(span Span, s *Scope) {
+	toks := b.toks
+	i := span.Header
+	for i < span.End && toks[i].Kind != Punct && declModifiers[toks[i].Text] {
+		i++
+	}
+	if i >= span.End || (typeKeywords[toks[i].Text] && toks[i].Kind != Punct) || toks[i].Is("delegate") {
+		return
+	}
+	end := b.typeEnd(i, span.End)
+	if end == -1 || end >= span.End || toks[end].Kind != Identifier {
+		return
+	}
+	name := end
+	for name+2 < span.End && toks[name+1].Is(".") && toks[name+2].Kind == Identifier {
+		name += 2
+	}
+	if name+1 >= span.End {
+		return
+	}
+	typ := joinText(toks[i:end])
+	switch next := toks[name+1]; {
+	case next.Is("{") || next.Is("=>"):
+		b.declare(s, name, PropertyDecl, typ)
+	case next.Is("=") || next.Is(";") || next.Is(","):
+		b.declare(s, name, FieldDecl, typ)
+		b.declarators(name+1, span.End, s, FieldDecl, typ)
+	}
+}
+
+func (b *Binder) localDeclaration(i, to int, s *Scope) {
+	toks := b.toks
+	for i < to && (toks[i].Is("using") || toks[i].Is("await") || toks[i].Is("const") ||
+		toks[i].Is("ref") || toks[i].Is("readonly") || toks[i].Is("scoped") || toks[i].Is("static") || toks[i].Is("async")) {
+		i++
+	}
+	if i >= to {
+		return
+	}
+
+	if toks[i].Is("var") && i+1 < to && toks[i+1].Is("(") {
+		b.deconstruction(i+1, to, s, true)
+		return
+	}
+	if toks[i].Is("(") {
+		b.deconstruction(i, to, s, false)
+		return
+	}
+
+	end := b.typeEnd(i, to)
+	if end == -1 || end >= to || toks[end].Kind != Identifier || end+1 >= len(toks) {
+		return
+	}
+	typ := joinText(toks[i:end])
+	switch next := toks[end+1]; {
+	case next.Is("="):
+		if typ == "var" {
+			typ = b.inferType(end+2, to)
+		}
+		b.declare(s, end, LocalDecl, typ)
+		b.declarators(end+1, to, s, LocalDecl, typ)
+	case next.Is(";") || next.Is(",") || next.Is("in") || next.Is(")"):
+		b.declare(s, end, LocalDecl, typ)
+		b.declarators(end+1, to, s, LocalDecl, typ)
+	case next.Is("(") || next.Is("<"):
+		b.declare(s, end, LocalDecl, "")
+	}
+}
+
+func (b *Binder) declarators(from, to int, s *Scope, kind DeclKind, typ string) {
+	toks := b.toks
+	depth := 0
+	for i := from; i < to; i++ {
+		switch {
+		case toks[i].Is("(") || toks[i].Is("[") || toks[i].Is("{"):
+			depth++
+		case toks[i].Is(")") || toks[i].Is("]") || toks[i].Is("}"):
+			depth--
+			if depth < 0 {
+				return
+			}
+		case depth == 0 && toks[i].Is(";"):
+			return
+		case depth == 0 && toks[i].Is(",") && i+2 < to && toks[i+1].Kind == Identifier &&
+			(toks[i+2].Is("=") || toks[i+2].Is(",") || toks[i+2].Is(";")):
+			b.declare(s, i+1, kind, typ)
+		}
+	}
+}
+
+func (b *Binder) deconstruction(open, to int, s *Scope, implicit bool) {
+	toks := b.toks
+	close := MatchingClose(toks, open)
+	if close == -1 || close+1 >= to || !toks[close+1].Is("=") {
+		return
+	}
+	for i := open + 1; i < close; i++ {
+		if toks[i].Kind != Identifier || !(toks[i+1].Is(",") || toks[i+1].Is(")")) {
+			continue
+		}
+		if implicit && (toks[i-1].Is("(") || toks[i-1].Is(",")) {
+			b.declare(s, i, LocalDecl, "var")
+		} else if !implicit && !toks[i-1].Is("(") && !toks[i-1].Is(",") && !toks[i-1].Is(".") {
+			b.declare(s, i, LocalDecl, toks[i-1].Text)
+		}
+	}
+}
+
+func (b *Binder) inferType(i, to int) string {
+	toks := b.toks
+	if i >= to {
+		return "var"
+	}
+	switch t := toks[i]; {
+	case t.Is("new") && i+1 < to:
+		if end := b.typeEnd(i+1, to); end != -1 && end < to && (toks[end].Is("(") || toks[end].Is("{") || toks[end].Is("[")) {
+			typ := joinText(toks[i+1 : end])
+			if toks[end].Is("[") {
+				typ += "[]"
+			}
+			return typ
+		}
+	case t.Kind == String:
+		return "string"
+	case t.Kind == Char:
+		return "char"
+	case t.Is("true") || t.Is("false"):
+		return "bool"
+	}
+	return "var"
+}
+
+func (b *Binder) paren(i, to int, s *Scope) int {
+	toks := b.toks
+	close := MatchingClose(toks, i)
+	if close == -1 || close >= to {
+		return to
+	}
+
+	if body, end, ok := b.functionBody(i, close, to); ok {
+		fn := b.newScope(s, i, end)
+		b.parameters(i, close, fn)
+		b.walk(i+1, close, fn)
+		b.walk(body, end+1, fn)
+		return end + 1
+	}
+
+	if i > 0 && (toks[i-1].Is("for") || toks[i-1].Is("foreach") || toks[i-1].Is("using") ||
+		toks[i-1].Is("catch") || toks[i-1].Is("fixed")) {
+		end := b.statementEnd(close+1, to)
+		stmt := b.newScope(s, i, end)
+		b.walk(i+1, close, stmt)
+		b.walk(close+1, end+1, stmt)
+		return end + 1
+	}
+
+	b.walk(i+1, close, s)
+	return close + 1
+}
+
+func (b *Binder) functionBody(open, close, to int) (int, int, bool) {
+	toks := b.toks
+	k := close + 1
+	lambda := k < to && toks[k].Is("=>")
+	if !lambda {
+		if open == 0 || !b.isDeclaredName(open-1) {
+			return 0, 0, false
+		}
+		for k < to && !toks[k].Is("{") && !toks[k].Is("=>") && !toks[k].Is(";") {
+			if toks[k].Is("(") {
+				if k = MatchingClose(toks, k); k == -1 {
+					return 0, 0, false
+				}
+			}
+			if toks[k].Is(")") && k != close && k+1 < len(toks) && toks[k+1].Is("(") {
+				return 0, 0, false
+			}
+			k++
+		}
+		if k >= to || toks[k].Is(";") {
+			return 0, 0, false
+		}
+	}
+
+	if toks[k].Is("{") {
+		end := MatchingClose(toks, k)
+		if end == -1 || end >= to {
+			return 0, 0, false
+		}
+		return k, end, true
+	}
+	if k+1 < to && toks[k+1].Is("{") {
+		end := MatchingClose(toks, k+1)
+		if end == -1 || end >= to {
+			return 0, 0, false
+		}
+		return k + 1, end, true
+	}
+	return k + 1, b.expressionEnd(k+1, to), true
+}
+
+func (b *Binder) isDeclaredName(i int) bool {
+	toks := b.toks
+	if toks[i].Is(">") {
+		depth := 0
+		for ; i >= 0; i-- {
+			if toks[i].Is(">") {
+				depth++
+			} else if toks[i].Is("<") {
+				depth--
+				if depth == 0 {
+					break
+				}
+			}
+		}
+		i--
+	}
+	if i < 0 || toks[i].Kind != Identifier {
+		return false
+	}
+	if i == 0 {
+		return true
+	}
+	prev := toks[i-1]
+	switch {
+	case prev.Is("new") || prev.Is(".") || prev.Is("?.") || prev.Is("return") || prev.Is("await"):
+		return false
+	case prev.Kind == Identifier || (prev.Kind == Keyword && (builtinTypes[prev.Text] || declModifiers[prev.Text])):
+		return true
+	case prev.Is(">") || prev.Is("]") || prev.Is("?") || prev.Is(")"):
+		return true
+	case prev.Is("{") || prev.Is("}") || prev.Is(";"):
+		return true
+	}
+	return false
+}
+
+func (b *Binder) parameters(open, close int, s *Scope) {
+	toks := b.toks
+	from := open + 1
+	depth := 0
+	for i := open + 1; i <= close; i++ {
+		t := toks[i]
+		switch {
+		case i < close && (t.Is("(") || t.Is("[") || t.Is("<") || t.Is("{")):
+			depth++
+		case i < close && (t.Is(")") || t.Is("]") || t.Is(">") || t.Is("}")):
+			depth--
+		case (t.Is(",") && depth == 0) || i == close:
+			b.parameter(from, i, s)
+			from = i + 1
+		}
+	}
+}
+
+func (b *Binder) parameter(from, to int, s *Scope) {
+	toks := b.toks
+	for from < to && toks[from].Is("[") {
+		end := MatchingClose(toks, from)
+		if end == -1 || end >= to {
+			return
+		}
+		from = end + 1
+	}
+	for from < to && toks[from].Kind != Punct && paramModifiers[toks[from].Text] {
+		from++
+	}
+	for k := from; k < to; k++ {
+		if toks[k].Is("=") {
+			to = k
+			break
+		}
+	}
+	if from >= to || toks[to-1].Kind != Identifier {
+		return
+	}
+	b.declare(s, to-1, ParameterDecl, joinText(toks[from:to-1]))
+}
+
+func (b *Binder) isLambdaParam(i int) bool {
+	if i == 0 {
+		return true
+	}
+	prev := b.toks[i-1]
+	if prev.Is("async") {
+		return true
+	}
+	return !(prev.Kind == Identifier || prev.Kind == Keyword || prev.Is(">") || prev.Is("]") ||
+		prev.Is("?") || prev.Is(".") || prev.Is("?."))
+}
+
+func (b *Binder) isAccessor(i int) bool {
+	if i < 0 {
+		return false
+	}
+	t := b.toks[i]
+	if t.Kind != Identifier {
+		return false
+	}
+	switch t.Text {
+	case "get", "set", "init", "add", "remove":
+	default:
+		return false
+	}
+	open := b.parent[i]
+	return open >= 0 && b.toks[open].Is("{") && (i == open+1 || b.toks[i-1].Is(";") || b.toks[i-1].Is("}") ||
+		b.toks[i-1].Kind == Keyword || b.toks[i-1].Is("]"))
+}
+
+func (b *Binder) declareValue(s *Scope, accessor int) {
+	switch b.toks[accessor].Text {
+	case "set", "init", "add", "remove":
+		s.Decls = append(s.Decls, &Declaration{Name: "value", Kind: ParameterDecl, Pos: b.toks[accessor].Pos, Scope: s})
+	}
+}
+
+func (b *Binder) isTypeBody(open int) bool {
+	toks := b.toks
+	for i := open - 1; i >= 0; i-- {
+		t := toks[i]
+		if t.Is(";") || t.Is("{") || t.Is("}") || t.Is("=") || t.Is("=>") || t.Is(")") && !b.primaryParameterList(i) {
+			return false
+		}
+		if typeKeywords[t.Text] && t.Kind != Punct && i+1 < open && toks[i+1].Kind == Identifier {
+			return !(i > 0 && (toks[i-1].Is(":") || toks[i-1].Is(",")))
+		}
+		if t.Is("namespace") {
+			return false
+		}
+	}
+	return false
+}
+
+func (b *Binder) primaryParameters(open int) (int, int) {
+	for i := open - 1; i >= 2; i-- {
+		if b.toks[i].Is(")") && b.primaryParameterList(i) {
+			return b.matchingOpen(i), i
+		}
+		if typeKeywords[b.toks[i].Text] && b.toks[i].Kind != Punct {
+			break
+		}
+	}
+	return -1, -1
+}
+
+func (b *Binder) primaryParameterList(close int) bool {
+	open := b.matchingOpen(close)
+	return open >= 2 && b.toks[open-1].Kind == Identifier && typeKeywords[b.toks[open-2].Text]
+}
+
+func (b *Binder) matchingOpen(close int) int {
+	depth := 0
+	for i := close; i >= 0; i-- {
+		switch {
+		case b.toks[i].Is(")"):
+			depth++
+		case b.toks[i].Is("("):
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func (b *Binder) typeEnd(i, to int) int {
+	toks := b.toks
+	if i >= to {
+		return -1
+	}
+	t := toks[i]
+	switch {
+	case t.Is("("):
+		close := MatchingClose(toks, i)
+		if close == -1 || close >= to {
+			return -1
+		}
+		commas := 0
+		for k := i + 1; k < close; k++ {
+			if toks[k].Is(",") {
+				commas++
+			} else if toks[k].Kind == Punct && !toks[k].Is("<") && !toks[k].Is(">") && !toks[k].Is("?") &&
+				!toks[k].Is("[") && !toks[k].Is("]") && !toks[k].Is(".") {
+				return -1
+			}
+		}
+		if commas == 0 {
+			return -1
+		}
+		i = close + 1
+	case t.Kind == Identifier || (t.Kind == Keyword && builtinTypes[t.Text]):
+		i++
+		for i+1 < to && (toks[i].Is(".") || toks[i].Is("::")) && toks[i+1].Kind == Identifier {
+			i += 2
+		}
+		if i < to && toks[i].Is("<") {
+			depth := 0
+			for ; i < to; i++ {
+				tk := toks[i]
+				switch {
+				case tk.Is("<"):
+					depth++
+				case tk.Is(">"):
+					depth--
+				case tk.Kind == Punct && !tk.Is(",") && !tk.Is(".") && !tk.Is("?") && !tk.Is("[") && !tk.Is("]") &&
+					!tk.Is("(") && !tk.Is(")"):
+					return -1
+				}
+				if depth == 0 {
+					break
+				}
+			}
+			if i >= to {
+				return -1
+			}
+			i++
+		}
+	default:
+		return -1
+	}
+
+	for i < to {
+		switch {
+		case toks[i].Is("?") || toks[i].Is("*"):
+			i++
+		case toks[i].Is("[") && i+1 < to && (toks[i+1].Is("]") || toks[i+1].Is(",")):
+			end := MatchingClose(toks, i)
+			if end == -1 {
+				return -1
+			}
+			i = end + 1
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+func (b *Binder) expressionEnd(i, to int) int {
+	toks := b.toks
+	depth := 0
+	for k := i; k < to; k++ {
+		switch {
+		case toks[k].Is("(") || toks[k].Is("[") || toks[k].Is("{"):
+			depth++
+		case toks[k].Is(")") || toks[k].Is("]") || toks[k].Is("}"):
+			if depth == 0 {
+				return k - 1
+			}
+			depth--
+		case depth == 0 && (toks[k].Is(";") || toks[k].Is(",")):
+			return k - 1
+		}
+	}
+	return to - 1
+}
+
+func (b *Binder) statementEnd(i, to int) int {
+	toks := b.toks
+	if i >= to {
+		return to - 1
+	}
+	if toks[i].Is("{") {
+		if end := MatchingClose(toks, i); end != -1 && end < to {
+			return end
+		}
+		return to - 1
+	}
+	depth := 0
+	for k := i; k < to; k++ {
+		switch {
+		case toks[k].Is("(") || toks[k].Is("[") || toks[k].Is("{"):
+			depth++
+		case toks[k].Is(")") || toks[k].Is("]") || toks[k].Is("}"):
+			if depth == 0 {
+				return k - 1
+			}
+			depth--
+		case depth == 0 && toks[k].Is(";"):
+			return k
+		}
+	}
+	return to - 1
+}
+
+func (b *Binder) resolve() {
+	for i, t := range b.toks {
+		switch t.Kind {
+		case Identifier:
+			b.bindReference(b.toks, i, true)
+		case String:
+			b.resolveHoles(t)
+		}
+	}
+}
+
+func (b *Binder) resolveHoles(str Token) {
+	for _, hole := range Holes(b.src, str) {
+		toks := Significant(Lex(b.src[hole[0]:hole[1]]))
+		for i := range toks {
+			toks[i].Pos += hole[0]
+			toks[i].End += hole[0]
+		}
+		toks = toks[:formatClause(toks)]
+		for i, t := range toks {
+			switch t.Kind {
+			case Identifier:
+				b.bindReference(toks, i, false)
+			case String:
+				b.resolveHoles(t)
+			}
+		}
+	}
+}
+
+func formatClause(toks []Token) int {
+	depth := 0
+	for i, t := range toks {
+		switch {
+		case t.Is("(") || t.Is("[") || t.Is("{"):
+			depth++
+		case t.Is(")") || t.Is("]") || t.Is("}"):
+			depth--
+		case depth == 0 && t.Is(":"):
+			return i
+		}
+	}
+	return len(toks)
+}
+
+func (b *Binder) bindReference(toks []Token, i int, initializers bool) {
+	t := toks[i]
+	if _, ok := b.decls[t.Pos]; ok {
+		return
+	}
+
+	typeOnly := false
+	if i > 0 {
+		prev := toks[i-1]
+		if prev.Is(".") && i > 1 && toks[i-2].Is("this") && (i < 3 || !toks[i-3].Is(".")) {
+			typeOnly = true
+		} else if prev.Is(".") || prev.Is("?.") || prev.Is("::") || prev.Is("->") {
+			return
+		}
+		if i+1 < len(toks) && toks[i+1].Is(":") && (prev.Is("(") || prev.Is(",")) {
+			return
+		}
+		if initializers && i+1 < len(toks) && toks[i+1].Is("=") && (prev.Is("{") || prev.Is(",")) && b.inInitializer(i) {
+			return
+		}
+	}
+
+	if d := b.lookup(b.scopeAt(t.Pos), t.Text, t.Pos, typeOnly); d != nil {
+		b.refs[t.Pos] = d
+	}
+}
+
+func (b *Binder) inInitializer(i int) bool {
+	open := b.parent[i]
+	if open <= 0 || !b.toks[open].Is("{") {
+		return false
+	}
+	prev := b.toks[open-1]
+	if prev.Is("new") || prev.Is("with") {
+		return true
+	}
+	if prev.Is(")") {
+		if o := b.matchingOpen(open - 1); o > 0 {
+			prev = b.toks[o-1]
+		}
+	}
+	for k := open - 1; k >= 0; k-- {
+		t := b.toks[k]
+		if t.Is("new") {
+			return true
+		}
+		if t.Kind != Identifier && !t.Is(".") && !t.Is("<") && !t.Is(">") && !t.Is(",") && !t.Is(")") && !t.Is("(") &&
+			!(t.Kind == Keyword && builtinTypes[t.Text]) {
+			return false
+		}
+	}
+	return false
+}
+
+func (b *Binder) scopeAt(pos int) *Scope {
+	s := b.root
+	for {
+		next := (*Scope)(nil)
+		for _, c := range s.Children {
+			if pos >= c.Start && pos < c.End {
+				next = c
+				break
+			}
+		}
+		if next == nil {
+			return s
+		}
+		s = next
+	}
+}
+
+func (b *Binder) lookup(s *Scope, name string, pos int, typeOnly bool) *Declaration {
+	for ; s != nil; s = s.Parent {
+		if typeOnly && !s.TypeBody {
+			continue
+		}
+		for _, d := range s.Decls {
+			if d.Name != name {
+				continue
+			}
+			if d.Kind == LocalDecl && d.Pos > pos {
+				continue
+			}
+			return d
+		}
+	}
+	return nil
+}
+
+func joinText(toks []Token) string {
+	var sb strings.Builder
+	for i, t := range toks {
+		if i > 0 && t.Kind != Punct && toks[i-1].Kind != Punct {
+			sb.WriteByte(' ')
+		} else if i > 0 && toks[i-1].Is(",") {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(t.Text)
+	}
+	return sb.String()
+}
@@ -0,0 +1,120 @@
+package syntax
+
+import (
+	"regexp"
+	"testing"
+)
+
+func occurrence(t *testing.T, src, name string, n int) int {
+	t.Helper()
+	matches := regexp.MustCompile(`\b`+name+`\b`).FindAllStringIndex(src, -1)
+	if n >= len(matches) {
+		t.Fatalf("%s has %d occurrences of %q, want index %d", src, len(matches), name, n)
+	}
+	return matches[n][0]
+}
+
+func TestBinderResolve(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		id   string
+		ref  int
+		decl int
+		kind DeclKind
+	}{
+		{"parameter", "class C { void M(int a) { Use(a); } }", "a", 1, 0, ParameterDecl},
+		{"field", "class C { int count; void M() { Use(count); } }", "count", 1, 0, FieldDecl},
+		{"local shadows field", "class C { int x; void M() { var x = 1; Use(x); } }", "x", 2, 1, LocalDecl},
+		{"field outside shadowing method", "class C { int x; void M() { var x = 1; } void N() { Use(x); } }", "x", 2, 0, FieldDecl},
+		{"this bypasses local", "class C { int x; void M() { var x = 1; this.x = x; } }", "x", 2, 0, FieldDecl},
+		{"local after this access", "class C { int x; void M() { var x = 1; this.x = x; } }", "x", 3, 1, LocalDecl},
+		{"use before declaration", "class C { void M() { Use(y); var y = 1; } }", "y", 0, -1, 0},
+		{"sibling blocks", "class C { void M() { { var t = 1; } { var t = 2; Use(t); } } }", "t", 2, 1, LocalDecl},
+		{"block local out of scope", "class C { void M() { { var t = 1; } Use(t); } }", "t", 1, -1, 0},
+		{"lambda parameter", "class C { void M() { Use(items.Select(item => item.Id)); } }", "item", 1, 0, ParameterDecl},
+		{"lambda parameter out of scope", "class C { void M() { Use(items.Select(item => item.Id)); Use(item); } }", "item", 2, -1, 0},
+		{"parenthesized lambda", "class C { void M() { Run((a, b) => a + b); } }", "b", 1, 0, ParameterDecl},
+		{"local function parameter", "class C { void M() { int Twice(int v) => v * 2; Use(Twice(3)); } }", "v", 1, 0, ParameterDecl},
+		{"local function parameter out of scope", "class C { void M() { int Twice(int v) => v * 2; Use(v); } }", "v", 2, -1, 0},
+		{"local function", "class C { void M() { int Twice(int v) => v * 2; Use(Twice(3)); } }", "Twice", 1, 0, LocalDecl},
+		{"out variable", "class C { void M(string s) { if (int.TryParse(s, out var n)) Use(n); } }", "n", 1, 0, LocalDecl},
+		{"pattern variable", "class C { void M(object o) { if (o is string s) Use(s); } }", "s", 1, 0, LocalDecl},
+		{"foreach variable", "class C { void M() { foreach (var item in items) Use(item); } }", "item", 1, 0, LocalDecl},
+		{"foreach variable out of scope", "class C { void M() { foreach (var item in items) Use(item); Use(item); } }", "item", 2, -1, 0},
+		{"setter value", "class C { int p; int P { get => p; set { p = value; } } }", "value", 0, -2, ParameterDecl},
+		{"member access", "class C { void M(Order o) { var Id = 1; Use(o.Id); } }", "Id", 1, -1, 0},
+		{"interpolation hole", `class C { void M() { var q = 1; var r = $"{q}"; } }`, "q", 1, 0, LocalDecl},
+		{"interpolation format clause", `class C { void M() { var N2 = 1; var q = 1; var r = $"{q:N2}"; } }`, "N2", 1, -1, 0},
+		{"nested interpolation", `class C { void M() { var q = 1; var r = $"{$"{q}"}"; } }`, "q", 1, 0, LocalDecl},
+		{"raw interpolation", `class C { void M() { var q = 1; var r = $$"""{{q}}"""; } }`, "q", 1, 0, LocalDecl},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := Bind(tt.src)
+			got := b.Resolve(occurrence(t, tt.src, tt.id, tt.ref))
+			switch {
+			case tt.decl == -1:
+				if got != nil {
+					t.Fatalf("resolved to %s %s at %d, want nothing", got.Kind, got.Name, got.Pos)
+				}
+				return
+			case got == nil:
+				t.Fatal("not resolved")
+			case tt.decl >= 0 && got.Pos != occurrence(t, tt.src, tt.id, tt.decl):
+				t.Fatalf("resolved to %s at %d, want occurrence %d", got.Kind, got.Pos, tt.decl)
+			}
+			if got.Kind != tt.kind {
+				t.Fatalf("kind = %s, want %s", got.Kind, tt.kind)
+			}
+		})
+	}
+}
+
+func TestBinderReferences(t *testing.T) {
+	src := "class C { void M() { var n = 1; Use(n); { Use(n); } Use(c => n + c); } void N() { var n = 2; Use(n); } }"
+	b := Bind(src)
+	decl := b.Declaration(occurrence(t, src, "n", 0))
+	if decl == nil {
+		t.Fatal("n is not declared")
+	}
+	refs := b.References(decl)
+	want := []int{occurrence(t, src, "n", 1), occurrence(t, src, "n", 2), occurrence(t, src, "n", 3)}
+	if len(refs) != len(want) {
+		t.Fatalf("references = %v, want %v", refs, want)
+	}
+	for i := range want {
+		if refs[i] != want[i] {
+			t.Fatalf("references = %v, want %v", refs, want)
+		}
+	}
+}
+
+func TestBinderInferredTypes(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{"void M() { var s = \"x\"; }", "string"},
+		{"void M() { var list = new List<int>(); }", "List<int>"},
+		{"void M() { var ok = true; }", "bool"},
+		{"void M() { var n = Compute(); }", "var"},
+		{"void M() { int[] xs = null; }", "int[]"},
+	}
+	for _, tt := range tests {
+		decls := Bind("class C { " + tt.src + " }").Declarations()
+		if len(decls) != 1 || decls[0].Type != tt.want {
+			t.Errorf("%s: got %d declarations, want one of type %s", tt.src, len(decls), tt.want)
+		}
+	}
+}
+
+func TestBindTruncatedSource(t *testing.T) {
+	for _, src := range []string{
+		"o\nc class e\n    public int Parse(string t)xt)",
+		"void M(int a)",
+		"{ var x = (",
+	} {
+		Bind(src)
+	}
+}
@@ -0,0 +1,449 @@
+package syntax
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type Kind int
+
+const (
+	Identifier Kind = iota
+	Keyword
+	Number
+	String
+	Char
+	Punct
+	Comment
+	Preprocessor
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Identifier:
+		return "identifier"
+	case Keyword:
+		return "keyword"
+	case Number:
+		return "number"
+	case String:
+		return "string"
+	case Char:
+		return "char"
+	case Punct:
+		return "punct"
+	case Comment:
+		return "comment"
+	case Preprocessor:
+		return "preprocessor"
+	default:
+		return "unknown"
+	}
+}
+
+type Token struct {
+	Kind         Kind
+	Text         string
+	Pos          int
+	End          int
+	Unterminated bool
+}
+
+func (t Token) Is(text string) bool {
+	return (t.Kind == Punct || t.Kind == Keyword || t.Kind == Identifier) && t.Text == text
+}
+
+func (t Token) IsTrivia() bool {
+	return t.Kind == Comment || t.Kind == Preprocessor
+}
+
+var keywords = map[string]bool{
+	"abstract": true, "as": true, "base": true, "bool": true, "break": true, "byte": true,
+	"case": true, "catch": true, "char": true, "checked": true, "class": true, "const": true,
+	"continue": true, "decimal": true, "default": true, "delegate": true, "do": true, "double": true,
+	"else": true, "enum": true, "event": true, "explicit": true, "extern": true, "false": true,
+	"finally": true, "fixed": true, "float": true, "for": true, "foreach": true, "goto": true,
+	"if": true, "implicit": true, "in": true, "int": true, "interface": true, "internal": true,
+	"is": true, "lock": true, "long": true, "namespace": true, "new": true, "null": true,
+	"object": true, "operator": true, "out": true, "override": true, "params": true, "private": true,
+	"protected": true, "public": true, "readonly": true, "ref": true, "return": true, "sbyte": true,
+	"sealed": true, "short": true, "sizeof": true, "stackalloc": true, "static": true, "string": true,
+	"struct": true, "switch": true, "this": true, "throw": true, "true": true, "try": true,
+	"typeof": true, "uint": true, "ulong": true, "unchecked": true, "unsafe": true, "ushort": true,
+	"using": true, "virtual": true, "void": true, "volatile": true, "while": true,
+}
+
+func IsKeyword(word string) bool {
+	return keywords[word]
+}
+
+var operators = []string{
+	"??=", "<<=", "...",
+	"=>", "==", "!=", "<=", ">=", "&&", "||", "??", "?.", "++", "--",
+	"+=", "-=", "*=", "/=", "%=", "&=", "|=", "^=", "<<", "::", "->", "..",
+}
+
+func Lex(src string) []Token {
+	l := &lexer{src: src}
+	l.run()
+	return l.tokens
+}
+
+func Significant(tokens []Token) []Token {
+	result := make([]Token, 0, len(tokens))
+	for _, t := range tokens {
+		if !t.IsTrivia() {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+type lexer struct {
+	src         string
+	pos         int
+	tokens      []Token
+	lineStarted bool
+	holes       [][2]int
+	holeDepth   int
+}
+
+func Holes(src string, t Token) [][2]int {
+	quote := strings.IndexByte(t.Text, '"')
+	if t.Kind != String || quote == -1 || !strings.Contains(t.Text[:quote], "$") {
+		return nil
+	}
+	l := &lexer{src: src[:t.End], pos: t.Pos}
+	l.scanString()
+	return l.holes
+}
+
+func (l *lexer) run() {
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == '\n':
+			l.lineStarted = false
+			l.pos++
+		case c == ' ' || c == '\t' || c == '\r' || c == '\f' || c == '\v':
+			l.pos++
+		case c == '#' && !l.lineStarted:
+			l.lexPreprocessor()
+		default:
+			l.lineStarted = true
+			l.lexToken()
+		}
+	}
+}
+
+func (l *lexer) emit(kind Kind, start int, unterminated bool) {
+	l.tokens = append(l.tokens, Token{
+		Kind:         kind,
+		Text:         l.src[start:l.pos],
+		Pos:          start,
+		End:          l.pos,
+		Unterminated: unterminated,
+	})
+}
+
+func (l *lexer) peek(offset int) byte {
+	if l.pos+offset < len(l.src) {
+		return l.src[l.pos+offset]
+	}
+	return 0
+}
+
+func (l *lexer) lexPreprocessor() {
+	start := l.pos
+	for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+		l.pos++
+	}
+	l.emit(Preprocessor, start, false)
+}
+
+func (l *lexer) lexToken() {
+	start := l.pos
+	c := l.src[l.pos]
+
+	switch {
+	case c == '/' && l.peek(1) == '/':
+		for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+			l.pos++
+		}
+		l.emit(Comment, start, false)
+	case c == '/' && l.peek(1) == '*':
+		end := strings.Index(l.src[l.pos+2:], "*/")
+		if end == -1 {
+			l.pos = len(l.src)
+			l.emit(Comment, start, true)
+			return
+		}
+		l.pos += 2 + end + 2
+		l.emit(Comment, start, false)
+	case c == '"' || ((c == '$' || c == '@') && l.isStringPrefix()):
+		unterminated := l.scanString()
+		l.emit(String, start, unterminated)
+	case c == '\'':
+		l.emit(Char, start, l.scanChar())
+	case c >= '0' && c <= '9' || (c == '.' && l.peek(1) >= '0' && l.peek(1) <= '9'):
+		l.scanNumber()
+		l.emit(Number, start, false)
+	case c == '@' || c == '_' || isLetterAt(l.src, l.pos):
+		if c == '@' {
+			l.pos++
+		}
+		l.scanIdentifier()
+		kind := Identifier
+		if c != '@' && keywords[l.src[start:l.pos]] {
+			kind = Keyword
+		}
+		l.emit(kind, start, false)
+	default:
+		for _, op := range operators {
+			if strings.HasPrefix(l.src[l.pos:], op) {
+				l.pos += len(op)
+				l.emit(Punct, start, false)
+				return
+			}
+		}
+		_, size := utf8.DecodeRuneInString(l.src[l.pos:])
+		l.pos += size
+		l.emit(Punct, start, false)
+	}
+}
+
+func (l *lexer) isStringPrefix() bool {
+	i := l.pos
+	for i < len(l.src) && (l.src[i] == '$' || l.src[i] == '@') {
+		i++
+	}
+	return i < len(l.src) && l.src[i] == '"' && i > l.pos
+}
+
+func (l *lexer) scanIdentifier() {
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		if c == '_' || (c >= '0' && c <= '9') || isLetterAt(l.src, l.pos) {
+			_, size := utf8.DecodeRuneInString(l.src[l.pos:])
+			l.pos += size
+			continue
+		}
+		break
+	}
+}
+
+func isLetterAt(s string, i int) bool {
+	c := s[i]
+	if c < utf8.RuneSelf {
+		return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+	}
+	r, _ := utf8.DecodeRuneInString(s[i:])
+	return unicode.IsLetter(r)
+}
+
+func (l *lexer) scanNumber() {
+	if l.src[l.pos] == '0' && (l.peek(1) == 'x' || l.peek(1) == 'X' || l.peek(1) == 'b' || l.peek(1) == 'B') {
+		l.pos += 2
+	}
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '_':
+			if (c == 'e' || c == 'E') && (l.peek(1) == '+' || l.peek(1) == '-') {
+				l.pos++
+			}
+			l.pos++
+		case c == '.' && l.peek(1) >= '0' && l.peek(1) <= '9':
+			l.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (l *lexer) scanChar() bool {
+	l.pos++
+	for l.pos < len(l.src) {
+		switch l.src[l.pos] {
+		case '\\':
+			l.pos += 2
+		case '\'':
+			l.pos++
+			return false
+		case '\n':
+			return true
+		default:
+			l.pos++
+		}
+	}
+	if l.pos > len(l.src) {
+		l.pos = len(l.src)
+	}
+	return true
+}
+
+func (l *lexer) scanString() bool {
+	dollars, verbatim := 0, false
+	for l.src[l.pos] != '"' {
+		if l.src[l.pos] == '$' {
+			dollars++
+		} else {
+			verbatim = true
+		}
+		l.pos++
+	}
+
+	quotes := 0
+	for l.pos+quotes < len(l.src) && l.src[l.pos+quotes] == '"' {
+		quotes++
+	}
+	if quotes >= 3 && !verbatim {
+		return l.scanRawString(quotes, dollars)
+	}
+
+	l.pos++
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == '\\' && !verbatim:
+			l.pos += 2
+		case c == '"':
+			if verbatim && l.peek(1) == '"' {
+				l.pos += 2
+				continue
+			}
+			l.pos++
+			return false
+		case c == '\n' && !verbatim:
+			return true
+		case c == '{' && dollars > 0:
+			if l.peek(1) == '{' {
+				l.pos += 2
+				continue
+			}
+			if l.scanHole(1) {
+				return true
+			}
+		default:
+			l.pos++
+		}
+	}
+	if l.pos > len(l.src) {
+		l.pos = len(l.src)
+	}
+	return true
+}
+
+func (l *lexer) scanRawString(quotes, dollars int) bool {
+	l.pos += quotes
+	delimiter := strings.Repeat("\"", quotes)
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case strings.HasPrefix(l.src[l.pos:], delimiter):
+			l.pos += quotes
+			for l.pos < len(l.src) && l.src[l.pos] == '"' {
+				l.pos++
+			}
+			return false
+		case c == '{' && dollars > 0:
+			run := 0
+			for l.pos+run < len(l.src) && l.src[l.pos+run] == '{' {
+				run++
+			}
+			if run < dollars {
+				l.pos += run
+				continue
+			}
+			l.pos += run - dollars
+			if l.scanHole(dollars) {
+				return true
+			}
+		default:
+			l.pos++
+		}
+	}
+	return true
+}
+
+func (l *lexer) scanHole(braces int) bool {
+	l.pos += braces
+	start := l.pos
+	l.holeDepth++
+	defer func() { l.holeDepth-- }()
+	depth := 0
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == '"' || ((c == '$' || c == '@') && l.isStringPrefix()):
+			if l.scanString() {
+				return true
+			}
+		case c == '\'':
+			if l.scanChar() {
+				return true
+			}
+		case c == '/' && l.peek(1) == '*':
+			end := strings.Index(l.src[l.pos+2:], "*/")
+			if end == -1 {
+				l.pos = len(l.src)
+				return true
+			}
+			l.pos += 2 + end + 2
+		case c == '{':
+			depth++
+			l.pos++
+		case c == '}':
+			if depth == 0 {
+				if l.holeDepth == 1 {
+					l.holes = append(l.holes, [2]int{start, l.pos})
+				}
+				l.pos += braces
+				if l.pos > len(l.src) {
+					l.pos = len(l.src)
+				}
+				return false
+			}
+			depth--
+			l.pos++
+		default:
+			l.pos++
+		}
+	}
+	return true
+}
+
+func MatchingClose(tokens []Token, open int) int {
+	var closer string
+	switch tokens[open].Text {
+	case "(":
+		closer = ")"
+	case "[":
+		closer = "]"
+	case "{":
+		closer = "}"
+	default:
+		return -1
+	}
+
+	depth := 0
+	for i := open; i < len(tokens); i++ {
+		if tokens[i].Kind != Punct {
+			continue
+		}
+		switch tokens[i].Text {
+		case "(", "[", "{":
+			depth++
+		case ")", "]", "}":
+			depth--
+			if depth == 0 {
+				if tokens[i].Text != closer {
+					return -1
+				}
+				return i
+			}
+		}
+	}
+	return -1
+}
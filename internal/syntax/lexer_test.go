@@ -0,0 +1,33 @@
+package syntax
+
+import "testing"
+
+func TestLexStrings(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{"regular", `x = "a\"b";`, []string{`"a\"b"`}},
+		{"verbatim quote escape", `x = @""".Count()>0";`, []string{`@""".Count()>0"`}},
+		{"raw", `x = """a "b" c""";`, []string{`"""a "b" c"""`}},
+		{"interpolated hole string", `x = $"{f("}")}";`, []string{`$"{f("}")}"`}},
+		{"raw interpolated", `x = $$"""{{{y}}}""";`, []string{`$$"""{{{y}}}"""`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, tok := range Lex(tt.src) {
+				if tok.Kind == String {
+					if tok.Unterminated {
+						t.Fatalf("%s lexed as unterminated", tok.Text)
+					}
+					got = append(got, tok.Text)
+				}
+			}
+			if len(got) != len(tt.want) || (len(got) > 0 && got[0] != tt.want[0]) {
+				t.Fatalf("strings = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package syntax
+
+import (
+	"strconv"
+	"strings"
+)
+
+type Masked struct {
+	Text         string
+	marker       string
+	originals    []string
+	placeholders []string
+	offsets      []int
+}
+
+func Mask(src string, literals bool) Masked {
+	m := Masked{Text: src}
+	for c := byte(0); c < 0x20 && m.marker == ""; c++ {
+		if c != '\t' && c != '\n' && c != '\r' && strings.IndexByte(src, c) == -1 {
+			m.marker = string(c)
+		}
+	}
+	if m.marker == "" {
+		return m
+	}
+
+	var b strings.Builder
+	last := 0
+	add := func(from, to int, open, close string) {
+		marker := m.marker + strconv.Itoa(len(m.originals)) + m.marker
+		placeholder := open + marker + strings.Repeat("\n", strings.Count(src[from:to], "\n")) + close
+
+		b.WriteString(src[last:from])
+		b.WriteString(placeholder)
+		last = to
+
+		m.originals = append(m.originals, src[from:to])
+		m.placeholders = append(m.placeholders, placeholder)
+		m.offsets = append(m.offsets, len(open))
+	}
+	for _, tok := range Lex(src) {
+		if segments, ok := literalSegments(src, tok, literals); ok {
+			for _, seg := range segments {
+				add(seg[0], seg[1], "", "")
+			}
+			continue
+		}
+		open, close, ok := maskShape(tok, literals)
+		if !ok {
+			continue
+		}
+		add(tok.Pos, tok.End, open, close)
+	}
+	if len(m.originals) == 0 {
+		return m
+	}
+	b.WriteString(src[last:])
+	m.Text = b.String()
+	return m
+}
+
+func literalSegments(src string, tok Token, literals bool) ([][2]int, bool) {
+	if !literals || tok.Unterminated {
+		return nil, false
+	}
+	holes := Holes(src, tok)
+	if len(holes) == 0 {
+		return nil, false
+	}
+
+	quote := strings.IndexByte(tok.Text, '"')
+	dollars := strings.Count(tok.Text[:quote], "$")
+	quotes := 0
+	for quote+quotes < len(tok.Text) && tok.Text[quote+quotes] == '"' {
+		quotes++
+	}
+	if quotes < 3 || strings.Contains(tok.Text[:quote], "@") {
+		quotes, dollars = 1, 1
+	}
+
+	var segments [][2]int
+	from := tok.Pos + quote + quotes
+	for _, hole := range append(holes, [2]int{tok.End - quotes + dollars, 0}) {
+		if to := hole[0] - dollars; to > from {
+			segments = append(segments, [2]int{from, to})
+		}
+		from = hole[1] + dollars
+	}
+	return segments, true
+}
+
+func maskShape(tok Token, literals bool) (string, string, bool) {
+	text := tok.Text
+	cr := ""
+	if strings.HasSuffix(text, "\r") {
+		cr = "\r"
+	}
+
+	switch tok.Kind {
+	case Comment:
+		switch {
+		case strings.HasPrefix(text, "///"):
+			return "///", cr, true
+		case strings.HasPrefix(text, "//"):
+			return "//", cr, true
+		case tok.Unterminated:
+			return "/*", "", true
+		default:
+			return "/*", "*/", true
+		}
+	case Char:
+		if !literals || tok.Unterminated {
+			return "", "", false
+		}
+		return "'", "'", true
+	case String:
+		if !literals || tok.Unterminated {
+			return "", "", false
+		}
+		quote := strings.IndexByte(text, '"')
+		quotes := 0
+		for quote+quotes < len(text) && text[quote+quotes] == '"' {
+			quotes++
+		}
+		if quotes < 3 || strings.Contains(text[:quote], "@") {
+			quotes = 1
+		}
+		if len(text) == quote+2*quotes {
+			return "", "", false
+		}
+		delimiter := strings.Repeat("\"", min(quotes, 3))
+		return text[:quote] + delimiter, delimiter, true
+	}
+	return "", "", false
+}
+
+func (m Masked) Restore(text string) (string, bool) {
+	if len(m.originals) == 0 {
+		return text, true
+	}
+
+	var b strings.Builder
+	seen := make([]bool, len(m.originals))
+	last := 0
+	for i := 0; i < len(text); {
+		start := strings.Index(text[i:], m.marker)
+		if start == -1 {
+			break
+		}
+		start += i
+		end := strings.Index(text[start+1:], m.marker)
+		if end == -1 {
+			return "", false
+		}
+		end += start + 1
+		n, err := strconv.Atoi(text[start+1 : end])
+		if err != nil || n < 0 || n >= len(m.originals) || seen[n] {
+			return "", false
+		}
+		from := start - m.offsets[n]
+		to := from + len(m.placeholders[n])
+		if from < last || to > len(text) || text[from:to] != m.placeholders[n] {
+			return "", false
+		}
+		seen[n] = true
+		b.WriteString(text[last:from])
+		b.WriteString(m.originals[n])
+		last = to
+		i = to
+	}
+	for _, ok := range seen {
+		if !ok {
+			return "", false
+		}
+	}
+	b.WriteString(text[last:])
+	return b.String(), true
+}
@@ -0,0 +1,45 @@
+package syntax
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMask(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		visible []string
+		hidden  []string
+	}{
+		{"comments", "var a = 1; // a.Count() > 0\n/* b */", []string{"var a = 1;", "//", "/*", "*/"}, []string{"Count", " b "}},
+		{"string", `var s = "x.Count() > 0";`, []string{`var s = "`, `";`}, []string{"Count"}},
+		{"interpolation holes stay visible", `var s = $"Total {a.Count()} of {b:N2}";`, []string{"{a.Count()}", "{b:N2}"}, []string{"Total", " of "}},
+		{"raw interpolation", `var s = $$"""{ "id": {{id}} }""";`, []string{"{{id}}"}, []string{`"id"`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Mask(tt.src, true)
+			for _, s := range tt.visible {
+				if !strings.Contains(m.Text, s) {
+					t.Errorf("masked text %q hides %q", m.Text, s)
+				}
+			}
+			for _, s := range tt.hidden {
+				if strings.Contains(m.Text, s) {
+					t.Errorf("masked text %q shows %q", m.Text, s)
+				}
+			}
+			if restored, ok := m.Restore(m.Text); !ok || restored != tt.src {
+				t.Errorf("Restore() = %q, %v", restored, ok)
+			}
+		})
+	}
+}
+
+func TestMaskRestoreRejectsDroppedText(t *testing.T) {
+	m := Mask("// note\nvar a = 1;\n", true)
+	if _, ok := m.Restore("var a = 1;\n"); ok {
+		t.Fatal("Restore accepted text with a placeholder removed")
+	}
+}
@@ -0,0 +1,77 @@
+package syntax
+
+type Span struct {
+	Start  int
+	Header int
+	End    int
+}
+
+func MemberSpans(toks []Token, from, to int) []Span {
+	var spans []Span
+	i := from
+	for i < to {
+		start := i
+		for i < to && toks[i].Is("[") {
+			end := MatchingClose(toks, i)
+			if end == -1 || end >= to {
+				return spans
+			}
+			i = end + 1
+		}
+		header := i
+
+	scan:
+		for i < to {
+			t := toks[i]
+			switch {
+			case t.Is("(") || t.Is("["):
+				end := MatchingClose(toks, i)
+				if end == -1 || end >= to {
+					return spans
+				}
+				i = end + 1
+			case t.Is("{"):
+				end := MatchingClose(toks, i)
+				if end == -1 || end >= to {
+					return spans
+				}
+				i = end + 1
+				if i < to && toks[i].Is("=") {
+					for i < to && !toks[i].Is(";") {
+						i++
+					}
+					i++
+				} else if i < to && toks[i].Is(";") {
+					i++
+				}
+				break scan
+			case t.Is("=>") || t.Is("="):
+				for i < to && !toks[i].Is(";") {
+					if toks[i].Is("{") || toks[i].Is("(") || toks[i].Is("[") {
+						end := MatchingClose(toks, i)
+						if end == -1 || end >= to {
+							return spans
+						}
+						i = end
+					}
+					i++
+				}
+				i++
+				break scan
+			case t.Is(";"):
+				i++
+				break scan
+			default:
+				i++
+			}
+		}
+		if i > to {
+			i = to
+		}
+		if header >= i {
+			break
+		}
+		spans = append(spans, Span{Start: start, Header: header, End: i})
+	}
+	return spans
+}
@@ -0,0 +1,139 @@
+package syntax
+
+import (
+	"fmt"
+	"strings"
+)
+
+type ProblemKind int
+
+const (
+	UnbalancedDelimiter ProblemKind = iota
+	UnterminatedLiteral
+	MissingTerminator
+)
+
+func (k ProblemKind) String() string {
+	switch k {
+	case UnbalancedDelimiter:
+		return "unbalanced delimiter"
+	case UnterminatedLiteral:
+		return "unterminated literal"
+	case MissingTerminator:
+		return "missing statement terminator"
+	default:
+		return "unknown"
+	}
+}
+
+type Problem struct {
+	Kind    ProblemKind
+	Line    int
+	Message string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("line %d: %s", p.Line, p.Message)
+}
+
+var closers = map[string]string{")": "(", "]": "[", "}": "{"}
+
+var statementKeywords = map[string]bool{
+	"return": true, "var": true, "if": true, "for": true, "foreach": true, "while": true,
+	"throw": true, "switch": true, "using": true, "break": true, "continue": true,
+	"do": true, "try": true, "yield": true, "goto": true, "lock": true,
+}
+
+var headerKeywords = map[string]bool{
+	"if": true, "while": true, "for": true, "foreach": true, "using": true,
+	"lock": true, "fixed": true, "switch": true, "catch": true,
+}
+
+func Problems(src string) []Problem {
+	var problems []Problem
+	line := func(pos int) int {
+		return strings.Count(src[:pos], "\n") + 1
+	}
+
+	all := Lex(src)
+	for _, t := range all {
+		if t.Unterminated {
+			problems = append(problems, Problem{UnterminatedLiteral, line(t.Pos), fmt.Sprintf("unterminated %s", t.Kind)})
+		}
+	}
+
+	toks := Significant(all)
+	var stack []int
+	for _, t := range toks {
+		if t.Kind != Punct {
+			continue
+		}
+		switch t.Text {
+		case "(", "[", "{":
+			stack = append(stack, t.Pos)
+		case ")", "]", "}":
+			if len(stack) == 0 {
+				problems = append(problems, Problem{UnbalancedDelimiter, line(t.Pos), fmt.Sprintf("unexpected '%s'", t.Text)})
+				continue
+			}
+			open := src[stack[len(stack)-1]:][:1]
+			stack = stack[:len(stack)-1]
+			if open != closers[t.Text] {
+				problems = append(problems, Problem{UnbalancedDelimiter, line(t.Pos), fmt.Sprintf("'%s' closes '%s'", t.Text, open)})
+			}
+		}
+	}
+	for _, pos := range stack {
+		problems = append(problems, Problem{UnbalancedDelimiter, line(pos), fmt.Sprintf("unclosed '%s'", src[pos:pos+1])})
+	}
+
+	for i := 1; i < len(toks); i++ {
+		prev, t := toks[i-1], toks[i]
+		if !statementKeywords[t.Text] || t.Kind == Punct || !strings.Contains(src[prev.End:t.Pos], "\n") {
+			continue
+		}
+		switch {
+		case prev.Kind == Identifier && !prev.Is("get") && !prev.Is("set") && !prev.Is("init"),
+			prev.Kind == Number, prev.Kind == String, prev.Kind == Char, prev.Is("]"):
+		case prev.Is(")"):
+			if open := matchingOpenParen(toks, i-1); open > 0 && headerKeywords[toks[open-1].Text] {
+				continue
+			}
+		default:
+			continue
+		}
+		problems = append(problems, Problem{MissingTerminator, line(prev.End), fmt.Sprintf("missing ';' before '%s'", t.Text)})
+	}
+	return problems
+}
+
+func matchingOpenParen(toks []Token, close int) int {
+	depth := 0
+	for i := close; i >= 0; i-- {
+		switch {
+		case toks[i].Is(")"):
+			depth++
+		case toks[i].Is("("):
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func Validate(before, after string) error {
+	seen := make(map[ProblemKind]int)
+	for _, p := range Problems(before) {
+		seen[p.Kind]++
+	}
+	for _, p := range Problems(after) {
+		if seen[p.Kind] > 0 {
+			seen[p.Kind]--
+			continue
+		}
+		return fmt.Errorf("%s: %s", p.Kind, p)
+	}
+	return nil
+}
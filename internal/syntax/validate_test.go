@@ -0,0 +1,89 @@
+package syntax
+
+import "testing"
+
+func TestProblems(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		kind ProblemKind
+		line int
+	}{
+		{"unclosed brace", "class C\n{\n    void M() {\n}\n", UnbalancedDelimiter, 2},
+		{"stray brace", "class C\n{\n}\n}\n", UnbalancedDelimiter, 4},
+		{"mismatched closer", "class C\n{\n    int[] xs = [1, 2);\n}\n", UnbalancedDelimiter, 3},
+		{"unterminated string", "class C\n{\n    string s = \"abc;\n}\n", UnterminatedLiteral, 3},
+		{"unterminated comment", "class C\n{\n}\n/* trailing", UnterminatedLiteral, 4},
+		{"missing semicolon", "void M()\n{\n    var a = 1\n    return a;\n}\n", MissingTerminator, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := Problems(tt.src)
+			if len(problems) != 1 || problems[0].Kind != tt.kind || problems[0].Line != tt.line {
+				t.Fatalf("problems = %v, want one %s on line %d", problems, tt.kind, tt.line)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		before  string
+		after   string
+		wantErr bool
+	}{
+		{
+			name:   "valid rewrite",
+			before: "if (x != null)\n{\n    x.Run();\n}\n",
+			after:  "x?.Run();\n",
+		},
+		{
+			name:   "braces inside strings and comments",
+			before: "var a = 1;\n",
+			after:  "var a = \"{\"; // }\nvar b = $\"{a}}}\";\n",
+		},
+		{
+			name:   "if header without semicolon",
+			before: "if (ok)\n    return;\n",
+			after:  "if (ok is true)\n    return;\n",
+		},
+		{
+			name:   "existing problem kept",
+			before: "class C\n{\n    void M() {\n}\n",
+			after:  "class C\n{\n    void M() =>\n}\n",
+		},
+		{
+			name:    "dropped closing brace",
+			before:  "class C\n{\n    void M() { }\n}\n",
+			after:   "class C\n{\n    void M() {\n}\n",
+			wantErr: true,
+		},
+		{
+			name:    "new unterminated string",
+			before:  "var s = \"a\";\n",
+			after:   "var s = \"a;\n",
+			wantErr: true,
+		},
+		{
+			name:    "new missing semicolon",
+			before:  "var a = 1;\nreturn a;\n",
+			after:   "var a = 1\nreturn a;\n",
+			wantErr: true,
+		},
+		{
+			name:    "second problem of the same kind",
+			before:  "class C\n{\n    void M() {\n}\n",
+			after:   "class C\n{\n    void M() {\n    void N() {\n}\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.before, tt.after)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
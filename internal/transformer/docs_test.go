@@ -0,0 +1,84 @@
+package transformer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/andiq123/sharpify/internal/rules"
+	"github.com/andiq123/sharpify/internal/scanner"
+)
+
+const exampleProject = `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <TargetFramework>net8.0</TargetFramework>
+    <ImplicitUsings>enable</ImplicitUsings>
+  </PropertyGroup>
+</Project>
+`
+
+func runExample(t *testing.T, rule rules.Rule, before string) Result {
+	t.Helper()
+	dir := t.TempDir()
+	target := filepath.Join(dir, "Example.cs")
+	files := []scanner.FileInfo{{Path: target, Content: before}}
+	if _, ok := rule.(rules.ProjectRule); ok {
+		other := filepath.Join(dir, "Other.cs")
+		files = append(files, scanner.FileInfo{Path: other, Content: before})
+		if err := os.WriteFile(filepath.Join(dir, "Example.csproj"), []byte(exampleProject), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, f := range files {
+		if err := os.WriteFile(f.Path, []byte(f.Content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tr := New([]rules.Rule{rule})
+	tr.SetSeverities(map[string]rules.Severity{rule.Name(): rules.SeverityFix})
+	for _, r := range tr.TransformAll(files) {
+		if r.File.Path == target {
+			return r
+		}
+	}
+	t.Fatalf("no result for %s", target)
+	return Result{}
+}
+
+func TestRuleDocumentation(t *testing.T) {
+	registry := NewRegistry()
+	for _, rule := range registry.All() {
+		rule := rule
+		t.Run(rule.Name(), func(t *testing.T) {
+			doc, ok := rules.DocumentationOf(rule)
+			if !ok {
+				t.Fatal("rule has no documentation")
+			}
+			if doc.Rationale == "" {
+				t.Error("documentation has no rationale")
+			}
+			if len(doc.Examples) == 0 {
+				t.Error("documentation has no examples")
+			}
+			for _, name := range doc.Related {
+				if name == rule.Name() {
+					t.Errorf("rule lists itself as related")
+				}
+				if _, ok := registry.Get(name); !ok {
+					t.Errorf("related rule %q is not registered", name)
+				}
+			}
+
+			for i, ex := range doc.Examples {
+				got := runExample(t, rule, ex.Before)
+				for _, f := range got.Failures {
+					t.Errorf("example %d: internal rule failure: %v", i+1, f)
+				}
+				if got.NewContent != ex.After {
+					t.Errorf("example %d does not match the rule's output\n--- got ---\n%s\n--- documented ---\n%s", i+1, got.NewContent, ex.After)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package transformer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andiq123/sharpify/internal/rules"
+	"github.com/andiq123/sharpify/internal/scanner"
+)
+
+const fuzzTimeout = 5 * time.Second
+
+func fuzzSeeds(f *testing.F) []string {
+	f.Helper()
+	var seeds []string
+	for _, pattern := range []string{"../../testdata/*.cs", "../../testdata/rules/*/*/*.cs"} {
+		paths, err := filepath.Glob(pattern)
+		if err != nil {
+			f.Fatal(err)
+		}
+		for _, path := range paths {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				f.Fatal(err)
+			}
+			seeds = append(seeds, string(data))
+		}
+	}
+	return seeds
+}
+
+func applyRule(rule rules.Rule, content string) (string, bool) {
+	t := New([]rules.Rule{rule})
+	t.Prepare([]scanner.FileInfo{{Path: "Fuzz.cs", Content: content}})
+	out, changed, _ := t.apply(rule, "Fuzz.cs", content)
+	return out, changed
+}
+
+func withinTimeout(t *testing.T, what string, fn func()) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn()
+	}()
+	select {
+	case <-done:
+	case <-time.After(fuzzTimeout):
+		t.Fatalf("%s did not finish within %s", what, fuzzTimeout)
+	}
+}
+
+func commentedOut(src string) string {
+	lines := strings.Split(src, "\n")
+	for i, line := range lines {
+		lines[i] = "// " + strings.TrimRight(line, "\r")
+	}
+	return strings.Join(lines, "\n") + "\n/* " + strings.ReplaceAll(src, "*/", "* /") + " */\n"
+}
+
+func inVerbatimString(src string) string {
+	return "class Fuzz\n{\n    const string Text = @\"" + strings.ReplaceAll(src, `"`, `""`) + "\";\n}\n"
+}
+
+func checkRule(t *testing.T, rule rules.Rule, src string) {
+	var out, again string
+	var changed bool
+	withinTimeout(t, rule.Name(), func() {
+		out, changed = applyRule(rule, src)
+		again, _ = applyRule(rule, out)
+	})
+	if !changed && out != src {
+		t.Errorf("%s changed the input but reported no change", rule.Name())
+	}
+	if again != out {
+		t.Errorf("%s is not idempotent\n--- first ---\n%s\n--- second ---\n%s", rule.Name(), out, again)
+	}
+
+	contexts := map[string]string{"comment": commentedOut(src)}
+	if lr, ok := rule.(rules.LiteralRule); !ok || !lr.RewritesLiterals() {
+		contexts["string literal"] = inVerbatimString(src)
+	}
+	for where, wrapped := range contexts {
+		var got string
+		withinTimeout(t, rule.Name(), func() {
+			got, _ = applyRule(rule, wrapped)
+		})
+		if got != wrapped {
+			t.Errorf("%s edited code inside a %s\n--- before ---\n%s\n--- after ---\n%s", rule.Name(), where, wrapped, got)
+		}
+	}
+}
+
+func FuzzRule(f *testing.F) {
+	names := NewRegistry().Names()
+	for _, seed := range fuzzSeeds(f) {
+		for i := range names {
+			f.Add(seed, uint8(i))
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, src string, index uint8) {
+		name := names[int(index)%len(names)]
+		rule, _ := NewRegistry().Get(name)
+		checkRule(t, rule, src)
+	})
+}
+
+func FuzzTransformer(f *testing.F) {
+	for _, seed := range fuzzSeeds(f) {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, src string) {
+		transform := func(content string) Result {
+			tr := New(NewRegistry().GetByVersion(rules.CSharp12, false))
+			file := scanner.FileInfo{Path: "Fuzz.cs", Content: content}
+			tr.Prepare([]scanner.FileInfo{file})
+			return tr.Transform(file)
+		}
+
+		var first, second Result
+		withinTimeout(t, "transformer", func() {
+			first = transform(src)
+			second = transform(first.NewContent)
+		})
+		if second.Changed {
+			t.Errorf("pipeline is not idempotent (rules: %s)\n--- first ---\n%s\n--- second ---\n%s", ruleNames(second.AppliedRules), first.NewContent, second.NewContent)
+		}
+		wrapped := commentedOut(src)
+		if r := transform(wrapped); r.NewContent != wrapped {
+			t.Errorf("pipeline edited code inside comments (rules: %s)", ruleNames(r.AppliedRules))
+		}
+	})
+}
+
+func ruleNames(applied []rules.RuleResult) string {
+	names := make([]string, len(applied))
+	for i, r := range applied {
+		names[i] = r.RuleName
+	}
+	return strings.Join(names, ", ")
+}
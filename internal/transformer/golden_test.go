@@ -0,0 +1,224 @@
+package transformer
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andiq123/sharpify/internal/rules"
+	"github.com/andiq123/sharpify/internal/scanner"
+)
+
+var update = flag.Bool("update", false, "regenerate expected.cs and pipeline.cs golden files")
+
+const goldenDir = "../../testdata/rules"
+
+type goldenCase struct {
+	rule    string
+	dir     string
+	input   string
+	options json.RawMessage
+}
+
+func (c goldenCase) negative() bool {
+	return strings.HasPrefix(filepath.Base(c.dir), "negative-")
+}
+
+func loadGoldenCases(t *testing.T) []goldenCase {
+	t.Helper()
+	ruleDirs, err := os.ReadDir(goldenDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var cases []goldenCase
+	for _, ruleDir := range ruleDirs {
+		if !ruleDir.IsDir() {
+			continue
+		}
+		caseDirs, err := os.ReadDir(filepath.Join(goldenDir, ruleDir.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, caseDir := range caseDirs {
+			if !caseDir.IsDir() {
+				continue
+			}
+			dir := filepath.Join(goldenDir, ruleDir.Name(), caseDir.Name())
+			input, err := os.ReadFile(filepath.Join(dir, "input.cs"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := goldenCase{rule: ruleDir.Name(), dir: dir, input: string(input)}
+			if options, err := os.ReadFile(filepath.Join(dir, "options.json")); err == nil {
+				c.options = options
+			}
+			cases = append(cases, c)
+		}
+	}
+	return cases
+}
+
+func newGoldenRegistry(t *testing.T, c goldenCase) *RuleRegistry {
+	t.Helper()
+	registry := NewRegistry()
+	if c.options != nil {
+		if err := registry.Configure(c.rule, c.options); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return registry
+}
+
+func stage(t *testing.T, c goldenCase, content string) (string, []scanner.FileInfo) {
+	t.Helper()
+	dir := t.TempDir()
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	target := filepath.Join(dir, "input.cs")
+	files := []scanner.FileInfo{{Path: target, Content: content}}
+	if err := os.WriteFile(target, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || name == "input.cs" || name == "expected.cs" || name == "pipeline.cs" || name == "options.json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(c.dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+		if strings.EqualFold(filepath.Ext(name), ".cs") {
+			files = append(files, scanner.FileInfo{Path: filepath.Join(dir, name), Content: string(data)})
+		}
+	}
+	return target, files
+}
+
+func run(t *testing.T, c goldenCase, ruleList []rules.Rule, severities map[string]rules.Severity, content string) Result {
+	t.Helper()
+	target, files := stage(t, c, content)
+	tr := New(ruleList)
+	tr.SetSeverities(severities)
+	for _, r := range tr.TransformAll(files) {
+		if r.File.Path == target {
+			return r
+		}
+	}
+	t.Fatalf("no result for %s", target)
+	return Result{}
+}
+
+func TestGoldenRules(t *testing.T) {
+	cases := loadGoldenCases(t)
+	covered := make(map[string]bool)
+	for _, c := range cases {
+		covered[c.rule] = true
+	}
+	for _, name := range NewRegistry().Names() {
+		if !covered[name] {
+			t.Errorf("rule %s has no golden cases in %s", name, goldenDir)
+		}
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.rule+"/"+filepath.Base(c.dir), func(t *testing.T) {
+			rule, ok := newGoldenRegistry(t, c).Get(c.rule)
+			if !ok {
+				t.Fatalf("unknown rule %q", c.rule)
+			}
+			alone := func(content string) Result {
+				return run(t, c, []rules.Rule{rule}, map[string]rules.Severity{c.rule: rules.SeverityFix}, content)
+			}
+
+			got := alone(c.input)
+			for _, f := range got.Failures {
+				t.Errorf("internal rule failure: %v", f)
+			}
+			if c.negative() && got.NewContent != c.input {
+				t.Errorf("negative case changed the input\n--- got ---\n%s", got.NewContent)
+			}
+
+			expectedPath := filepath.Join(c.dir, "expected.cs")
+			if *update && !c.negative() {
+				writeGolden(t, expectedPath, got.NewContent)
+			}
+			expected := readGolden(t, expectedPath)
+			if c.negative() && expected != c.input {
+				t.Errorf("expected.cs of a negative case must equal input.cs")
+			}
+			if got.NewContent != expected {
+				t.Errorf("output does not match expected.cs\n--- got ---\n%s\n--- expected ---\n%s", got.NewContent, expected)
+			}
+
+			if again := alone(expected); again.Changed {
+				t.Errorf("rule is not idempotent on expected.cs\n--- got ---\n%s", again.NewContent)
+			}
+		})
+	}
+}
+
+func TestGoldenPipeline(t *testing.T) {
+	for _, c := range loadGoldenCases(t) {
+		c := c
+		t.Run(c.rule+"/"+filepath.Base(c.dir), func(t *testing.T) {
+			registry := newGoldenRegistry(t, c)
+			all := registry.GetByVersion(rules.CSharp12, false)
+			pipeline := func(content string) Result {
+				r := run(t, c, all, nil, content)
+				for _, f := range r.Failures {
+					t.Errorf("internal rule failure: %v", f)
+				}
+				return r
+			}
+
+			got := pipeline(c.input)
+			pipelinePath := filepath.Join(c.dir, "pipeline.cs")
+			if *update {
+				writeGolden(t, pipelinePath, got.NewContent)
+			}
+			if expected := readGolden(t, pipelinePath); got.NewContent != expected {
+				t.Errorf("pipeline output does not match pipeline.cs\n--- got ---\n%s\n--- expected ---\n%s", got.NewContent, expected)
+			}
+			if again := pipeline(got.NewContent); again.Changed {
+				t.Errorf("pipeline is not idempotent (rules: %s)\n--- got ---\n%s", appliedNames(again), again.NewContent)
+			}
+			pipeline(readGolden(t, filepath.Join(c.dir, "expected.cs")))
+		})
+	}
+}
+
+func appliedNames(r Result) string {
+	var names []string
+	for _, a := range r.AppliedRules {
+		names = append(names, a.RuleName)
+	}
+	return strings.Join(names, ", ")
+}
+
+func writeGolden(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readGolden(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run with -update to create it)", err)
+	}
+	return string(data)
+}
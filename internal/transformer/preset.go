@@ -0,0 +1,172 @@
+package transformer
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/andiq123/sharpify/internal/rules"
+)
+
+const DefaultPreset = "safe"
+
+type Preset struct {
+	Name        string              `json:"-"`
+	Description string              `json:"description,omitempty"`
+	Extends     string              `json:"extends,omitempty"`
+	Rules       []string            `json:"rules,omitempty"`
+	Exclude     []string            `json:"exclude,omitempty"`
+	SafeOnly    bool                `json:"safeOnly,omitempty"`
+	MaxVersion  rules.CSharpVersion `json:"maxVersion,omitempty"`
+}
+
+func builtinPresets() []Preset {
+	return []Preset{
+		{
+			Name:        "safe",
+			Description: "Every rule that is safe to apply without review",
+			SafeOnly:    true,
+		},
+		{
+			Name:        "recommended",
+			Description: "Safe rules plus well-understood rewrites that rarely need attention",
+			Extends:     "safe",
+			Rules:       []string{"target-typed-new", "switch-expression", "discard-variable"},
+		},
+		{
+			Name:        "aggressive",
+			Description: "Every rule, including ones that need manual review",
+		},
+		{
+			Name:        "csharp10-upgrade",
+			Description: "Safe rules available up to C# 10 (.NET 6)",
+			SafeOnly:    true,
+			MaxVersion:  rules.CSharp10,
+		},
+		{
+			Name:        "performance",
+			Description: "Rules that remove avoidable allocations and enumerations",
+			Rules: []string{
+				"linq-count-any",
+				"linq-where-first",
+				"span-suggestion",
+				"stopwatch-start-new",
+				"tuple-deconstruction",
+			},
+		},
+		{
+			Name:        "readability",
+			Description: "Rules that make code shorter and clearer without changing behavior",
+			Rules: []string{
+				"conditional-access-delegate",
+				"default-literal",
+				"exception-filter",
+				"expression-body",
+				"file-scoped-namespace",
+				"index-range",
+				"nameof-expression",
+				"null-coalescing-assignment",
+				"null-propagation",
+				"pattern-matching",
+				"pattern-matching-null",
+				"string-interpolation",
+				"string-isnullorempty",
+				"throw-expression",
+				"throw-helper",
+				"var-pattern",
+			},
+		},
+	}
+}
+
+func (r *RuleRegistry) RegisterPreset(p Preset) {
+	r.presets[p.Name] = p
+}
+
+func (r *RuleRegistry) Preset(name string) (Preset, bool) {
+	p, ok := r.presets[name]
+	return p, ok
+}
+
+func (r *RuleRegistry) Presets() []Preset {
+	result := make([]Preset, 0, len(r.presets))
+	for _, p := range r.presets {
+		result = append(result, p)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+func (r *RuleRegistry) ResolvePreset(name string) ([]rules.Rule, error) {
+	selected, err := r.resolvePreset(name, map[string]bool{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]rules.Rule, 0, len(selected))
+	for ruleName := range selected {
+		result = append(result, r.rules[ruleName])
+	}
+	sortRules(result)
+	return result, nil
+}
+
+func (r *RuleRegistry) resolvePreset(name string, visiting map[string]bool) (map[string]bool, error) {
+	p, ok := r.presets[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown preset %q", name)
+	}
+	if visiting[name] {
+		return nil, fmt.Errorf("preset %q extends itself", name)
+	}
+	visiting[name] = true
+	defer delete(visiting, name)
+
+	selected := make(map[string]bool)
+	switch {
+	case p.Extends != "":
+		base, err := r.resolvePreset(p.Extends, visiting)
+		if err != nil {
+			return nil, err
+		}
+		selected = base
+	case len(p.Rules) == 0:
+		for ruleName := range r.rules {
+			selected[ruleName] = true
+		}
+	}
+
+	for ruleName := range selected {
+		if !p.allows(r.rules[ruleName]) {
+			delete(selected, ruleName)
+		}
+	}
+
+	for _, ruleName := range p.Rules {
+		if _, ok := r.rules[ruleName]; !ok {
+			return nil, fmt.Errorf("preset %q: unknown rule %q", name, ruleName)
+		}
+		selected[ruleName] = true
+	}
+
+	for _, ruleName := range p.Exclude {
+		delete(selected, ruleName)
+	}
+
+	return selected, nil
+}
+
+func (p Preset) allows(rule rules.Rule) bool {
+	vr, ok := rule.(rules.VersionedRule)
+	if !ok {
+		return !p.SafeOnly && p.MaxVersion == 0
+	}
+	if p.SafeOnly && !vr.IsSafe() {
+		return false
+	}
+	if p.MaxVersion != 0 && vr.MinVersion() > p.MaxVersion {
+		return false
+	}
+	return true
+}
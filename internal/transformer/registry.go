@@ -1,6 +1,8 @@
 package transformer
 
 import (
+	"encoding/json"
+	"fmt"
 	"sort"
 
 	"github.com/andiq123/sharpify/internal/rules"
@@ -8,70 +10,89 @@ import (
 
 
 type RuleRegistry struct {
-	rules map[string]rules.Rule
+	rules   map[string]rules.Rule
+	presets map[string]Preset
 }
 
 
 func NewRegistry() *RuleRegistry {
 	r := &RuleRegistry{
-		rules: make(map[string]rules.Rule),
+		rules:   make(map[string]rules.Rule),
+		presets: make(map[string]Preset),
 	}
 
 	
-	r.Register(rules.NewExpressionBody())
-	r.Register(rules.NewStringInterpolation())
-	r.Register(rules.NewStringConcatToInterpolation())
-	r.Register(rules.NewNameofExpression())
-	r.Register(rules.NewNullPropagation())
-	r.Register(rules.NewVarPattern())
-	r.Register(rules.NewStopwatchStartNew())
-	r.Register(rules.NewConditionalAccessDelegate())
-	r.Register(rules.NewExceptionFilter())
-	r.Register(rules.NewLinqCountAny())
-	r.Register(rules.NewLinqWhereFirst())
+	r.register(rules.NewExpressionBody())
+	r.register(rules.NewStringInterpolation())
+	r.register(rules.NewStringConcatToInterpolation())
+	r.register(rules.NewNameofExpression())
+	r.register(rules.NewNullPropagation())
+	r.register(rules.NewVarPattern())
+	r.register(rules.NewStopwatchStartNew())
+	r.register(rules.NewConditionalAccessDelegate())
+	r.register(rules.NewExceptionFilter())
+	r.register(rules.NewLinqCountAny())
+	r.register(rules.NewLinqWhereFirst())
 
 	
-	r.Register(rules.NewPatternMatching())
-	r.Register(rules.NewDefaultLiteral())
-	r.Register(rules.NewTupleDeconstruction())
-	r.Register(rules.NewDiscardVariable())
-	r.Register(rules.NewSpanSuggestion())
-	r.Register(rules.NewThrowExpression())
-	r.Register(rules.NewTupleSwap())
+	r.register(rules.NewPatternMatching())
+	r.register(rules.NewDefaultLiteral())
+	r.register(rules.NewTupleDeconstruction())
+	r.register(rules.NewDiscardVariable())
+	r.register(rules.NewSpanSuggestion())
+	r.register(rules.NewThrowExpression())
+	r.register(rules.NewTupleSwap())
 
 	
-	r.Register(rules.NewNullCoalescing())
-	r.Register(rules.NewIndexRange())
-	r.Register(rules.NewSwitchExpression())
+	r.register(rules.NewNullCoalescing())
+	r.register(rules.NewIndexRange())
+	r.register(rules.NewSwitchExpression())
 
 	
-	r.Register(rules.NewTargetTypedNew())
-	r.Register(rules.NewPatternMatchingNull())
-	r.Register(rules.NewRecordType())
-	r.Register(rules.NewInitOnlyProperty())
+	r.register(rules.NewTargetTypedNew())
+	r.register(rules.NewPatternMatchingNull())
+	r.register(rules.NewRecordType())
+	r.register(rules.NewInitOnlyProperty())
 
 	
-	r.Register(rules.NewFileScopedNamespace())
-	r.Register(rules.NewGlobalUsing())
-	r.Register(rules.NewImplicitUsing())
-	r.Register(rules.NewThrowHelper())
+	r.register(rules.NewFileScopedNamespace())
+	r.register(rules.NewGlobalUsing())
+	r.register(rules.NewImplicitUsing())
+	r.register(rules.NewThrowHelper())
 
 	
-	r.Register(rules.NewRawStringLiteral())
-	r.Register(rules.NewRequiredProperty())
-	r.Register(rules.NewListPattern())
-	r.Register(rules.NewStringIsNullOrEmpty())
+	r.register(rules.NewRawStringLiteral())
+	r.register(rules.NewRequiredProperty())
+	r.register(rules.NewListPattern())
+	r.register(rules.NewStringIsNullOrEmpty())
 
 	
-	r.Register(rules.NewCollectionExpression())
-	r.Register(rules.NewPrimaryConstructor())
-	r.Register(rules.NewSpreadOperator())
+	r.register(rules.NewCollectionExpression())
+	r.register(rules.NewPrimaryConstructor())
+	r.register(rules.NewSpreadOperator())
+
+	for _, p := range builtinPresets() {
+		r.RegisterPreset(p)
+	}
 
 	return r
 }
 
 
-func (r *RuleRegistry) Register(rule rules.Rule) {
+func (r *RuleRegistry) Register(rule rules.Rule) error {
+	if _, exists := r.rules[rule.Name()]; exists {
+		return fmt.Errorf("rule %s conflicts with an existing rule", rule.Name())
+	}
+	r.register(rule)
+	return nil
+}
+
+
+func (r *RuleRegistry) Replace(rule rules.Rule) {
+	r.register(rule)
+}
+
+func (r *RuleRegistry) register(rule rules.Rule) {
 	r.rules[rule.Name()] = rule
 }
 
@@ -116,17 +137,24 @@ func (r *RuleRegistry) GetByVersion(version rules.CSharpVersion, safeOnly bool)
 		}
 	}
 
-	
+	sortRules(result)
+	return result
+}
+
+func sortRules(result []rules.Rule) {
+	version := func(rule rules.Rule) rules.CSharpVersion {
+		if vr, ok := rule.(rules.VersionedRule); ok {
+			return vr.MinVersion()
+		}
+		return 0
+	}
 	sort.Slice(result, func(i, j int) bool {
-		vi := result[i].(rules.VersionedRule).MinVersion()
-		vj := result[j].(rules.VersionedRule).MinVersion()
+		vi, vj := version(result[i]), version(result[j])
 		if vi != vj {
 			return vi < vj
 		}
 		return result[i].Name() < result[j].Name()
 	})
-
-	return result
 }
 
 
@@ -170,3 +198,16 @@ func (r *RuleRegistry) GroupByVersion() map[rules.CSharpVersion][]rules.Rule {
 
 	return groups
 }
+
+
+func (r *RuleRegistry) Configure(name string, options json.RawMessage) error {
+	rule, ok := r.Get(name)
+	if !ok {
+		return fmt.Errorf("unknown rule %q", name)
+	}
+	c, ok := rule.(rules.Configurable)
+	if !ok {
+		return fmt.Errorf("rule %q has no options", name)
+	}
+	return c.Configure(options)
+}
@@ -1,8 +1,20 @@
 package transformer
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/andiq123/sharpify/internal/diff"
+	"github.com/andiq123/sharpify/internal/project"
 	"github.com/andiq123/sharpify/internal/rules"
 	"github.com/andiq123/sharpify/internal/scanner"
+	"github.com/andiq123/sharpify/internal/symbols"
+	"github.com/andiq123/sharpify/internal/syntax"
 )
 
 
@@ -11,18 +23,74 @@ type Result struct {
 	NewContent   string
 	Changed      bool
 	AppliedRules []rules.RuleResult
+	Findings     []rules.Finding
+	Failures     []RuleFailure
+}
+
+type RuleFailure struct {
+	Rule string
+	Err  error
+}
+
+func (f RuleFailure) Error() string {
+	return fmt.Sprintf("%s: %v", f.Rule, f.Err)
 }
 
 
 type Transformer struct {
-	rules []rules.Rule
+	rules      []rules.Rule
+	severities map[string]rules.Severity
+	symbols    *symbols.Index
+	excluded   map[string]map[string]bool
+	baseline   Baseline
+	context    []scanner.FileInfo
+}
+
+type Baseline interface {
+	Suppresses(path, rule string, h diff.Hunk) bool
 }
 
 
 func New(ruleList []rules.Rule) *Transformer {
 	return &Transformer{
-		rules: ruleList,
+		rules:      ruleList,
+		severities: make(map[string]rules.Severity),
+		excluded:   make(map[string]map[string]bool),
+	}
+}
+
+func (t *Transformer) Only(rule rules.Rule) *Transformer {
+	only := *t
+	only.rules = []rules.Rule{rule}
+	return &only
+}
+
+func (t *Transformer) Exclude(rule, path string) {
+	if t.excluded[rule] == nil {
+		t.excluded[rule] = make(map[string]bool)
+	}
+	t.excluded[rule][absPath(path)] = true
+}
+
+func (t *Transformer) isExcluded(rule, path string) bool {
+	return t.excluded[rule][absPath(path)]
+}
+
+func (t *Transformer) SetBaseline(b Baseline) {
+	t.baseline = b
+}
+
+func (t *Transformer) SetSeverities(overrides map[string]rules.Severity) {
+	for name, severity := range overrides {
+		t.severities[name] = severity
+	}
+}
+
+func (t *Transformer) Severity(rule rules.Rule) rules.Severity {
+	if severity, ok := t.severities[rule.Name()]; ok {
+		return severity
 	}
+	return rules.DefaultSeverityOf(rule)
 }
 
 
@@ -34,7 +102,31 @@ func (t *Transformer) Transform(file scanner.FileInfo) Result {
 	}
 
 	for _, rule := range t.rules {
-		newContent, applied := rule.Apply(result.NewContent)
+		severity := t.Severity(rule)
+		if severity != rules.SeverityFix {
+			result.Findings = append(result.Findings, t.analyze(rule, severity, file.Path, result.NewContent)...)
+			continue
+		}
+		if _, ok := rule.(rules.ProjectRule); ok || t.isExcluded(rule.Name(), file.Path) {
+			continue
+		}
+
+		newContent, applied, err := t.apply(rule, file.Path, result.NewContent)
+		if err != nil {
+			result.Failures = append(result.Failures, RuleFailure{Rule: rule.Name(), Err: err})
+		}
+		if applied {
+			if err := syntax.Validate(result.NewContent, newContent); err != nil {
+				result.Failures = append(result.Failures, RuleFailure{Rule: rule.Name(), Err: invalidCode(err)})
+				applied = false
+			}
+		}
+		if applied {
+			if newContent, err = t.withoutBaselined(rule, file.Path, result.NewContent, newContent); err != nil {
+				result.Failures = append(result.Failures, RuleFailure{Rule: rule.Name(), Err: err})
+			}
+			applied = newContent != result.NewContent
+		}
 		if applied {
 			result.NewContent = newContent
 			result.Changed = true
@@ -44,16 +136,279 @@ func (t *Transformer) Transform(file scanner.FileInfo) Result {
 				Description: rule.Description(),
 			})
 		}
+
+		if analyzer, ok := rule.(rules.Analyzer); ok {
+			result.Findings = append(result.Findings, withSeverity(analyzer.Analyze(result.NewContent), severity)...)
+		}
 	}
 
+	sort.SliceStable(result.Findings, func(i, j int) bool {
+		return result.Findings[i].Line < result.Findings[j].Line
+	})
+
 	return result
 }
 
+func (t *Transformer) apply(rule rules.Rule, path, content string) (string, bool, error) {
+	lr, ok := rule.(rules.LiteralRule)
+	masked := syntax.Mask(content, !ok || !lr.RewritesLiterals())
+
+	var out string
+	var applied bool
+	if fr, ok := rule.(rules.FallibleRule); ok {
+		var err error
+		if out, applied, err = fr.TryApply(path, masked.Text); err != nil {
+			return content, false, err
+		}
+	} else if cr, ok := rule.(rules.ContextRule); ok && t.symbols != nil {
+		out, applied = cr.ApplyContext(&rules.Context{File: path, Symbols: t.symbols}, masked.Text)
+	} else {
+		out, applied = rule.Apply(masked.Text)
+	}
+	if !applied {
+		return content, false, nil
+	}
+
+	restored, ok := masked.Restore(out)
+	if !ok {
+		return content, false, errMaskedText
+	}
+	return restored, restored != content, nil
+}
+
+var errMaskedText = errors.New("removed or reordered a comment or string literal")
+
+func (t *Transformer) withoutBaselined(rule rules.Rule, path, before, after string) (string, error) {
+	if t.baseline == nil {
+		return after, nil
+	}
+	hunks := diff.Hunks(before, after)
+	kept := make([]diff.Hunk, 0, len(hunks))
+	for _, h := range hunks {
+		if !t.baseline.Suppresses(path, rule.Name(), h) {
+			kept = append(kept, h)
+		}
+	}
+	switch len(kept) {
+	case len(hunks):
+		return after, nil
+	case 0:
+		return before, nil
+	}
+	next := diff.Apply(before, kept)
+	if strings.EqualFold(filepath.Ext(path), ".cs") {
+		if err := syntax.Validate(before, next); err != nil {
+			return before, fmt.Errorf("changes are invalid without the baselined ones: %w", err)
+		}
+	}
+	return next, nil
+}
+
+func invalidCode(err error) error {
+	return fmt.Errorf("produced invalid code: %w", err)
+}
+
+func (t *Transformer) analyze(rule rules.Rule, severity rules.Severity, path, content string) []rules.Finding {
+	if analyzer, ok := rule.(rules.Analyzer); ok {
+		return withSeverity(analyzer.Analyze(content), severity)
+	}
+
+	newContent, applied, _ := t.apply(rule, path, content)
+	if !applied || newContent == content {
+		return nil
+	}
+	return []rules.Finding{{
+		Rule:     rule.Name(),
+		Severity: severity,
+		Line:     firstChangedLine(content, newContent),
+		Message:  rule.Description(),
+	}}
+}
+
+func withSeverity(findings []rules.Finding, severity rules.Severity) []rules.Finding {
+	for i := range findings {
+		findings[i].Severity = severity
+	}
+	return findings
+}
+
+func firstChangedLine(before, after string) int {
+	oldLines := strings.Split(before, "\n")
+	newLines := strings.Split(after, "\n")
+	for i := range oldLines {
+		if i >= len(newLines) || oldLines[i] != newLines[i] {
+			return i + 1
+		}
+	}
+	return len(oldLines)
+}
+
 
 func (t *Transformer) TransformAll(files []scanner.FileInfo) []Result {
+	results, _ := t.TransformAllContext(context.Background(), files)
+	return results
+}
+
+func (t *Transformer) TransformAllContext(ctx context.Context, files []scanner.FileInfo) ([]Result, error) {
+	t.Prepare(files)
+
 	results := make([]Result, 0, len(files))
 	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		results = append(results, t.Transform(file))
 	}
+	return t.applyProjectRules(results), nil
+}
+
+func (t *Transformer) applyProjectRules(results []Result) []Result {
+	var projectRules []rules.ProjectRule
+	for _, rule := range t.rules {
+		if pr, ok := rule.(rules.ProjectRule); ok {
+			projectRules = append(projectRules, pr)
+		}
+	}
+	if len(projectRules) == 0 {
+		return results
+	}
+
+	locator := project.NewLocator()
+	byPath := make(map[string]int, len(results))
+	var projects []*project.Project
+	seen := make(map[*project.Project]bool)
+	for i, r := range results {
+		byPath[absPath(r.File.Path)] = i
+		if p := locator.Owner(r.File.Path); p != nil && !seen[p] {
+			seen[p] = true
+			projects = append(projects, p)
+		}
+	}
+
+	resultFor := func(path string) *Result {
+		key := absPath(path)
+		i, ok := byPath[key]
+		if !ok {
+			original, _ := os.ReadFile(path)
+			results = append(results, Result{
+				File:       scanner.FileInfo{Path: path, Content: string(original)},
+				NewContent: string(original),
+			})
+			i = len(results) - 1
+			byPath[key] = i
+		}
+		return &results[i]
+	}
+
+	for _, p := range projects {
+		paths, err := p.SourceFiles()
+		if err != nil {
+			continue
+		}
+
+		files := make([]rules.SourceFile, 0, len(paths))
+		for _, path := range paths {
+			if i, ok := byPath[absPath(path)]; ok {
+				files = append(files, rules.SourceFile{Path: results[i].File.Path, Content: results[i].NewContent})
+				continue
+			}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				continue
+			}
+			files = append(files, rules.SourceFile{Path: path, Content: string(content)})
+		}
+
+		for _, rule := range projectRules {
+			severity := t.Severity(rule)
+			if severity == rules.SeverityFix {
+				changed, applied := rule.ApplyProject(rules.ProjectFiles{Project: p, Files: files})
+				if applied {
+					for _, f := range changed {
+						if t.isExcluded(rule.Name(), f.Path) {
+							continue
+						}
+						r := resultFor(f.Path)
+						content, err := t.withoutBaselined(rule, f.Path, r.NewContent, f.Content)
+						if err != nil {
+							r.Failures = append(r.Failures, RuleFailure{Rule: rule.Name(), Err: err})
+						}
+						if f.Content = content; f.Content == r.NewContent {
+							continue
+						}
+						if strings.EqualFold(filepath.Ext(f.Path), ".cs") {
+							if err := syntax.Validate(r.NewContent, f.Content); err != nil {
+								r.Failures = append(r.Failures, RuleFailure{Rule: rule.Name(), Err: invalidCode(err)})
+								continue
+							}
+							files = upsertSource(files, f)
+						}
+
+						r.NewContent = f.Content
+						r.Changed = r.NewContent != r.File.Content
+						r.AppliedRules = append(r.AppliedRules, rules.RuleResult{
+							RuleName:    rule.Name(),
+							Applied:     true,
+							Description: rule.Description(),
+						})
+					}
+				}
+			}
+
+			if analyzer, ok := rule.(rules.ProjectAnalyzer); ok {
+				if findings := analyzer.AnalyzeProject(rules.ProjectFiles{Project: p, Files: files}); len(findings) > 0 {
+					r := resultFor(p.Path)
+					r.Findings = append(r.Findings, withSeverity(findings, severity)...)
+				}
+			}
+		}
+	}
 	return results
 }
+
+func upsertSource(files []rules.SourceFile, f rules.SourceFile) []rules.SourceFile {
+	for i := range files {
+		if absPath(files[i].Path) == absPath(f.Path) {
+			files[i].Content = f.Content
+			return files
+		}
+	}
+	return append(files, f)
+}
+
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
+
+func (t *Transformer) AddContext(files []scanner.FileInfo) {
+	t.context = append(t.context, files...)
+}
+
+func (t *Transformer) Prepare(files []scanner.FileInfo) {
+	sources := make([]rules.SourceFile, 0, len(files)+len(t.context))
+	seen := make(map[string]bool, len(files))
+	for _, f := range files {
+		sources = append(sources, rules.SourceFile{Path: f.Path, Content: f.Content})
+		seen[absPath(f.Path)] = true
+	}
+	for _, f := range t.context {
+		if !seen[absPath(f.Path)] {
+			sources = append(sources, rules.SourceFile{Path: f.Path, Content: f.Content})
+		}
+	}
+	for _, rule := range t.rules {
+		if p, ok := rule.(rules.Preparer); ok {
+			p.Prepare(sources)
+		}
+		if _, ok := rule.(rules.ContextRule); ok && t.symbols == nil {
+			t.symbols = symbols.New()
+			for _, f := range sources {
+				t.symbols.Add(f.Path, f.Content)
+			}
+		}
+	}
+}
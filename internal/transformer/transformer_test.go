@@ -0,0 +1,81 @@
+package transformer
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/andiq123/sharpify/internal/rules"
+	"github.com/andiq123/sharpify/internal/scanner"
+)
+
+type fakeRule struct {
+	name  string
+	apply func(string) string
+}
+
+func (r fakeRule) Name() string        { return r.name }
+func (r fakeRule) Description() string { return r.name }
+
+func (r fakeRule) Apply(content string) (string, bool) {
+	out := r.apply(content)
+	return out, out != content
+}
+
+func TestTransformRollsBackInvalidRewrites(t *testing.T) {
+	input := "class A\n{\n    void M() { Run(); }\n}\n"
+	breaks := fakeRule{"drop-brace", func(s string) string { return strings.Replace(s, "}", "", 1) }}
+	renames := fakeRule{"rename", func(s string) string { return strings.ReplaceAll(s, "Run", "Execute") }}
+	tr := New([]rules.Rule{breaks, renames})
+	tr.SetSeverities(map[string]rules.Severity{"drop-brace": rules.SeverityFix, "rename": rules.SeverityFix})
+
+	result := tr.Transform(scanner.FileInfo{Path: "A.cs", Content: input})
+	if len(result.Failures) != 1 || result.Failures[0].Rule != "drop-brace" {
+		t.Fatalf("failures = %v, want one for drop-brace", result.Failures)
+	}
+	if want := strings.ReplaceAll(input, "Run", "Execute"); result.NewContent != want {
+		t.Fatalf("content = %q, want %q", result.NewContent, want)
+	}
+	if len(result.AppliedRules) != 1 || result.AppliedRules[0].RuleName != "rename" {
+		t.Fatalf("applied = %v, want only rename", result.AppliedRules)
+	}
+}
+
+var docLine = regexp.MustCompile(`(?m)^\s*///.*\n`)
+
+func TestTransformReportsRulesThatDropMaskedText(t *testing.T) {
+	input := "class A\n{\n    /// <summary>Id.</summary>\n    public int Id { get; set; }\n}\n"
+	stripDocs := fakeRule{"strip-docs", func(s string) string { return docLine.ReplaceAllString(s, "") }}
+	tr := New([]rules.Rule{stripDocs})
+	tr.SetSeverities(map[string]rules.Severity{"strip-docs": rules.SeverityFix})
+
+	result := tr.Transform(scanner.FileInfo{Path: "A.cs", Content: input})
+	if result.Changed || result.NewContent != input {
+		t.Fatalf("content changed:\n%s", result.NewContent)
+	}
+	if len(result.Failures) != 1 || !errors.Is(result.Failures[0].Err, errMaskedText) {
+		t.Fatalf("failures = %v, want one masked-text failure", result.Failures)
+	}
+}
+
+type fakeAnalyzer struct {
+	fakeRule
+}
+
+func (r fakeAnalyzer) Analyze(content string) []rules.Finding {
+	return []rules.Finding{{Rule: r.name, Line: 1, Message: "left as is"}}
+}
+
+func TestTransformReportsFindingsAtConfiguredSeverity(t *testing.T) {
+	for _, severity := range []rules.Severity{rules.SeverityFix, rules.SeveritySuggest, rules.SeverityInfo} {
+		analyzer := fakeAnalyzer{fakeRule{"analyze", func(s string) string { return s }}}
+		tr := New([]rules.Rule{analyzer})
+		tr.SetSeverities(map[string]rules.Severity{"analyze": severity})
+
+		result := tr.Transform(scanner.FileInfo{Path: "A.cs", Content: "class A { }\n"})
+		if len(result.Findings) != 1 || result.Findings[0].Severity != severity {
+			t.Errorf("severity %v: findings = %v", severity, result.Findings)
+		}
+	}
+}
@@ -0,0 +1,632 @@
+package ui
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/andiq123/sharpify/internal/diff"
+	"github.com/andiq123/sharpify/internal/review"
+	"github.com/andiq123/sharpify/internal/rules"
+	"github.com/andiq123/sharpify/internal/scanner"
+	"github.com/andiq123/sharpify/internal/syntax"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+type pane int
+
+const (
+	filesPane pane = iota
+	rulesPane
+	diffPane
+)
+
+const (
+	sidebarWidth      = 36
+	explorerThreshold = 8
+	diffContext       = 3
+)
+
+var (
+	keywordStyle = lipgloss.NewStyle().Foreground(Primary)
+	stringStyle  = lipgloss.NewStyle().Foreground(Warning)
+	commentStyle = lipgloss.NewStyle().Foreground(Muted).Italic(true)
+	numberStyle  = lipgloss.NewStyle().Foreground(Accent)
+	gutterRemove = lipgloss.NewStyle().Foreground(Error).Bold(true)
+	gutterAdd    = lipgloss.NewStyle().Foreground(Secondary).Bold(true)
+	focusStyle   = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(Primary)
+	blurStyle    = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(Muted)
+	cursorStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF")).Background(Primary)
+)
+
+type explorerChange struct {
+	review.Change
+	project  bool
+	included bool
+}
+
+type fileEntry struct {
+	rel string
+	dir bool
+}
+
+type explorer struct {
+	changes []*explorerChange
+	rules   []string
+
+	focus      pane
+	fileCursor int
+	ruleCursor int
+	change     int
+	filter     string
+	scroll     int
+
+	width  int
+	height int
+
+	apply     bool
+	highlight map[string][]string
+}
+
+func newExplorer(changes []*explorerChange) *explorer {
+	e := &explorer{changes: changes, highlight: make(map[string][]string), width: 120, height: 40}
+	seen := make(map[string]bool)
+	for _, c := range changes {
+		if !seen[c.Rule] {
+			seen[c.Rule] = true
+			e.rules = append(e.rules, c.Rule)
+		}
+	}
+	sort.Strings(e.rules)
+	return e
+}
+
+func (e *explorer) Init() tea.Cmd {
+	return nil
+}
+
+func (e *explorer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		e.width, e.height = msg.Width, msg.Height
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			return e, tea.Quit
+		case "a":
+			e.apply = true
+			return e, tea.Quit
+		case "tab":
+			e.focus = (e.focus + 1) % 3
+		case "shift+tab":
+			e.focus = (e.focus + 2) % 3
+		case "up", "k":
+			e.move(-1)
+		case "down", "j":
+			e.move(1)
+		case "n":
+			e.focus = diffPane
+			e.move(1)
+		case "p":
+			e.focus = diffPane
+			e.move(-1)
+		case " ", "x":
+			e.toggle()
+		case "enter":
+			if e.focus == rulesPane {
+				e.filter = ""
+				if e.ruleCursor > 0 {
+					e.filter = e.rules[e.ruleCursor-1]
+				}
+				e.fileCursor, e.change, e.scroll = 0, 0, 0
+			} else if e.focus == filesPane {
+				e.focus = diffPane
+			}
+		case "f":
+			e.filter = ""
+			e.ruleCursor = 0
+		}
+	}
+	return e, nil
+}
+
+func (e *explorer) move(delta int) {
+	switch e.focus {
+	case filesPane:
+		e.fileCursor = clamp(e.fileCursor+delta, 0, len(e.fileNames())-1)
+		e.change, e.scroll = 0, 0
+	case rulesPane:
+		e.ruleCursor = clamp(e.ruleCursor+delta, 0, len(e.rules))
+	case diffPane:
+		e.change = clamp(e.change+delta, 0, len(e.fileChanges())-1)
+	}
+}
+
+func (e *explorer) toggle() {
+	var targets []*explorerChange
+	switch e.focus {
+	case filesPane:
+		targets = e.fileChanges()
+	case rulesPane:
+		for _, c := range e.changes {
+			if e.ruleCursor == 0 || c.Rule == e.rules[e.ruleCursor-1] {
+				targets = append(targets, c)
+			}
+		}
+	case diffPane:
+		if changes := e.fileChanges(); e.change < len(changes) {
+			targets = []*explorerChange{changes[e.change]}
+		}
+	}
+	if len(targets) == 1 && targets[0].project {
+		targets = e.projectChanges(targets[0].Rule)
+	}
+
+	include := false
+	for _, c := range targets {
+		if !c.included {
+			include = true
+			break
+		}
+	}
+	for _, c := range targets {
+		if c.project {
+			for _, p := range e.projectChanges(c.Rule) {
+				p.included = include
+			}
+		}
+		c.included = include
+	}
+}
+
+func (e *explorer) projectChanges(rule string) []*explorerChange {
+	var result []*explorerChange
+	for _, c := range e.changes {
+		if c.project && c.Rule == rule {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+func (e *explorer) visible(c *explorerChange) bool {
+	return e.filter == "" || c.Rule == e.filter
+}
+
+func (e *explorer) fileNames() []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, c := range e.changes {
+		if e.visible(c) && !seen[c.RelPath] {
+			seen[c.RelPath] = true
+			names = append(names, c.RelPath)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (e *explorer) currentFile() string {
+	names := e.fileNames()
+	if len(names) == 0 {
+		return ""
+	}
+	return names[clamp(e.fileCursor, 0, len(names)-1)]
+}
+
+func (e *explorer) fileChanges() []*explorerChange {
+	file := e.currentFile()
+	var result []*explorerChange
+	for _, c := range e.changes {
+		if c.RelPath == file && e.visible(c) {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+func (e *explorer) counts(match func(*explorerChange) bool) (int, int) {
+	included, total := 0, 0
+	for _, c := range e.changes {
+		if match(c) {
+			total++
+			if c.included {
+				included++
+			}
+		}
+	}
+	return included, total
+}
+
+func (e *explorer) View() string {
+	included, total := e.counts(func(*explorerChange) bool { return true })
+	header := HeaderStyle.Render("⚡ Sharpify review") + "  " +
+		SubtitleStyle.Render(fmt.Sprintf("%d of %d change(s) included in %d file(s)", included, total, len(e.fileNames())))
+	if e.filter != "" {
+		header += "  " + AccentStyle.Render("filter: "+e.filter)
+	}
+	help := SubtitleStyle.Render("tab pane • ↑/↓ move • space toggle • n/p next/prev change • enter filter rule • f clear filter • a apply • q quit")
+
+	bodyHeight := e.height - 4
+	if bodyHeight < 6 {
+		bodyHeight = 6
+	}
+	filesHeight := bodyHeight / 2
+	rulesHeight := bodyHeight - filesHeight
+	diffWidth := e.width - sidebarWidth - 4
+	if diffWidth < 20 {
+		diffWidth = 20
+	}
+
+	left := lipgloss.JoinVertical(lipgloss.Left,
+		e.box(filesPane, e.viewFiles(filesHeight-2), sidebarWidth, filesHeight-2),
+		e.box(rulesPane, e.viewRules(rulesHeight-2), sidebarWidth, rulesHeight-2),
+	)
+	right := e.box(diffPane, e.viewDiff(diffWidth, bodyHeight-2), diffWidth, bodyHeight-2)
+	return lipgloss.JoinVertical(lipgloss.Left, header, lipgloss.JoinHorizontal(lipgloss.Top, left, right), help)
+}
+
+func (e *explorer) box(p pane, content string, width, height int) string {
+	style := blurStyle
+	if e.focus == p {
+		style = focusStyle
+	}
+	return style.Width(width).Height(height).MaxHeight(height + 2).Render(content)
+}
+
+func (e *explorer) viewFiles(height int) string {
+	var entries []fileEntry
+	lastDir := ""
+	for _, name := range e.fileNames() {
+		if dir := path.Dir(name); dir != "." && dir != lastDir {
+			entries = append(entries, fileEntry{rel: dir, dir: true})
+			lastDir = dir
+		}
+		entries = append(entries, fileEntry{rel: name})
+	}
+
+	var lines []string
+	cursorLine := 0
+	current := e.currentFile()
+	for _, entry := range entries {
+		if entry.dir {
+			lines = append(lines, SubtitleStyle.Render(ansi.Truncate("▾ "+entry.rel+"/", sidebarWidth-1, "…")))
+			continue
+		}
+		rel := entry.rel
+		included, total := e.counts(func(c *explorerChange) bool { return c.RelPath == rel && e.visible(c) })
+		indent := ""
+		if path.Dir(rel) != "." {
+			indent = "  "
+		}
+		label := fmt.Sprintf("%s%s %s", indent, checkbox(included, total), path.Base(rel))
+		count := fmt.Sprintf(" %d/%d", included, total)
+		label = ansi.Truncate(label, sidebarWidth-1-len(count), "…") + SubtitleStyle.Render(count)
+		if rel == current {
+			cursorLine = len(lines)
+			label = cursorStyle.Render("›") + label
+		} else {
+			label = " " + label
+		}
+		lines = append(lines, label)
+	}
+	if len(lines) == 0 {
+		lines = append(lines, SubtitleStyle.Render("No changes"))
+	}
+	return strings.Join(window(lines, cursorLine, height), "\n")
+}
+
+func (e *explorer) viewRules(height int) string {
+	included, total := e.counts(func(*explorerChange) bool { return true })
+	lines := []string{e.ruleLine(0, "All rules", e.filter == "", included, total)}
+	for i, rule := range e.rules {
+		rule := rule
+		included, total := e.counts(func(c *explorerChange) bool { return c.Rule == rule })
+		lines = append(lines, e.ruleLine(i+1, rule, e.filter == rule, included, total))
+	}
+	return strings.Join(window(lines, e.ruleCursor, height), "\n")
+}
+
+func (e *explorer) ruleLine(index int, name string, active bool, included, total int) string {
+	count := fmt.Sprintf(" %d/%d", included, total)
+	label := checkbox(included, total) + " " + name
+	if active {
+		label = AccentStyle.Render(label)
+	}
+	label = ansi.Truncate(label, sidebarWidth-1-len(count), "…") + SubtitleStyle.Render(count)
+	if index == e.ruleCursor && e.focus == rulesPane {
+		return cursorStyle.Render("›") + label
+	}
+	return " " + label
+}
+
+func (e *explorer) viewDiff(width, height int) string {
+	changes := e.fileChanges()
+	if len(changes) == 0 {
+		return SubtitleStyle.Render("Nothing to show")
+	}
+	e.change = clamp(e.change, 0, len(changes)-1)
+
+	column := (width - 5) / 2
+	var lines []string
+	current := 0
+	for i, c := range changes {
+		if i == e.change {
+			current = len(lines)
+		}
+		mark := "[x]"
+		if !c.included {
+			mark = "[ ]"
+		}
+		title := fmt.Sprintf("%s %s  %s", mark, c.Rule, c.Description)
+		if i == e.change {
+			title = cursorStyle.Render(ansi.Truncate(title, width-1, "…"))
+		} else {
+			title = RuleStyle.Render(ansi.Truncate(title, width-1, "…"))
+		}
+		lines = append(lines, title)
+		if c.project {
+			lines = append(lines, SubtitleStyle.Render("  Changes several files at once and is applied as a whole."), "")
+			continue
+		}
+		lines = append(lines, e.sideBySide(c, column)...)
+		lines = append(lines, "")
+	}
+
+	if current < e.scroll {
+		e.scroll = current
+	}
+	if current >= e.scroll+height-diffContext {
+		e.scroll = current
+	}
+	end := e.scroll + height
+	if end > len(lines) {
+		end = len(lines)
+	}
+	return strings.Join(lines[e.scroll:end], "\n")
+}
+
+func (e *explorer) sideBySide(c *explorerChange, column int) []string {
+	before := e.highlighted(c.Before)
+	after := e.highlighted(c.Hunk.NewText())
+
+	start := c.Hunk.OldStart - diffContext
+	if start < 0 {
+		start = 0
+	}
+	end := c.Hunk.OldEnd + diffContext
+	if end > len(before) {
+		end = len(before)
+	}
+
+	var rows []string
+	row := func(left, right string, lg, rg string) {
+		rows = append(rows, lg+cell(left, column)+" │"+rg+cell(right, column))
+	}
+	for i := start; i < c.Hunk.OldStart; i++ {
+		row(before[i], before[i], " ", " ")
+	}
+	for i := 0; i < len(c.Hunk.Old) || i < len(c.Hunk.New); i++ {
+		left, right := "", ""
+		lg, rg := " ", " "
+		if i < len(c.Hunk.Old) {
+			left, lg = before[c.Hunk.OldStart+i], gutterRemove.Render("-")
+		}
+		if i < len(after) {
+			right, rg = after[i], gutterAdd.Render("+")
+		}
+		row(left, right, lg, rg)
+	}
+	for i := c.Hunk.OldEnd; i < end; i++ {
+		row(before[i], before[i], " ", " ")
+	}
+	return rows
+}
+
+func (e *explorer) highlighted(content string) []string {
+	if lines, ok := e.highlight[content]; ok {
+		return lines
+	}
+	lines := highlightCSharp(content)
+	e.highlight[content] = lines
+	return lines
+}
+
+func (e *explorer) decisions() (map[string]bool, map[string]bool) {
+	included := make(map[string]bool)
+	includedProjects := make(map[string]bool)
+	for _, c := range e.changes {
+		if !c.included {
+			continue
+		}
+		if c.project {
+			includedProjects[c.Rule] = true
+			continue
+		}
+		included[changeKey(c.RelPath, c.Rule, c.Hunk)] = true
+	}
+	return included, includedProjects
+}
+
+func onlyIncluded(included map[string]bool) func(review.Change) review.Decision {
+	return func(c review.Change) review.Decision {
+		if included[changeKey(c.RelPath, c.Rule, c.Hunk)] {
+			return review.Accept
+		}
+		return review.Reject
+	}
+}
+
+func changeKey(rel, rule string, h diff.Hunk) string {
+	return rel + "\x00" + rule + "\x00" + review.Fingerprint(h)
+}
+
+func highlightCSharp(content string) []string {
+	var b strings.Builder
+	last := 0
+	for _, t := range syntax.Lex(content) {
+		b.WriteString(content[last:t.Pos])
+		style, ok := tokenStyle(t.Kind)
+		if !ok {
+			b.WriteString(t.Text)
+		} else {
+			for i, part := range strings.Split(t.Text, "\n") {
+				if i > 0 {
+					b.WriteString("\n")
+				}
+				b.WriteString(style.Render(part))
+			}
+		}
+		last = t.End
+	}
+	b.WriteString(content[last:])
+
+	lines := strings.Split(strings.ReplaceAll(b.String(), "\t", "    "), "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], "\r")
+	}
+	return lines
+}
+
+func tokenStyle(kind syntax.Kind) (lipgloss.Style, bool) {
+	switch kind {
+	case syntax.Keyword:
+		return keywordStyle, true
+	case syntax.String, syntax.Char:
+		return stringStyle, true
+	case syntax.Comment, syntax.Preprocessor:
+		return commentStyle, true
+	case syntax.Number:
+		return numberStyle, true
+	}
+	return lipgloss.Style{}, false
+}
+
+func cell(s string, width int) string {
+	s = ansi.Truncate(s, width, "…")
+	if pad := width - ansi.StringWidth(s); pad > 0 {
+		s += strings.Repeat(" ", pad)
+	}
+	return s
+}
+
+func checkbox(included, total int) string {
+	switch {
+	case included == 0:
+		return "○"
+	case included == total:
+		return SuccessStyle.Render("●")
+	default:
+		return WarningStyle.Render("◐")
+	}
+}
+
+func window(lines []string, cursor, height int) []string {
+	if height <= 0 || len(lines) <= height {
+		return lines
+	}
+	start := cursor - height/2
+	if start < 0 {
+		start = 0
+	}
+	if start+height > len(lines) {
+		start = len(lines) - height
+	}
+	return lines[start : start+height]
+}
+
+func clamp(v, lo, hi int) int {
+	if v > hi {
+		v = hi
+	}
+	if v < lo {
+		v = lo
+	}
+	return v
+}
+
+func (im *InteractiveMode) exploreChanges(workingDir string, files []scanner.FileInfo, enabledRules []rules.Rule) {
+	reviewer, baseline := im.newReviewer(workingDir, enabledRules)
+
+	var changes []*explorerChange
+	reviewer.Prompt = func(c review.Change) review.Decision {
+		changes = append(changes, &explorerChange{Change: c, included: true})
+		return review.Accept
+	}
+	reviewer.ConfirmProject = func(rule rules.Rule, paths []string) review.Decision {
+		for _, rel := range paths {
+			changes = append(changes, &explorerChange{
+				Change: review.Change{
+					Path:        filepath.Join(reviewer.Root, rel),
+					RelPath:     rel,
+					Rule:        rule.Name(),
+					Description: rule.Description(),
+				},
+				project:  true,
+				included: true,
+			})
+		}
+		return review.Accept
+	}
+	summary := reviewer.Review(files)
+	if len(changes) == 0 {
+		if summary.Suppressed > 0 {
+			fmt.Printf("  %s change(s) skipped by baseline\n", SubtitleStyle.Render(fmt.Sprint(summary.Suppressed)))
+		}
+		fmt.Println(SubtitleStyle.Render("No changes applied"))
+		return
+	}
+
+	model := newExplorer(changes)
+	if _, err := tea.NewProgram(model, tea.WithAltScreen()).Run(); err != nil {
+		fmt.Println(Fail("Explorer failed: " + err.Error()))
+		return
+	}
+	if !model.apply {
+		fmt.Println(SubtitleStyle.Render("Cancelled"))
+		return
+	}
+
+	included, includedProjects := model.decisions()
+	reviewer = &review.Reviewer{
+		Root:       reviewer.Root,
+		Rules:      enabledRules,
+		Severities: im.config.Severity,
+		Baseline:   baseline,
+	}
+	reviewer.Prompt = onlyIncluded(included)
+	reviewer.ConfirmProject = func(rule rules.Rule, _ []string) review.Decision {
+		if includedProjects[rule.Name()] {
+			return review.Accept
+		}
+		return review.Reject
+	}
+	summary = reviewer.Review(files)
+
+	fmt.Printf("  %s accepted, %s rejected\n",
+		SuccessStyle.Render(fmt.Sprint(summary.Accepted)),
+		WarningStyle.Render(fmt.Sprint(summary.Rejected)))
+
+	var updates []review.File
+	for _, f := range summary.Files {
+		rel, _ := filepath.Rel(workingDir, f.Path)
+		for _, failure := range f.Failures {
+			fmt.Println(Warn(fmt.Sprintf("%s: %s was rolled back (%v)", rel, failure.Rule, failure.Err)))
+		}
+		if f.Changed() {
+			updates = append(updates, f)
+		}
+	}
+	if len(updates) == 0 {
+		fmt.Println(SubtitleStyle.Render("No changes applied"))
+		return
+	}
+	im.writeChanges(workingDir, updates, true)
+}
@@ -0,0 +1,108 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/andiq123/sharpify/internal/diff"
+	"github.com/andiq123/sharpify/internal/review"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func testExplorer() *explorer {
+	before := "class A\n{\n    var x = 1;\n    var y = 2;\n}\n"
+	hunks := diff.Hunks(before, "class A\n{\n    int x = 1;\n    int y = 2;\n}\n")
+	change := func(rel, rule string, h diff.Hunk) *explorerChange {
+		return &explorerChange{Change: review.Change{RelPath: rel, Rule: rule, Hunk: h, Before: before}, included: true}
+	}
+	return newExplorer([]*explorerChange{
+		change("src/A.cs", "explicit-type", hunks[0]),
+		change("src/B.cs", "explicit-type", hunks[0]),
+		change("src/B.cs", "other-rule", hunks[0]),
+		{Change: review.Change{RelPath: "src/A.cs", Rule: "project-rule"}, project: true, included: true},
+		{Change: review.Change{RelPath: "src/B.cs", Rule: "project-rule"}, project: true, included: true},
+	})
+}
+
+func press(e *explorer, keys ...string) {
+	for _, k := range keys {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		switch k {
+		case "tab":
+			msg = tea.KeyMsg{Type: tea.KeyTab}
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		}
+		e.Update(msg)
+	}
+}
+
+func TestExplorerTogglesChangesFilesAndRules(t *testing.T) {
+	e := testExplorer()
+
+	press(e, "tab", "tab", " ")
+	if e.changes[0].included {
+		t.Fatal("space in the diff pane should exclude the selected change")
+	}
+	included, projects := e.decisions()
+	if len(included) != 2 || !projects["project-rule"] {
+		t.Fatalf("decisions = %v, %v", included, projects)
+	}
+	accept := onlyIncluded(included)
+	if accept(e.changes[0].Change) != review.Reject || accept(e.changes[1].Change) != review.Accept {
+		t.Fatal("only changes left included should be accepted")
+	}
+
+	press(e, "tab", "tab", "down", " ")
+	if !e.changes[0].included || !e.changes[1].included {
+		t.Fatal("toggling a partially included rule should include all of its changes")
+	}
+	press(e, " ")
+	if e.changes[0].included || e.changes[1].included || !e.changes[2].included {
+		t.Fatal("toggling the rule again should exclude only its changes")
+	}
+}
+
+func TestExplorerProjectRulesToggleTogether(t *testing.T) {
+	e := testExplorer()
+	press(e, "tab", "tab", "n", "n", " ")
+	if e.changes[3].included || e.changes[4].included {
+		t.Fatal("excluding a project rule change should exclude it in every file")
+	}
+	_, projects := e.decisions()
+	if projects["project-rule"] {
+		t.Fatalf("project decisions = %v", projects)
+	}
+}
+
+func TestExplorerRejectsChangesItNeverShowed(t *testing.T) {
+	e := testExplorer()
+	included, _ := e.decisions()
+
+	before := "class A\n{\n    var x = 1;\n}\n"
+	shifted := diff.Hunks(before, "class A\n{\n    int x = 1;\n}\n")[0]
+	c := review.Change{RelPath: "src/A.cs", Rule: "explicit-type", Hunk: shifted, Before: before}
+	if onlyIncluded(included)(c) != review.Reject {
+		t.Fatal("a hunk whose fingerprint changed since the explorer ran should be rejected")
+	}
+}
+
+func TestExplorerRuleFilter(t *testing.T) {
+	e := testExplorer()
+	press(e, "tab", "down", "down", "enter")
+	if e.filter != "other-rule" {
+		t.Fatalf("filter = %q", e.filter)
+	}
+	if files := e.fileNames(); len(files) != 1 || files[0] != "src/B.cs" {
+		t.Fatalf("files = %v", files)
+	}
+	if view := e.View(); !strings.Contains(view, "filter: other-rule") {
+		t.Fatal("view should show the active filter")
+	}
+	press(e, "a")
+	if !e.apply {
+		t.Fatal("a should request apply")
+	}
+}
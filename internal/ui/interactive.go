@@ -6,14 +6,18 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strings"
 	"syscall"
 
 	"github.com/andiq123/sharpify/internal/backup"
 	"github.com/andiq123/sharpify/internal/config"
+	"github.com/andiq123/sharpify/internal/diff"
+	"github.com/andiq123/sharpify/internal/review"
 	"github.com/andiq123/sharpify/internal/rules"
 	"github.com/andiq123/sharpify/internal/scanner"
 	"github.com/andiq123/sharpify/internal/transformer"
 	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
 )
 
 type InteractiveMode struct {
@@ -21,6 +25,7 @@ type InteractiveMode struct {
 	scanner   *scanner.CSharpScanner
 	config    *config.Config
 	backupMgr *backup.Manager
+	plugins   config.Plugins
 	ctx       context.Context
 	cancel    context.CancelFunc
 }
@@ -31,8 +36,19 @@ func NewInteractive() *InteractiveMode {
 
 	ctx, cancel := context.WithCancel(context.Background())
 
+	registry := transformer.NewRegistry()
+	cfg.RegisterPresets(registry)
+	if err := cfg.ConfigureRules(registry); err != nil {
+		fmt.Println(Warn(err.Error()))
+	}
+	plugins, err := cfg.StartPlugins(registry)
+	if err != nil {
+		fmt.Println(Warn(err.Error()))
+	}
+
 	im := &InteractiveMode{
-		registry: transformer.NewRegistry(),
+		registry: registry,
+		plugins:  plugins,
 		scanner:  scanner.New(),
 		config:   cfg,
 		ctx:      ctx,
@@ -56,6 +72,7 @@ func (im *InteractiveMode) setupSignalHandler() {
 }
 
 func (im *InteractiveMode) Run() error {
+	defer im.plugins.Close()
 	fmt.Println(Banner())
 	fmt.Println()
 
@@ -87,10 +104,12 @@ func (im *InteractiveMode) Run() error {
 
 func (im *InteractiveMode) printStatusBar() {
 	version := im.config.GetVersion()
-	ruleCount := len(im.registry.GetByVersion(version, im.config.SafeOnly))
+	ruleCount := len(im.quickRules())
 
 	mode := SuccessStyle.Render("safe")
-	if !im.config.SafeOnly {
+	if im.config.Preset != "" {
+		mode = AccentStyle.Render(im.config.Preset)
+	} else if !im.config.SafeOnly {
 		mode = WarningStyle.Render("all rules")
 	}
 
@@ -130,6 +149,7 @@ func (im *InteractiveMode) runQuick() {
 	if path == "" {
 		return
 	}
+	im.loadProjectRules(path)
 
 	files, err := im.scanFiles(path)
 	if err != nil || len(files) == 0 {
@@ -137,8 +157,7 @@ func (im *InteractiveMode) runQuick() {
 	}
 
 	
-	allRules := im.registry.GetByVersion(im.config.GetVersion(), im.config.SafeOnly)
-	enabledRules := im.config.GetEnabledRules(allRules)
+	enabledRules := im.quickRules()
 
 	if len(enabledRules) == 0 {
 		fmt.Println(Warn("No rules enabled. Go to Settings to configure."))
@@ -150,11 +169,34 @@ func (im *InteractiveMode) runQuick() {
 	im.applyTransformations(path, files, enabledRules)
 }
 
+func (im *InteractiveMode) quickRules() []rules.Rule {
+	version := im.config.GetVersion()
+	if im.config.Preset == "" {
+		return im.config.GetEnabledRules(im.registry.GetByVersion(version, im.config.SafeOnly))
+	}
+
+	presetRules, err := im.registry.ResolvePreset(im.config.Preset)
+	if err != nil {
+		fmt.Println(Warn(err.Error()))
+		return nil
+	}
+
+	result := make([]rules.Rule, 0, len(presetRules))
+	for _, r := range presetRules {
+		if vr, ok := r.(rules.VersionedRule); ok && vr.MinVersion() > version {
+			continue
+		}
+		result = append(result, r)
+	}
+	return im.config.GetEnabledRules(result)
+}
+
 func (im *InteractiveMode) runCustom() {
 	path := im.selectPath()
 	if path == "" {
 		return
 	}
+	im.loadProjectRules(path)
 
 	files, err := im.scanFiles(path)
 	if err != nil || len(files) == 0 {
@@ -173,6 +215,12 @@ func (im *InteractiveMode) runCustom() {
 	im.applyTransformations(path, files, selectedRules)
 }
 
+func (im *InteractiveMode) loadProjectRules(path string) {
+	if err := config.RegisterRules(im.registry, path); err != nil {
+		fmt.Println(Warn(err.Error()))
+	}
+}
+
 func (im *InteractiveMode) selectPath() string {
 	currentDir, _ := os.Getwd()
 
@@ -286,6 +334,10 @@ func (im *InteractiveMode) selectRules(allRules []rules.Rule) []rules.Rule {
 
 func (im *InteractiveMode) applyTransformations(workingDir string, files []scanner.FileInfo, enabledRules []rules.Rule) {
 	t := transformer.New(enabledRules)
+	t.SetSeverities(im.config.Severity)
+	if _, baseline := loadBaseline(workingDir); baseline != nil {
+		t.SetBaseline(baseline)
+	}
 	results := t.TransformAll(files)
 
 	var changed []transformer.Result
@@ -295,6 +347,9 @@ func (im *InteractiveMode) applyTransformations(workingDir string, files []scann
 		}
 	}
 
+	im.printFindings(workingDir, results)
+	im.printFailures(workingDir, results)
+
 	if len(changed) == 0 {
 		fmt.Println()
 		fmt.Println(Success("All files are already up to date!"))
@@ -307,46 +362,274 @@ func (im *InteractiveMode) applyTransformations(workingDir string, files []scann
 	fmt.Println(TitleStyle.Render(fmt.Sprintf("📝 %d file(s) to update", len(changed))))
 	fmt.Println()
 
-	for _, r := range changed {
-		rel, _ := filepath.Rel(workingDir, r.File.Path)
-		fmt.Printf("  %s %s\n", FileStyle.Render("→"), rel)
-		for _, rule := range r.AppliedRules {
-			fmt.Printf("    %s\n", RuleStyle.Render("+ "+rule.Description))
+	large := len(changed) > explorerThreshold
+	if large {
+		fmt.Println(SubtitleStyle.Render("  Too many files to list here; open the explorer to browse them"))
+	} else {
+		for _, r := range changed {
+			rel, _ := filepath.Rel(workingDir, r.File.Path)
+			fmt.Printf("  %s %s\n", FileStyle.Render("→"), rel)
+			for _, rule := range r.AppliedRules {
+				fmt.Printf("    %s\n", RuleStyle.Render("+ "+rule.Description))
+			}
 		}
 	}
 
 	fmt.Println()
 
 	
-	var confirm bool
-	err := huh.NewConfirm().
+	options := []huh.Option[string]{
+		huh.NewOption("✓ Yes, apply all", "apply"),
+		huh.NewOption("🔍 Review each change", "review"),
+		huh.NewOption("🖥  Open full-screen explorer", "explore"),
+		huh.NewOption("✗ Cancel", "cancel"),
+	}
+	if large {
+		options = append([]huh.Option[string]{options[2]}, options[0], options[1], options[3])
+	}
+	var choice string
+	err := huh.NewSelect[string]().
 		Title("Apply these changes?").
-		Affirmative("✓ Yes, apply").
-		Negative("✗ Cancel").
-		Value(&confirm).
+		Options(options...).
+		Value(&choice).
 		Run()
 
-	if err != nil || !confirm {
+	if err != nil || choice == "cancel" {
 		fmt.Println(SubtitleStyle.Render("Cancelled"))
 		return
 	}
+	if choice == "explore" {
+		im.exploreChanges(workingDir, files, enabledRules)
+		return
+	}
+	im.reviewChanges(workingDir, files, enabledRules, choice == "review")
+}
 
-	
-	if im.config.BackupEnabled {
+func (im *InteractiveMode) reviewChanges(workingDir string, files []scanner.FileInfo, enabledRules []rules.Rule, interactive bool) {
+	reviewer, baseline := im.newReviewer(workingDir, enabledRules)
+	reviewer.Prompt = im.promptChange
+	reviewer.ConfirmProject = im.confirmProjectRule
+	if !interactive {
+		reviewer.Prompt = func(review.Change) review.Decision { return review.Accept }
+		reviewer.ConfirmProject = func(rules.Rule, []string) review.Decision { return review.Accept }
+	}
+	summary := reviewer.Review(files)
+
+	if interactive {
+		fmt.Println()
+		fmt.Println(Divider())
+		fmt.Printf("  %s accepted, %s rejected",
+			SuccessStyle.Render(fmt.Sprint(summary.Accepted)),
+			WarningStyle.Render(fmt.Sprint(summary.Rejected)))
+	}
+	if summary.Suppressed > 0 {
+		fmt.Printf("  %s change(s) skipped by baseline", SubtitleStyle.Render(fmt.Sprint(summary.Suppressed)))
+	}
+	fmt.Println()
+
+	if baseline != nil && summary.Remembered > 0 {
+		if err := baseline.Save(); err != nil {
+			fmt.Println(Fail("Failed to save baseline: " + err.Error()))
+		} else {
+			fmt.Println(InfoStyle.Render("📌 Remembered rejections: ") + SubtitleStyle.Render(baseline.Path()))
+		}
+	}
+
+	var updates []review.File
+	for _, f := range summary.Files {
+		if interactive {
+			rel, _ := filepath.Rel(workingDir, f.Path)
+			for _, failure := range f.Failures {
+				fmt.Println(Warn(fmt.Sprintf("%s: %s was rolled back (%v)", rel, failure.Rule, failure.Err)))
+			}
+		}
+		if f.Changed() {
+			updates = append(updates, f)
+		}
+	}
+	if len(updates) == 0 {
+		fmt.Println(SubtitleStyle.Render("No changes applied"))
+		return
+	}
+	im.writeChanges(workingDir, updates, false)
+}
+
+func (im *InteractiveMode) newReviewer(workingDir string, enabledRules []rules.Rule) (*review.Reviewer, *review.Baseline) {
+	root, baseline := loadBaseline(workingDir)
+	return &review.Reviewer{
+		Root:       root,
+		Rules:      enabledRules,
+		Severities: im.config.Severity,
+		Baseline:   baseline,
+	}, baseline
+}
+
+func loadBaseline(workingDir string) (string, *review.Baseline) {
+	root := workingDir
+	if info, err := os.Stat(root); err == nil && !info.IsDir() {
+		root = filepath.Dir(root)
+	}
+	baseline, err := review.OpenBaseline(root)
+	if err != nil {
+		fmt.Println(Warn("Ignoring baseline: " + err.Error()))
+		return root, nil
+	}
+	return baseline.Root(), baseline
+}
+
+func (im *InteractiveMode) promptChange(c review.Change) review.Decision {
+	fmt.Println()
+	fmt.Println(Divider())
+	fmt.Printf("  %s %s  %s\n",
+		FileStyle.Render(c.RelPath),
+		RuleStyle.Render(c.Rule),
+		SubtitleStyle.Render(fmt.Sprintf("change %d/%d", c.Index, c.Count)))
+	fmt.Println(SubtitleStyle.Render("  " + c.Description))
+	fmt.Println()
+	fmt.Print(renderHunk(c))
+	fmt.Println()
+
+	decision := review.Reject
+	err := huh.NewSelect[review.Decision]().
+		Title("Apply this change?").
+		Options(
+			huh.NewOption("y  Accept", review.Accept),
+			huh.NewOption("n  Reject", review.Reject),
+			huh.NewOption("b  Reject and don't propose it again", review.RejectAndRemember),
+			huh.NewOption("a  Accept all remaining changes from "+c.Rule, review.AcceptRule),
+			huh.NewOption("s  Skip the rest of this file", review.SkipFile),
+			huh.NewOption("q  Quit and apply accepted changes", review.Quit),
+		).
+		Value(&decision).
+		Run()
+	if err != nil {
+		return review.Quit
+	}
+	return decision
+}
+
+func (im *InteractiveMode) confirmProjectRule(rule rules.Rule, files []string) review.Decision {
+	fmt.Println()
+	fmt.Println(Divider())
+	fmt.Printf("  %s  %s\n", RuleStyle.Render(rule.Name()), SubtitleStyle.Render(rule.Description()))
+	for _, f := range files {
+		fmt.Printf("    %s %s\n", FileStyle.Render("→"), f)
+	}
+	fmt.Println()
+
+	decision := review.Reject
+	err := huh.NewSelect[review.Decision]().
+		Title(fmt.Sprintf("Apply %s to %d file(s)?", rule.Name(), len(files))).
+		Options(
+			huh.NewOption("y  Accept", review.Accept),
+			huh.NewOption("n  Reject", review.Reject),
+			huh.NewOption("b  Reject and don't propose it again", review.RejectAndRemember),
+			huh.NewOption("q  Quit and apply accepted changes", review.Quit),
+		).
+		Value(&decision).
+		Run()
+	if err != nil {
+		return review.Quit
+	}
+	return decision
+}
+
+func renderHunk(c review.Change) string {
+	const context = 3
+	lines := diff.Lines(c.Before)
+	start := c.Hunk.OldStart - context
+	if start < 0 {
+		start = 0
+	}
+	end := c.Hunk.OldEnd + context
+	if end > len(lines) {
+		end = len(lines)
+	}
+
+	var b strings.Builder
+	line := func(style lipgloss.Style, prefix, text string) {
+		b.WriteString("  " + style.Render(prefix+strings.TrimRight(text, "\r\n")) + "\n")
+	}
+	for _, l := range lines[start:c.Hunk.OldStart] {
+		line(SubtitleStyle, "  ", l)
+	}
+	for _, l := range c.Hunk.Old {
+		line(DiffRemoveStyle, "- ", l)
+	}
+	for _, l := range c.Hunk.New {
+		line(DiffAddStyle, "+ ", l)
+	}
+	for _, l := range lines[c.Hunk.OldEnd:end] {
+		line(SubtitleStyle, "  ", l)
+	}
+	return b.String()
+}
+
+func (im *InteractiveMode) writeChanges(workingDir string, updates []review.File, forceBackup bool) {
+	if im.config.BackupEnabled || forceBackup {
 		im.backupMgr = backup.New(workingDir)
-		for _, r := range changed {
-			_ = im.backupMgr.Backup(r.File.Path, r.File.Content)
+		for _, f := range updates {
+			if _, err := os.Stat(f.Path); os.IsNotExist(err) {
+				_ = im.backupMgr.BackupNew(f.Path)
+				continue
+			}
+			_ = im.backupMgr.Backup(f.Path, f.Original)
 		}
 		fmt.Println(InfoStyle.Render("📦 Backup created: ") + SubtitleStyle.Render(im.backupMgr.BackupDir()))
 	}
 
 	
-	for _, r := range changed {
-		_ = os.WriteFile(r.File.Path, []byte(r.NewContent), 0644)
+	for _, f := range updates {
+		_ = os.WriteFile(f.Path, []byte(f.Content), 0644)
 	}
 
 	fmt.Println()
-	fmt.Println(Success(fmt.Sprintf("Updated %d file(s) successfully!", len(changed))))
+	fmt.Println(Success(fmt.Sprintf("Updated %d file(s) successfully!", len(updates))))
+}
+
+func (im *InteractiveMode) printFindings(workingDir string, results []transformer.Result) {
+	total := 0
+	for _, r := range results {
+		total += len(r.Findings)
+	}
+	if total == 0 {
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(Divider())
+	fmt.Println(TitleStyle.Render(fmt.Sprintf("💡 %d suggestion(s)", total)))
+	fmt.Println()
+
+	for _, r := range results {
+		if len(r.Findings) == 0 {
+			continue
+		}
+		rel, _ := filepath.Rel(workingDir, r.File.Path)
+		fmt.Printf("  %s %s\n", FileStyle.Render("→"), rel)
+		for _, f := range r.Findings {
+			style := InfoStyle
+			if f.Severity != rules.SeverityInfo {
+				style = WarningStyle
+			}
+			fmt.Printf("    %s %s %s\n",
+				style.Render(fmt.Sprintf("%-7s", f.Severity)),
+				SubtitleStyle.Render(fmt.Sprintf("L%d", f.Line)),
+				f.Message)
+		}
+	}
+}
+
+func (im *InteractiveMode) printFailures(workingDir string, results []transformer.Result) {
+	for _, r := range results {
+		if len(r.Failures) == 0 {
+			continue
+		}
+		rel, _ := filepath.Rel(workingDir, r.File.Path)
+		for _, f := range r.Failures {
+			fmt.Println(Warn(fmt.Sprintf("%s: %s was rolled back (%v)", rel, f.Rule, f.Err)))
+		}
+	}
 }
 
 func (im *InteractiveMode) showSettings() {
@@ -355,9 +638,15 @@ func (im *InteractiveMode) showSettings() {
 	fmt.Println()
 
 	var version string
+	var preset string = im.config.Preset
 	var safeOnly bool = im.config.SafeOnly
 	var backupEnabled bool = im.config.BackupEnabled
 
+	presetOptions := []huh.Option[string]{huh.NewOption("None  (use Safe Mode setting)", "")}
+	for _, p := range im.registry.Presets() {
+		presetOptions = append(presetOptions, huh.NewOption(fmt.Sprintf("%-18s %s", p.Name, p.Description), p.Name))
+	}
+
 	form := huh.NewForm(
 		huh.NewGroup(
 			huh.NewSelect[string]().
@@ -374,6 +663,11 @@ func (im *InteractiveMode) showSettings() {
 					huh.NewOption("C# 13  (.NET 9.0+)", "13"),
 				).
 				Value(&version),
+			huh.NewSelect[string]().
+				Title("Preset").
+				Description("Rule preset used by Quick Run").
+				Options(presetOptions...).
+				Value(&preset),
 			huh.NewConfirm().
 				Title("Safe Mode").
 				Description("Only apply safe transformations (recommended)").
@@ -390,6 +684,7 @@ func (im *InteractiveMode) showSettings() {
 	}
 
 	im.config.TargetVersion = version
+	im.config.Preset = preset
 	im.config.SafeOnly = safeOnly
 	im.config.BackupEnabled = backupEnabled
 	_ = im.config.Save()
@@ -444,6 +739,66 @@ func (im *InteractiveMode) showRules() {
 	fmt.Println(SubtitleStyle.Render(fmt.Sprintf("  Total: %d rules", totalRules)))
 	fmt.Println()
 
-	var cont bool
-	_ = huh.NewConfirm().Title("").Affirmative("← Back").Negative("").Value(&cont).Run()
+	options := []huh.Option[string]{huh.NewOption("← Back", "")}
+	for _, r := range im.registry.All() {
+		options = append(options, huh.NewOption(r.Name(), r.Name()))
+	}
+	for {
+		var name string
+		err := huh.NewSelect[string]().
+			Title("Show details for a rule").
+			Options(options...).
+			Value(&name).
+			Run()
+		if err != nil || name == "" {
+			return
+		}
+		if r, ok := im.registry.Get(name); ok {
+			fmt.Println()
+			fmt.Println(ruleDetails(r))
+		}
+	}
+}
+
+func ruleDetails(r rules.Rule) string {
+	var b strings.Builder
+	b.WriteString(RuleStyle.Render(r.Name()) + "\n")
+	b.WriteString(SubtitleStyle.Render(r.Description()) + "\n\n")
+
+	if vr, ok := r.(rules.VersionedRule); ok {
+		v := vr.MinVersion()
+		fmt.Fprintf(&b, "  Requires: %s (%s)\n", v, v.DotNetVersion())
+		if vr.IsSafe() {
+			b.WriteString("  Safety:   " + SuccessStyle.Render("✓ safe") + "\n")
+		} else {
+			b.WriteString("  Safety:   " + WarningStyle.Render("⚠ needs review") + "\n")
+		}
+	}
+
+	doc, ok := rules.DocumentationOf(r)
+	if !ok {
+		return b.String()
+	}
+	if doc.Rationale != "" {
+		b.WriteString("\n" + AccentStyle.Render("Why") + "\n  " + doc.Rationale + "\n")
+	}
+	for _, ex := range doc.Examples {
+		b.WriteString("\n" + AccentStyle.Render("Example") + "\n")
+		for _, line := range strings.Split(strings.TrimRight(ex.Before, "\n"), "\n") {
+			b.WriteString(DiffRemoveStyle.Render("  - "+line) + "\n")
+		}
+		for _, line := range strings.Split(strings.TrimRight(ex.After, "\n"), "\n") {
+			b.WriteString(DiffAddStyle.Render("  + "+line) + "\n")
+		}
+	}
+	if len(doc.Caveats) > 0 {
+		b.WriteString("\n" + AccentStyle.Render("Caveats") + "\n")
+		for _, c := range doc.Caveats {
+			b.WriteString("  " + WarningStyle.Render("⚠") + " " + c + "\n")
+		}
+	}
+	if len(doc.Related) > 0 {
+		b.WriteString("\n" + SubtitleStyle.Render("Related: "+strings.Join(doc.Related, ", ")) + "\n")
+	}
+	return b.String()
 }
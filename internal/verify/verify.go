@@ -0,0 +1,228 @@
+package verify
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+
+	"github.com/andiq123/sharpify/internal/backup"
+	"github.com/andiq123/sharpify/pkg/sharpify"
+)
+
+type TransformFunc func(ruleNames []string, excluded map[string][]string) ([]sharpify.Result, error)
+
+type Verifier struct {
+	Command   string
+	Dir       string
+	Rules     []string
+	Transform TransformFunc
+	Backup    *backup.Manager
+}
+
+type Revert struct {
+	Rule string
+	Path string
+}
+
+type Report struct {
+	Passed         bool
+	BaselineFailed bool
+	Reverted       []Revert
+	Results        []sharpify.Result
+	Output         string
+	Builds         int
+}
+
+func (v *Verifier) Verify(results []sharpify.Result) (Report, error) {
+	report := Report{Results: results}
+	ok, out := v.build(&report)
+	if ok {
+		report.Passed = true
+		return report, nil
+	}
+	report.Output = out
+
+	if err := v.Backup.RestoreAll(); err != nil {
+		return report, err
+	}
+	if ok, _ := v.build(&report); !ok {
+		report.BaselineFailed = true
+		return report, v.write(results)
+	}
+
+	applied := appliedRules(v.Rules, results)
+	excluded := make(map[string][]string)
+	trial := func(ruleNames []string) (bool, []sharpify.Result, error) {
+		results, err := v.trial(ruleNames, excluded)
+		if err != nil {
+			return false, nil, err
+		}
+		ok, out := v.build(&report)
+		if !ok {
+			report.Output = out
+		}
+		return ok, results, nil
+	}
+
+	var trialErr error
+	passes := func(names []string) bool {
+		if trialErr != nil {
+			return false
+		}
+		ok, _, err := trial(names)
+		trialErr = err
+		return ok
+	}
+
+	culprits := bisect(nil, applied, passes)
+	if trialErr != nil {
+		return report, trialErr
+	}
+	good := without(applied, culprits)
+
+	for _, rule := range culprits {
+		files := touchedFiles(rule, results)
+		withRule := append(append([]string(nil), good...), rule)
+		bad := bisect(nil, files, func(subset []string) bool {
+			excluded[rule] = without(files, subset)
+			return passes(withRule)
+		})
+		if trialErr != nil {
+			return report, trialErr
+		}
+		excluded[rule] = bad
+		if len(bad) < len(files) {
+			good = withRule
+		}
+		for _, path := range bad {
+			report.Reverted = append(report.Reverted, Revert{Rule: rule, Path: path})
+		}
+	}
+
+	ok, final, err := trial(good)
+	if err != nil {
+		return report, err
+	}
+	if !ok {
+		report.Results = nil
+		report.Reverted = nil
+		return report, v.Backup.RestoreAll()
+	}
+	report.Passed = true
+	report.Results = final
+	return report, nil
+}
+
+func (v *Verifier) trial(ruleNames []string, excluded map[string][]string) ([]sharpify.Result, error) {
+	if err := v.Backup.RestoreAll(); err != nil {
+		return nil, err
+	}
+	results, err := v.Transform(ruleNames, excluded)
+	if err != nil {
+		return nil, err
+	}
+	return results, v.write(results)
+}
+
+func (v *Verifier) write(results []sharpify.Result) error {
+	for _, r := range results {
+		if !r.Changed {
+			continue
+		}
+		if err := v.track(r.Path); err != nil {
+			return err
+		}
+		if err := os.WriteFile(r.Path, []byte(r.Content), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", r.Path, err)
+		}
+	}
+	return nil
+}
+
+func (v *Verifier) track(path string) error {
+	if v.Backup.Tracks(path) {
+		return nil
+	}
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return v.Backup.BackupNew(path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+	return v.Backup.Backup(path, string(content))
+}
+
+func (v *Verifier) build(report *Report) (bool, string) {
+	report.Builds++
+	var c *exec.Cmd
+	if runtime.GOOS == "windows" {
+		c = exec.Command("cmd", "/C", v.Command)
+	} else {
+		c = exec.Command("sh", "-c", v.Command)
+	}
+	c.Dir = v.Dir
+	out, err := c.CombinedOutput()
+	return err == nil, string(out)
+}
+
+func bisect(good, candidates []string, passes func([]string) bool) []string {
+	if len(candidates) == 0 || passes(append(append([]string(nil), good...), candidates...)) {
+		return nil
+	}
+	if len(candidates) == 1 {
+		return candidates
+	}
+
+	mid := len(candidates) / 2
+	left := bisect(good, candidates[:mid], passes)
+	good = append(append([]string(nil), good...), without(candidates[:mid], left)...)
+	right := bisect(good, candidates[mid:], passes)
+	return append(left, right...)
+}
+
+func appliedRules(ruleNames []string, results []sharpify.Result) []string {
+	used := make(map[string]bool)
+	for _, r := range results {
+		for _, applied := range r.Applied {
+			used[applied.Name] = true
+		}
+	}
+	var names []string
+	for _, name := range ruleNames {
+		if used[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func touchedFiles(rule string, results []sharpify.Result) []string {
+	var files []string
+	for _, r := range results {
+		for _, applied := range r.Applied {
+			if applied.Name == rule {
+				files = append(files, r.Path)
+				break
+			}
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+func without(list, remove []string) []string {
+	skip := make(map[string]bool, len(remove))
+	for _, s := range remove {
+		skip[s] = true
+	}
+	var kept []string
+	for _, s := range list {
+		if !skip[s] {
+			kept = append(kept, s)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,206 @@
+package verify
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/andiq123/sharpify/internal/backup"
+	"github.com/andiq123/sharpify/pkg/sharpify"
+)
+
+type appendRule struct {
+	name   string
+	suffix string
+	only   string
+}
+
+func (r appendRule) Name() string        { return r.name }
+func (r appendRule) Description() string { return r.name }
+
+func (r appendRule) Apply(content string) (string, bool) {
+	if r.only != "" && !strings.Contains(content, r.only) {
+		return content, false
+	}
+	return content + r.suffix, true
+}
+
+const stubBuild = `if grep -rl BROKEN --include=*.cs . >/dev/null; then echo "error CS1002: ; expected"; exit 1; fi`
+
+func setup(t *testing.T, files map[string]string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("stub build script needs sh")
+	}
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func apply(t *testing.T, dir string, ruleList []sharpify.Rule) (*Verifier, []sharpify.Result) {
+	t.Helper()
+	registry := sharpify.NewRegistry()
+	var names []string
+	for _, r := range ruleList {
+		registry.Register(r)
+		names = append(names, r.Name())
+	}
+	transform := func(ruleNames []string, excluded map[string][]string) ([]sharpify.Result, error) {
+		if len(ruleNames) == 0 {
+			return nil, nil
+		}
+		return sharpify.TransformDir(context.Background(), dir, sharpify.Options{Registry: registry, Rules: ruleNames, Exclude: excluded})
+	}
+
+	results, err := transform(names, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := backup.New(t.TempDir())
+	for _, r := range results {
+		if !r.Changed {
+			continue
+		}
+		if err := m.Backup(r.Path, r.Original); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(r.Path, []byte(r.Content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return &Verifier{Command: stubBuild, Dir: dir, Rules: names, Transform: transform, Backup: m}, results
+}
+
+func read(t *testing.T, dir, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestVerifyRevertsOnlyBreakingRewrite(t *testing.T) {
+	dir := setup(t, map[string]string{
+		"A.cs": "class A {}\n",
+		"B.cs": "class B { Bad b; }\n",
+		"C.cs": "class C { Bad c; }\n",
+	})
+	v, results := apply(t, dir, []sharpify.Rule{
+		appendRule{name: "good", suffix: "// good\n"},
+		appendRule{name: "breaks", suffix: "BROKEN\n", only: "class B"},
+		appendRule{name: "harmless", suffix: "// harmless\n", only: "Bad"},
+	})
+
+	report, err := v.Verify(results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Passed {
+		t.Fatalf("expected build to pass after reverting, output: %s", report.Output)
+	}
+	if len(report.Reverted) != 1 || report.Reverted[0].Rule != "breaks" || filepath.Base(report.Reverted[0].Path) != "B.cs" {
+		t.Fatalf("unexpected reverts: %+v", report.Reverted)
+	}
+
+	if got := read(t, dir, "B.cs"); strings.Contains(got, "BROKEN") || !strings.Contains(got, "// good") || !strings.Contains(got, "// harmless") {
+		t.Errorf("B.cs = %q", got)
+	}
+	if got := read(t, dir, "A.cs"); got != "class A {}\n// good\n" {
+		t.Errorf("A.cs = %q", got)
+	}
+}
+
+func TestVerifyPassesWithoutBisecting(t *testing.T) {
+	dir := setup(t, map[string]string{"A.cs": "class A {}\n"})
+	v, results := apply(t, dir, []sharpify.Rule{appendRule{name: "good", suffix: "// good\n"}})
+
+	report, err := v.Verify(results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Passed || report.Builds != 1 || len(report.Reverted) != 0 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+}
+
+func TestVerifyKeepsChangesWhenBaselineFails(t *testing.T) {
+	dir := setup(t, map[string]string{"A.cs": "class A {} // BROKEN\n"})
+	v, results := apply(t, dir, []sharpify.Rule{appendRule{name: "good", suffix: "// good\n"}})
+
+	report, err := v.Verify(results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.BaselineFailed {
+		t.Fatalf("expected baseline failure: %+v", report)
+	}
+	if got := read(t, dir, "A.cs"); !strings.HasSuffix(got, "// good\n") {
+		t.Errorf("changes were not kept: %q", got)
+	}
+}
+
+func TestVerifyTracksFilesOnlyTouchedByTrials(t *testing.T) {
+	dir := setup(t, map[string]string{
+		"A.cs": "class A {}\n",
+		"B.cs": "class B {}\n",
+		"C.cs": "class C {}\n",
+	})
+	result := func(name, rule, content string) sharpify.Result {
+		return sharpify.Result{
+			Path:    filepath.Join(dir, name),
+			Content: content,
+			Changed: true,
+			Applied: []sharpify.AppliedRule{{Name: rule}},
+		}
+	}
+	transform := func(ruleNames []string, excluded map[string][]string) ([]sharpify.Result, error) {
+		rules := strings.Join(ruleNames, ",")
+		var results []sharpify.Result
+		if strings.Contains(rules, "breaks") && len(excluded["breaks"]) == 0 {
+			results = append(results, result("A.cs", "breaks", "class A {} // BROKEN\n"))
+		}
+		if strings.Contains(rules, "touch") {
+			results = append(results, result("B.cs", "touch", "class B {} // touched\n"))
+			if !strings.Contains(rules, "breaks") {
+				results = append(results, result("C.cs", "touch", "class C {} // touched\n"))
+				results = append(results, result("GlobalUsings.cs", "touch", "global using System;\n"))
+			}
+		}
+		return results, nil
+	}
+
+	results, _ := transform([]string{"breaks", "touch"}, nil)
+	m := backup.New(t.TempDir())
+	for _, r := range results {
+		if err := m.Backup(r.Path, read(t, dir, filepath.Base(r.Path))); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(r.Path, []byte(r.Content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	v := &Verifier{Command: stubBuild, Dir: dir, Rules: []string{"breaks", "touch"}, Transform: transform, Backup: m}
+	if report, err := v.Verify(results); err != nil || !report.Passed {
+		t.Fatalf("Verify = %+v, %v", report, err)
+	}
+
+	if err := m.RestoreAll(); err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[string]string{"A.cs": "class A {}\n", "B.cs": "class B {}\n", "C.cs": "class C {}\n"} {
+		if got := read(t, dir, name); got != want {
+			t.Errorf("%s = %q after restore, want %q", name, got, want)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "GlobalUsings.cs")); !os.IsNotExist(err) {
+		t.Errorf("GlobalUsings.cs was not removed on restore: %v", err)
+	}
+}
@@ -0,0 +1,195 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/andiq123/sharpify/internal/scanner"
+	"github.com/andiq123/sharpify/pkg/sharpify"
+	"github.com/fsnotify/fsnotify"
+)
+
+const DefaultDebounce = 300 * time.Millisecond
+
+type TransformFunc func(ctx context.Context, path, content string) (sharpify.Result, error)
+
+type Event struct {
+	Path    string
+	Result  sharpify.Result
+	Written bool
+	Err     error
+}
+
+type Watcher struct {
+	Root      string
+	Debounce  time.Duration
+	Apply     bool
+	Transform TransformFunc
+	OnEvent   func(Event)
+
+	scanner *scanner.CSharpScanner
+	fsw     *fsnotify.Watcher
+	written map[string]string
+	ready   chan struct{}
+}
+
+func (w *Watcher) Run(ctx context.Context) error {
+	root, err := filepath.Abs(w.Root)
+	if err != nil {
+		return fmt.Errorf("invalid path: %w", err)
+	}
+	info, err := os.Stat(root)
+	if err != nil {
+		return fmt.Errorf("path not found: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", root)
+	}
+	w.Root = root
+	if w.Debounce <= 0 {
+		w.Debounce = DefaultDebounce
+	}
+	w.scanner = scanner.New()
+	w.written = make(map[string]string)
+
+	w.fsw, err = fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer w.fsw.Close()
+	if _, err := w.addTree(root); err != nil {
+		return err
+	}
+	if w.ready != nil {
+		close(w.ready)
+	}
+
+	pending := make(map[string]bool)
+	timer := time.NewTimer(time.Hour)
+	timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err, ok := <-w.fsw.Errors:
+			if !ok {
+				return nil
+			}
+			w.emit(Event{Err: err})
+		case ev, ok := <-w.fsw.Events:
+			if !ok {
+				return nil
+			}
+			for _, path := range w.changed(ev) {
+				pending[path] = true
+			}
+			if len(pending) > 0 {
+				timer.Reset(w.Debounce)
+			}
+		case <-timer.C:
+			paths := make([]string, 0, len(pending))
+			for path := range pending {
+				paths = append(paths, path)
+			}
+			sort.Strings(paths)
+			pending = make(map[string]bool)
+			for _, path := range paths {
+				w.process(ctx, path)
+			}
+		}
+	}
+}
+
+func (w *Watcher) changed(ev fsnotify.Event) []string {
+	if !ev.Has(fsnotify.Create) && !ev.Has(fsnotify.Write) {
+		return nil
+	}
+	info, err := os.Stat(ev.Name)
+	if err != nil {
+		return nil
+	}
+	if info.IsDir() {
+		if w.scanner.SkipDir(info.Name()) {
+			return nil
+		}
+		files, err := w.addTree(ev.Name)
+		if err != nil {
+			w.emit(Event{Path: ev.Name, Err: err})
+		}
+		return files
+	}
+	if !w.scanner.Includes(w.Root, ev.Name) {
+		return nil
+	}
+	return []string{ev.Name}
+}
+
+func (w *Watcher) addTree(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if !d.IsDir() {
+			if w.scanner.Includes(w.Root, path) {
+				files = append(files, path)
+			}
+			return nil
+		}
+		if path != w.Root && w.scanner.SkipDir(d.Name()) {
+			return filepath.SkipDir
+		}
+		return w.fsw.Add(path)
+	})
+	return files, err
+}
+
+func (w *Watcher) process(ctx context.Context, path string) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			w.emit(Event{Path: path, Err: err})
+		}
+		return
+	}
+	content := string(data)
+	if last, ok := w.written[path]; ok {
+		delete(w.written, path)
+		if last == content {
+			return
+		}
+	}
+
+	result, err := w.Transform(ctx, path, content)
+	if err != nil {
+		w.emit(Event{Path: path, Err: err})
+		return
+	}
+	event := Event{Path: path, Result: result}
+	if w.Apply && result.Changed {
+		if err := os.WriteFile(path, []byte(result.Content), 0644); err != nil {
+			event.Err = fmt.Errorf("failed to write: %w", err)
+		} else {
+			w.written[path] = result.Content
+			event.Written = true
+		}
+	}
+	if result.Changed || len(result.Diagnostics) > 0 || event.Err != nil {
+		w.emit(event)
+	}
+}
+
+func (w *Watcher) emit(e Event) {
+	if w.OnEvent != nil {
+		w.OnEvent(e)
+	}
+}
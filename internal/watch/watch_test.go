@@ -0,0 +1,141 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andiq123/sharpify/pkg/sharpify"
+)
+
+func start(t *testing.T, apply bool) (string, chan Event) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "bin"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	registry := sharpify.NewRegistry()
+	registry.Register(sharpify.NewRule("foo-to-bar", "Rename Foo to Bar", sharpify.CSharp6, true, func(content string) (string, bool) {
+		return strings.ReplaceAll(content, "Foo", "Bar"), strings.Contains(content, "Foo")
+	}))
+	opts := sharpify.Options{Registry: registry, Rules: []string{"foo-to-bar"}}
+
+	events := make(chan Event, 16)
+	w := &Watcher{
+		Root:     dir,
+		Debounce: 50 * time.Millisecond,
+		Apply:    apply,
+		Transform: func(ctx context.Context, path, content string) (sharpify.Result, error) {
+			opts := opts
+			opts.Filename = path
+			return sharpify.Transform(ctx, content, opts)
+		},
+		OnEvent: func(e Event) { events <- e },
+		ready:   make(chan struct{}),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- w.Run(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Error(err)
+		}
+	})
+	select {
+	case <-w.ready:
+	case err := <-done:
+		t.Fatal(err)
+	}
+	return dir, events
+}
+
+func write(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func next(t *testing.T, events chan Event) Event {
+	t.Helper()
+	select {
+	case e := <-events:
+		if e.Err != nil {
+			t.Fatal(e.Err)
+		}
+		return e
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event")
+	}
+	return Event{}
+}
+
+func quiet(t *testing.T, events chan Event) {
+	t.Helper()
+	select {
+	case e := <-events:
+		t.Fatalf("unexpected event: %+v", e)
+	case <-time.After(300 * time.Millisecond):
+	}
+}
+
+func TestWatchApplies(t *testing.T) {
+	dir, events := start(t, true)
+	path := filepath.Join(dir, "A.cs")
+
+	write(t, path, "class Foo {}\n")
+	e := next(t, events)
+	if e.Path != path || !e.Written {
+		t.Fatalf("unexpected event: %+v", e)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "class Bar {}\n" {
+		t.Errorf("A.cs = %q", data)
+	}
+	quiet(t, events)
+
+	write(t, filepath.Join(dir, "bin", "Gen.cs"), "class Foo {}\n")
+	write(t, filepath.Join(dir, "notes.txt"), "Foo")
+	quiet(t, events)
+}
+
+func TestWatchAtomicSave(t *testing.T) {
+	dir, events := start(t, false)
+	path := filepath.Join(dir, "A.cs")
+	write(t, path, "class A {}\n")
+	quiet(t, events)
+
+	tmp := filepath.Join(dir, ".A.cs.tmp")
+	write(t, tmp, "class Foo {}\n")
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatal(err)
+	}
+	e := next(t, events)
+	if e.Path != path || e.Written || !e.Result.Changed {
+		t.Fatalf("unexpected event: %+v", e)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "class Foo {}\n" {
+		t.Errorf("report mode modified the file: %q", data)
+	}
+}
+
+func TestWatchNewDirectory(t *testing.T) {
+	dir, events := start(t, true)
+	sub := filepath.Join(dir, "src", "Models")
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	write(t, filepath.Join(sub, "M.cs"), "class Foo {}\n")
+
+	if e := next(t, events); filepath.Base(e.Path) != "M.cs" || !e.Written {
+		t.Fatalf("unexpected event: %+v", e)
+	}
+}
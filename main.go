@@ -8,6 +8,7 @@ import (
 
 	"github.com/andiq123/sharpify/cmd"
 	"github.com/andiq123/sharpify/internal/ui"
+	"github.com/andiq123/sharpify/pkg/sharpify"
 )
 
 var version = "1.0.0"
@@ -17,9 +18,14 @@ func main() {
 	batch := flag.Bool("batch", false, "Run in batch mode (non-interactive)")
 	batchShort := flag.Bool("b", false, "Run in batch mode (non-interactive)")
 	dryRun := flag.Bool("dry-run", false, "Preview changes without modifying files")
-	rulesFlag := flag.String("rules", "", "Comma-separated list of rules to apply (default: all)")
+	rulesFlag := flag.String("rules", "", "Comma-separated list of rules to apply (overrides --preset)")
+	presetFlag := flag.String("preset", "", "Rule preset to apply (default: safe)")
 	verbose := flag.Bool("verbose", false, "Show detailed output")
+	verifyBuild := flag.String("verify-build", "", "Build command to run after applying changes; failing rewrites are reverted")
+	format := flag.String("format", "text", "Batch output format: text, json or html")
+	output := flag.String("output", "", "Write the json or html report to this file instead of stdout")
 	listRules := flag.Bool("list-rules", false, "List all available transformation rules")
+	listPresets := flag.Bool("list-presets", false, "List all available rule presets")
 	showVersion := flag.Bool("version", false, "Show version")
 	help := flag.Bool("help", false, "Show help")
 
@@ -38,13 +44,59 @@ func main() {
 	}
 
 	if *listRules {
-		cmd.ListRules()
+		if err := cmd.ListRules(os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *listPresets {
+		if err := cmd.ListPresets(os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if flag.NArg() > 0 && flag.Arg(0) == "fix" && !*batch && !*batchShort {
+		runFix(flag.Args()[1:], *rulesFlag, *presetFlag)
+		return
+	}
+
+	if flag.NArg() > 0 && flag.Arg(0) == "watch" && !*batch && !*batchShort {
+		runWatch(flag.Args()[1:], *rulesFlag, *presetFlag)
+		return
+	}
+
+	if flag.NArg() > 0 && flag.Arg(0) == "stats" && !*batch && !*batchShort {
+		runStats(flag.Args()[1:], *rulesFlag, *presetFlag)
+		return
+	}
+
+	if flag.NArg() > 0 && flag.Arg(0) == "trend" && !*batch && !*batchShort {
+		runTrend(flag.Args()[1:])
+		return
+	}
+
+	if flag.NArg() > 0 && flag.Arg(0) == "explain" && !*batch && !*batchShort {
+		runExplain(flag.Args()[1:])
+		return
+	}
+
+	if flag.NArg() > 0 && flag.Arg(0) == "lsp" && !*batch && !*batchShort {
+		flag.CommandLine.Parse(flag.Args()[1:])
+		cfg := cmd.Config{Rules: splitRules(*rulesFlag), Preset: *presetFlag}
+		if err := cmd.ServeLSP(cfg, version); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
 		return
 	}
 
 	
-	if *batch || *batchShort {
-		runBatch(dryRun, rulesFlag, verbose)
+	if *batch || *batchShort || *format != "text" {
+		runBatch(dryRun, rulesFlag, presetFlag, verbose, verifyBuild, format, output)
 		return
 	}
 
@@ -56,25 +108,22 @@ func main() {
 	}
 }
 
-func runBatch(dryRun *bool, rulesFlag *string, verbose *bool) {
+func runBatch(dryRun *bool, rulesFlag *string, presetFlag *string, verbose *bool, verifyBuild *string, format *string, output *string) {
 	path := "."
 	if flag.NArg() > 0 {
 		path = flag.Arg(0)
 	}
 
-	var rules []string
-	if *rulesFlag != "" {
-		rules = strings.Split(*rulesFlag, ",")
-		for i := range rules {
-			rules[i] = strings.TrimSpace(rules[i])
-		}
-	}
-
 	cfg := cmd.Config{
 		Path:    path,
 		DryRun:  *dryRun,
-		Rules:   rules,
+		Rules:   splitRules(*rulesFlag),
+		Preset:  *presetFlag,
 		Verbose: *verbose,
+
+		VerifyBuild: *verifyBuild,
+		Format:      *format,
+		Output:      *output,
 	}
 
 	if err := cmd.Run(cfg); err != nil {
@@ -83,6 +132,126 @@ func runBatch(dryRun *bool, rulesFlag *string, verbose *bool) {
 	}
 }
 
+func runFix(args []string, rulesFlag, presetFlag string) {
+	fs := flag.NewFlagSet("fix", flag.ExitOnError)
+	stdin := fs.Bool("stdin", false, "Read C# source from stdin and write the result to stdout")
+	filename := fs.String("stdin-filename", "", "Path used to resolve config and the project for stdin input")
+	rules := fs.String("rules", rulesFlag, "Comma-separated list of rules to apply (overrides --preset)")
+	preset := fs.String("preset", presetFlag, "Rule preset to apply (default: safe)")
+	dryRun := fs.Bool("dry-run", false, "Preview changes without modifying files")
+	verbose := fs.Bool("verbose", false, "Show detailed output")
+	fs.Parse(args)
+
+	cfg := cmd.Config{
+		Path:    *filename,
+		DryRun:  *dryRun,
+		Rules:   splitRules(*rules),
+		Preset:  *preset,
+		Verbose: *verbose,
+	}
+
+	if !*stdin {
+		cfg.Path = "."
+		if fs.NArg() > 0 {
+			cfg.Path = fs.Arg(0)
+		}
+		if err := cmd.Run(cfg); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	changed, err := cmd.Fix(cfg, os.Stdin, os.Stdout, os.Stderr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if changed {
+		os.Exit(cmd.ExitChanged)
+	}
+}
+
+func runWatch(args []string, rulesFlag, presetFlag string) {
+	fs := flag.NewFlagSet("watch", flag.ExitOnError)
+	apply := fs.Bool("apply", false, "Apply safe rules to saved files instead of only reporting")
+	rules := fs.String("rules", rulesFlag, "Comma-separated list of rules to apply (overrides --preset)")
+	preset := fs.String("preset", presetFlag, "Rule preset to apply (default: safe)")
+	fs.Parse(args)
+
+	cfg := cmd.Config{
+		Path:   ".",
+		Rules:  splitRules(*rules),
+		Preset: *preset,
+	}
+	if fs.NArg() > 0 {
+		cfg.Path = fs.Arg(0)
+	}
+	if err := cmd.Watch(cfg, *apply); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func runStats(args []string, rulesFlag, presetFlag string) {
+	fs := flag.NewFlagSet("stats", flag.ExitOnError)
+	format := fs.String("format", "text", "Output format: text, json or markdown")
+	noHistory := fs.Bool("no-history", false, "Don't append this run to .sharpify/history.jsonl")
+	rules := fs.String("rules", rulesFlag, "Comma-separated list of rules to count (default: all rules)")
+	preset := fs.String("preset", presetFlag, "Rule preset to count (default: all rules)")
+	fs.Parse(args)
+
+	cfg := cmd.Config{
+		Path:   ".",
+		Rules:  splitRules(*rules),
+		Preset: *preset,
+	}
+	if fs.NArg() > 0 {
+		cfg.Path = fs.Arg(0)
+	}
+	if err := cmd.Stats(cfg, *format, !*noHistory, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func runTrend(args []string) {
+	fs := flag.NewFlagSet("trend", flag.ExitOnError)
+	limit := fs.Int("limit", 20, "Number of most recent snapshots to show")
+	fs.Parse(args)
+
+	cfg := cmd.Config{Path: "."}
+	if fs.NArg() > 0 {
+		cfg.Path = fs.Arg(0)
+	}
+	if err := cmd.Trend(cfg, *limit, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func runExplain(args []string) {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: sharpify explain <rule>")
+		os.Exit(1)
+	}
+	if err := cmd.Explain(args[0], os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func splitRules(flagValue string) []string {
+	var rules []string
+	if flagValue != "" {
+		rules = strings.Split(flagValue, ",")
+		for i := range rules {
+			rules[i] = strings.TrimSpace(rules[i])
+		}
+	}
+	return rules
+}
+
 func printUsage() {
 	fmt.Println(`sharpify - Modernize legacy C# code
 
@@ -90,6 +259,13 @@ Usage:
   sharpify [flags] [path]
   sharpify                             # Run interactive mode (default)
   sharpify -b ./src                    # Batch mode on ./src
+  sharpify fix --stdin --stdin-filename src/File.cs
+                                       # Filter stdin to stdout (exit 2 if changed)
+  sharpify watch [--apply] ./src       # Report (or fix) files as they are saved
+  sharpify stats [--format json] ./src # Modernization score and legacy-pattern counts
+  sharpify trend ./src                 # Metrics over time from .sharpify/history.jsonl
+  sharpify explain <rule>              # Rationale, examples and caveats for a rule
+  sharpify lsp                         # Language server on stdin/stdout
 
 Arguments:
   path    Path to C# file or directory (default: current directory)
@@ -97,9 +273,15 @@ Arguments:
 Flags:
   -b, --batch      Run in batch mode (non-interactive)
   --dry-run        Preview changes without modifying files
-  --rules          Comma-separated list of rules to apply (default: all)
+  --preset         Rule preset to apply (default: safe)
+  --rules          Comma-separated list of rules to apply (overrides --preset)
   --verbose        Show detailed output
+  --verify-build   Build command to run after applying changes (e.g. "dotnet build");
+                   rewrites that break it are found by bisection and reverted
+  --format         Batch output: text (default), json or html
+  --output         Write the json or html report to a file instead of stdout
   --list-rules     List all available transformation rules
+  --list-presets   List all available rule presets
   --version        Show version
   --help           Show this help
 
@@ -107,17 +289,23 @@ Examples:
   sharpify                             # Interactive mode (default)
   sharpify -b .                        # Batch: improve all C# files
   sharpify -b --dry-run ./src          # Preview changes
+  sharpify -b --preset recommended ./src
   sharpify -b --rules file-scoped-namespace,pattern-matching ./MyProject
+  sharpify -b --verify-build "dotnet build" ./MyProject
+  sharpify --dry-run --format html --output report.html ./src
 
-Available Rules:
-  file-scoped-namespace    Convert to file-scoped namespaces (C# 10+)
-  var-pattern              Use var for obvious type declarations
-  target-typed-new         Use target-typed new (C# 9+)
-  null-coalescing          Use ?? and ??= operators
-  expression-body          Use expression-bodied members (C# 6+)
-  string-interpolation     Use string interpolation (C# 6+)
-  pattern-matching         Use pattern matching (C# 7+)
-  collection-expression    Use collection expressions (C# 12+)
-  index-range              Use ^index and ranges (C# 8+)`)
+Presets:
+  safe                 Every safe rule (default)
+  recommended          Safe rules plus well-understood rewrites
+  aggressive           Every rule, including ones that need review
+  csharp10-upgrade     Safe rules available up to C# 10
+  performance          Allocation and enumeration improvements
+  readability          Shorter, clearer code
+
+Available Rules:`)
+	for _, r := range sharpify.NewRegistry().Rules() {
+		fmt.Printf("  %-28s %s\n", r.Name, r.Description)
+	}
 	fmt.Println()
+	fmt.Println("Run `sharpify explain <rule>` for details on a rule.")
 }
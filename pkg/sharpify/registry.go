@@ -0,0 +1,182 @@
+package sharpify
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/andiq123/sharpify/internal/config"
+	"github.com/andiq123/sharpify/internal/rules"
+	"github.com/andiq123/sharpify/internal/transformer"
+)
+
+type Rule = rules.Rule
+
+type VersionedRule = rules.VersionedRule
+
+type CSharpVersion = rules.CSharpVersion
+
+const (
+	CSharp6  = rules.CSharp6
+	CSharp7  = rules.CSharp7
+	CSharp8  = rules.CSharp8
+	CSharp9  = rules.CSharp9
+	CSharp10 = rules.CSharp10
+	CSharp11 = rules.CSharp11
+	CSharp12 = rules.CSharp12
+	CSharp13 = rules.CSharp13
+)
+
+type Severity = rules.Severity
+
+const (
+	SeverityFix     = rules.SeverityFix
+	SeveritySuggest = rules.SeveritySuggest
+	SeverityInfo    = rules.SeverityInfo
+)
+
+type Documentation = rules.Documentation
+
+type Example = rules.Example
+
+type DocumentedRule = rules.DocumentedRule
+
+func DocumentationOf(rule Rule) (Documentation, bool) {
+	return rules.DocumentationOf(rule)
+}
+
+type Preset = transformer.Preset
+
+const DefaultPreset = transformer.DefaultPreset
+
+type RuleInfo struct {
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	MinVersion  CSharpVersion `json:"minVersion"`
+	Safe        bool          `json:"safe"`
+	Severity    Severity      `json:"severity"`
+}
+
+func InfoOf(rule Rule) RuleInfo {
+	info := RuleInfo{
+		Name:        rule.Name(),
+		Description: rule.Description(),
+		Severity:    rules.DefaultSeverityOf(rule),
+	}
+	if vr, ok := rule.(VersionedRule); ok {
+		info.MinVersion = vr.MinVersion()
+		info.Safe = vr.IsSafe()
+	}
+	return info
+}
+
+type Registry struct {
+	inner *transformer.RuleRegistry
+}
+
+func NewRegistry() *Registry {
+	return &Registry{inner: transformer.NewRegistry()}
+}
+
+func (r *Registry) Register(rule Rule) error {
+	return r.inner.Register(rule)
+}
+
+func (r *Registry) Replace(rule Rule) {
+	r.inner.Replace(rule)
+}
+
+func (r *Registry) Get(name string) (Rule, bool) {
+	return r.inner.Get(name)
+}
+
+func (r *Registry) Rules() []RuleInfo {
+	names := r.inner.Names()
+	result := make([]RuleInfo, 0, len(names))
+	for _, name := range names {
+		rule, _ := r.inner.Get(name)
+		result = append(result, InfoOf(rule))
+	}
+	return result
+}
+
+func (r *Registry) Configure(name string, options json.RawMessage) error {
+	return r.inner.Configure(name, options)
+}
+
+func (r *Registry) RegisterPreset(p Preset) {
+	r.inner.RegisterPreset(p)
+}
+
+func (r *Registry) Presets() []Preset {
+	return r.inner.Presets()
+}
+
+func (r *Registry) ResolvePreset(name string) ([]Rule, error) {
+	return r.inner.ResolvePreset(name)
+}
+
+func (r *Registry) LoadRules(dir string) error {
+	loaded, err := config.LoadRules(dir)
+	if err != nil {
+		return err
+	}
+	for _, rule := range loaded {
+		if err := r.Register(rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Registry) selectRules(opts Options) ([]Rule, error) {
+	if len(opts.Rules) > 0 {
+		for _, name := range opts.Rules {
+			if _, ok := r.inner.Get(name); !ok {
+				return nil, fmt.Errorf("unknown rule %q", name)
+			}
+		}
+		return r.inner.GetByNames(opts.Rules), nil
+	}
+
+	preset := opts.Preset
+	if preset == "" {
+		preset = DefaultPreset
+	}
+	selected, err := r.inner.ResolvePreset(preset)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Version == 0 {
+		return selected, nil
+	}
+	result := make([]Rule, 0, len(selected))
+	for _, rule := range selected {
+		if vr, ok := rule.(VersionedRule); ok && vr.MinVersion() > opts.Version {
+			continue
+		}
+		result = append(result, rule)
+	}
+	return result, nil
+}
+
+func NewTemplateRule(name, description string, minVersion CSharpVersion, safe bool, match, replace string) (Rule, error) {
+	return rules.NewTemplateRule(name, description, minVersion, safe, match, replace)
+}
+
+type funcRule struct {
+	name        string
+	description string
+	minVersion  CSharpVersion
+	safe        bool
+	apply       func(content string) (string, bool)
+}
+
+func NewRule(name, description string, minVersion CSharpVersion, safe bool, apply func(content string) (string, bool)) Rule {
+	return &funcRule{name: name, description: description, minVersion: minVersion, safe: safe, apply: apply}
+}
+
+func (r *funcRule) Name() string                        { return r.name }
+func (r *funcRule) Description() string                 { return r.description }
+func (r *funcRule) MinVersion() CSharpVersion           { return r.minVersion }
+func (r *funcRule) IsSafe() bool                        { return r.safe }
+func (r *funcRule) Apply(content string) (string, bool) { return r.apply(content) }
@@ -0,0 +1,309 @@
+package sharpify
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/andiq123/sharpify/internal/project"
+	"github.com/andiq123/sharpify/internal/review"
+	"github.com/andiq123/sharpify/internal/rules"
+	"github.com/andiq123/sharpify/internal/scanner"
+	"github.com/andiq123/sharpify/internal/transformer"
+)
+
+type Options struct {
+	Registry *Registry
+	Rules    []string
+	Preset   string
+	Version  CSharpVersion
+	Severity map[string]Severity
+	Exclude  map[string][]string
+	Filename string
+}
+
+type AppliedRule struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type DiagnosticKind int
+
+const (
+	DiagnosticFinding DiagnosticKind = iota
+	DiagnosticFailure
+)
+
+func (k DiagnosticKind) String() string {
+	if k == DiagnosticFailure {
+		return "failure"
+	}
+	return "finding"
+}
+
+func (k DiagnosticKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+func (k *DiagnosticKind) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "finding":
+		*k = DiagnosticFinding
+	case "failure":
+		*k = DiagnosticFailure
+	default:
+		return fmt.Errorf("unknown diagnostic kind %q", text)
+	}
+	return nil
+}
+
+type Diagnostic struct {
+	Kind     DiagnosticKind `json:"kind"`
+	Rule     string         `json:"rule"`
+	Severity Severity       `json:"severity"`
+	Line     int            `json:"line,omitempty"`
+	Message  string         `json:"message"`
+}
+
+type Result struct {
+	Path        string        `json:"path"`
+	Original    string        `json:"-"`
+	Content     string        `json:"-"`
+	Scanned     bool          `json:"-"`
+	Changed     bool          `json:"changed"`
+	Applied     []AppliedRule `json:"applied,omitempty"`
+	Diagnostics []Diagnostic  `json:"diagnostics,omitempty"`
+}
+
+func (r Result) Findings() []Diagnostic {
+	return r.diagnostics(DiagnosticFinding)
+}
+
+func (r Result) Failures() []Diagnostic {
+	return r.diagnostics(DiagnosticFailure)
+}
+
+func (r Result) diagnostics(kind DiagnosticKind) []Diagnostic {
+	var result []Diagnostic
+	for _, d := range r.Diagnostics {
+		if d.Kind == kind {
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+func SelectRules(opts Options) ([]Rule, error) {
+	return opts.registry().selectRules(opts)
+}
+
+func Transform(ctx context.Context, source string, opts Options) (Result, error) {
+	results, err := run(ctx, []scanner.FileInfo{{Path: opts.Filename, Content: source}}, opts, false, opts.Filename)
+	if err != nil {
+		return Result{}, err
+	}
+	return results[0], nil
+}
+
+func TransformFS(ctx context.Context, fsys fs.FS, opts Options) ([]Result, error) {
+	files, err := scanner.New().ScanFS(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return run(ctx, files, opts, false, "")
+}
+
+func TransformDir(ctx context.Context, path string, opts Options) ([]Result, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path: %w", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("path not found: %w", err)
+	}
+
+	var files []scanner.FileInfo
+	if info.IsDir() {
+		if files, err = scanner.New().Scan(path); err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+	} else {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		files = []scanner.FileInfo{{Path: path, Content: string(content)}}
+	}
+	return run(ctx, files, opts, true, path)
+}
+
+func ProjectVersion(path string) (CSharpVersion, bool) {
+	p := project.NewLocator().Owner(path)
+	if p == nil {
+		return 0, false
+	}
+	return rules.ProjectVersion(p)
+}
+
+func (o Options) registry() *Registry {
+	if o.Registry != nil {
+		return o.Registry
+	}
+	return NewRegistry()
+}
+
+func run(ctx context.Context, files []scanner.FileInfo, opts Options, onDisk bool, root string) ([]Result, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	selected, err := SelectRules(opts)
+	if err != nil {
+		return nil, err
+	}
+	if !onDisk {
+		selected = withoutProjectRules(selected)
+	}
+	var context []scanner.FileInfo
+	if needsProject(selected) {
+		var ok bool
+		if context, ok = projectContext(files, root); !ok && !onDisk {
+			selected = withoutPreparers(selected)
+		}
+	}
+
+	t := transformer.New(selected)
+	t.AddContext(context)
+	t.SetSeverities(opts.Severity)
+	for rule, paths := range opts.Exclude {
+		for _, path := range paths {
+			t.Exclude(rule, path)
+		}
+	}
+	if root != "" {
+		baseline, err := review.FindBaseline(root)
+		if err != nil {
+			return nil, fmt.Errorf("invalid baseline: %w", err)
+		}
+		if baseline != nil {
+			t.SetBaseline(baseline)
+		}
+	}
+
+	raw, err := t.TransformAllContext(ctx, files)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]Result, len(raw))
+	for i, r := range raw {
+		results[i] = newResult(r)
+		results[i].Scanned = i < len(files)
+	}
+	return results, nil
+}
+
+func withoutProjectRules(selected []Rule) []Rule {
+	result := make([]Rule, 0, len(selected))
+	for _, r := range selected {
+		if _, ok := r.(rules.ProjectRule); !ok {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+func withoutPreparers(selected []Rule) []Rule {
+	result := make([]Rule, 0, len(selected))
+	for _, r := range selected {
+		if _, ok := r.(rules.Preparer); !ok {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+func needsProject(selected []Rule) bool {
+	for _, r := range selected {
+		if _, ok := r.(rules.Preparer); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func projectContext(files []scanner.FileInfo, root string) ([]scanner.FileInfo, bool) {
+	if root == "" {
+		return nil, false
+	}
+	given := make(map[string]bool, len(files))
+	for _, f := range files {
+		if abs, err := filepath.Abs(f.Path); err == nil {
+			given[abs] = true
+		}
+	}
+
+	locator := project.NewLocator()
+	seen := make(map[*project.Project]bool)
+	var context []scanner.FileInfo
+	complete := true
+	for _, f := range files {
+		p := locator.Owner(f.Path)
+		if p == nil {
+			complete = false
+			continue
+		}
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		paths, err := p.SourceFiles()
+		if err != nil {
+			complete = false
+			continue
+		}
+		for _, path := range paths {
+			if abs, err := filepath.Abs(path); err == nil && given[abs] {
+				continue
+			}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				complete = false
+				continue
+			}
+			context = append(context, scanner.FileInfo{Path: path, Content: string(content)})
+		}
+	}
+	return context, complete
+}
+
+func newResult(r transformer.Result) Result {
+	result := Result{
+		Path:     r.File.Path,
+		Original: r.File.Content,
+		Content:  r.NewContent,
+		Changed:  r.Changed,
+	}
+	for _, a := range r.AppliedRules {
+		result.Applied = append(result.Applied, AppliedRule{Name: a.RuleName, Description: a.Description})
+	}
+	for _, f := range r.Findings {
+		result.Diagnostics = append(result.Diagnostics, Diagnostic{
+			Kind:     DiagnosticFinding,
+			Rule:     f.Rule,
+			Severity: f.Severity,
+			Line:     f.Line,
+			Message:  f.Message,
+		})
+	}
+	for _, f := range r.Failures {
+		result.Diagnostics = append(result.Diagnostics, Diagnostic{
+			Kind:     DiagnosticFailure,
+			Rule:     f.Rule,
+			Severity: SeverityFix,
+			Message:  f.Err.Error(),
+		})
+	}
+	return result
+}
@@ -0,0 +1,256 @@
+package sharpify
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/andiq123/sharpify/internal/diff"
+	"github.com/andiq123/sharpify/internal/review"
+)
+
+const source = `class A
+{
+    void M(string name)
+    {
+        throw new ArgumentNullException("name");
+    }
+}
+`
+
+func TestTransform(t *testing.T) {
+	got, err := Transform(context.Background(), source, Options{Rules: []string{"nameof-expression"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Changed || !strings.Contains(got.Content, "nameof(name)") {
+		t.Fatalf("unexpected content:\n%s", got.Content)
+	}
+	if got.Original != source {
+		t.Error("original content was not kept")
+	}
+	if len(got.Applied) != 1 || got.Applied[0].Name != "nameof-expression" {
+		t.Errorf("unexpected applied rules: %+v", got.Applied)
+	}
+}
+
+func TestTransformFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"src/A.cs":         {Data: []byte(source)},
+		"src/bin/Gen.cs":   {Data: []byte(source)},
+		"README.md":        {Data: []byte("# readme")},
+		"src/B.cs":         {Data: []byte("class B {}\n")},
+		"src/obj/Debug.cs": {Data: []byte(source)},
+	}
+	results, err := TransformFS(context.Background(), fsys, Options{Rules: []string{"nameof-expression"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	for _, r := range results {
+		if r.Changed != (r.Path == "src/A.cs") {
+			t.Errorf("%s: changed = %v", r.Path, r.Changed)
+		}
+	}
+}
+
+func TestCustomRule(t *testing.T) {
+	registry := NewRegistry()
+	err := registry.Register(NewRule("no-foo", "Rename Foo to Bar", CSharp6, true, func(content string) (string, bool) {
+		return strings.ReplaceAll(content, "Foo", "Bar"), strings.Contains(content, "Foo")
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	template, err := NewTemplateRule("count-any", "Use Any()", CSharp6, true, "$x.Count() > 0", "$x.Any()")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.Register(template); err != nil {
+		t.Fatal(err)
+	}
+
+	var found bool
+	for _, info := range registry.Rules() {
+		if info.Name == "no-foo" {
+			found = info.Safe && info.MinVersion == CSharp6
+		}
+	}
+	if !found {
+		t.Fatal("custom rule not listed")
+	}
+
+	got, err := Transform(context.Background(), "class Foo { bool M() => items.Count() > 0; } // Foo\n", Options{
+		Registry: registry,
+		Rules:    []string{"no-foo", "count-any"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "class Bar { bool M() => items.Any(); } // Foo\n"
+	if got.Content != want {
+		t.Errorf("got %q, want %q", got.Content, want)
+	}
+}
+
+func TestRegisterRejectsDuplicateName(t *testing.T) {
+	registry := NewRegistry()
+	builtin, _ := registry.Get("collection-expression")
+	impostor := NewRule("collection-expression", "Do something else", CSharp6, true, func(content string) (string, bool) {
+		return content, false
+	})
+	if err := registry.Register(impostor); err == nil {
+		t.Fatal("expected an error for a rule named like a built-in")
+	}
+	if got, _ := registry.Get("collection-expression"); got != builtin {
+		t.Error("built-in rule was replaced")
+	}
+
+	registry.Replace(impostor)
+	if got, _ := registry.Get("collection-expression"); got != impostor {
+		t.Error("Replace did not override the built-in rule")
+	}
+}
+
+func TestDiagnostics(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register(NewRule("unbalanced", "Open a brace", CSharp6, true, func(content string) (string, bool) {
+		return content + "{\n", true
+	}))
+
+	got, err := Transform(context.Background(), "class A {}\n", Options{Registry: registry, Rules: []string{"unbalanced"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	failures := got.Failures()
+	if got.Changed || len(failures) != 1 || failures[0].Rule != "unbalanced" || failures[0].Kind != DiagnosticFailure {
+		t.Fatalf("unexpected result: changed=%v diagnostics=%+v", got.Changed, got.Diagnostics)
+	}
+}
+
+func TestSelectRules(t *testing.T) {
+	if _, err := SelectRules(Options{Rules: []string{"does-not-exist"}}); err == nil {
+		t.Error("expected an error for an unknown rule")
+	}
+
+	selected, err := SelectRules(Options{Version: CSharp7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(selected) == 0 {
+		t.Fatal("default preset selected no rules")
+	}
+	for _, r := range selected {
+		if vr, ok := r.(VersionedRule); ok && vr.MinVersion() > CSharp7 {
+			t.Errorf("%s requires %v", r.Name(), vr.MinVersion())
+		}
+	}
+}
+
+func TestTransformCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Transform(ctx, source, Options{}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestTransformHonoursBaseline(t *testing.T) {
+	root := t.TempDir()
+	opts := Options{Rules: []string{"nameof-expression"}, Filename: filepath.Join(root, "src", "A.cs")}
+	first, err := Transform(context.Background(), source, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	baseline, err := review.LoadBaseline(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, h := range diff.Hunks(source, first.Content) {
+		baseline.Add("src/A.cs", "nameof-expression", h)
+	}
+	if err := baseline.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Transform(context.Background(), source, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Changed {
+		t.Errorf("baselined change was applied:\n%s", got.Content)
+	}
+}
+
+const person = `public class Person
+{
+    public string Name { get; set; }
+}
+`
+
+func TestTransformIndexesOwningProject(t *testing.T) {
+	root := t.TempDir()
+	opts := Options{Rules: []string{"init-property"}, Filename: filepath.Join(root, "Person.cs")}
+
+	got, err := Transform(context.Background(), person, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Changed {
+		t.Fatalf("converted without project context:\n%s", got.Content)
+	}
+
+	write := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("App.csproj", `<Project Sdk="Microsoft.NET.Sdk" />`)
+	write("Program.cs", "var p = new Person { Name = \"a\" };\n")
+	if got, err = Transform(context.Background(), person, opts); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(got.Content, "{ get; init; }") {
+		t.Fatalf("expected init setter:\n%s", got.Content)
+	}
+
+	write("Rename.cs", "class Rename { void M(Person p) { p.Name = \"b\"; } }\n")
+	if got, err = Transform(context.Background(), person, opts); err != nil {
+		t.Fatal(err)
+	}
+	if got.Changed {
+		t.Errorf("converted a property written in another file:\n%s", got.Content)
+	}
+}
+
+func TestTransformDirMarksScannedFiles(t *testing.T) {
+	root := t.TempDir()
+	for name, content := range map[string]string{
+		"App.csproj": `<Project Sdk="Microsoft.NET.Sdk" />`,
+		"A.cs":       "using System.Text;\n\nclass A { }\n",
+		"B.cs":       "using System.Text;\n\nclass B { }\n",
+	} {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	results, err := TransformDir(context.Background(), root, Options{Rules: []string{"global-using"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	scanned := map[string]bool{}
+	for _, r := range results {
+		scanned[filepath.Base(r.Path)] = r.Scanned
+	}
+	if !scanned["A.cs"] || !scanned["B.cs"] || scanned["GlobalUsings.cs"] || len(scanned) != 3 {
+		t.Errorf("scanned = %v", scanned)
+	}
+}
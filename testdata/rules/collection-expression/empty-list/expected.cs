@@ -0,0 +1,7 @@
+namespace Demo;
+
+public class Sample
+{
+    private List<string> _names = [];
+    private int[] _ids = [];
+}
@@ -0,0 +1,6 @@
+namespace Demo;
+
+public class Sample
+{
+    private List<string> _names = new List<string>(16);
+}
@@ -0,0 +1,11 @@
+namespace Demo;
+
+public class Sample
+{
+    public event EventHandler Changed;
+
+    protected void OnChanged()
+    {
+        Changed?.Invoke(this, EventArgs.Empty);
+    }
+}
@@ -0,0 +1,12 @@
+namespace Demo;
+
+public class Sample
+{
+    public Action Callback;
+    public Action Fallback;
+
+    public void Run()
+    {
+        if (Callback != null) Fallback();
+    }
+}
@@ -0,0 +1,10 @@
+namespace Demo;
+
+public class Sample
+{
+    public object Reset()
+    {
+        var value = default(int);
+        return value;
+    }
+}
@@ -0,0 +1,10 @@
+namespace Demo;
+
+public class Sample
+{
+    public int Reset()
+    {
+        int value = default(int);
+        return default(int);
+    }
+}
@@ -0,0 +1,17 @@
+namespace Demo;
+
+public class Sample
+{
+    public string Describe(string s)
+    {
+        if (!int.TryParse(s, out var q)) return "";
+        var r = $"{q}";
+        return r;
+    }
+
+    public string Format(string s)
+    {
+        int.TryParse(s, out var amount);
+        return $"Total: {amount:N2} ({$"{amount,8}"})";
+    }
+}
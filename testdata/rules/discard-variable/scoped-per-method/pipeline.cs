@@ -0,0 +1,15 @@
+namespace Demo;
+
+public class Sample
+{
+    public int Parse(string text)
+    {
+        int.TryParse(text, out var number);
+        return number;
+    }
+
+    public bool IsNumber(string text)
+    {
+        return int.TryParse(text, out _);
+    }
+}
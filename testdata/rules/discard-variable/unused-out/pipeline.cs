@@ -0,0 +1,9 @@
+namespace Demo;
+
+public class Sample
+{
+    public bool IsNumber(string text)
+    {
+        return int.TryParse(text, out _);
+    }
+}
@@ -0,0 +1,16 @@
+namespace Demo;
+
+public class Sample
+{
+    public void Run()
+    {
+        try
+        {
+            Work();
+        }
+        catch (IOException ex) when (IsTransient(ex))
+        {
+            Retry();
+        }
+    }
+}
@@ -0,0 +1,17 @@
+namespace Demo;
+
+public class Sample
+{
+    public void Run()
+    {
+        try
+        {
+            Work();
+        }
+        catch (IOException ex)
+        {
+            if (!IsTransient(ex)) throw;
+            Retry();
+        }
+    }
+}
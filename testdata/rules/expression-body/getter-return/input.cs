@@ -0,0 +1,11 @@
+namespace Demo;
+
+public class Sample
+{
+    private int _count;
+
+    public int Count
+    {
+        get { return _count; }
+    }
+}
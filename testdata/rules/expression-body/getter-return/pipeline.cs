@@ -0,0 +1,8 @@
+namespace Demo;
+
+public class Sample
+{
+    private int _count;
+
+    public int Count => _count;
+}
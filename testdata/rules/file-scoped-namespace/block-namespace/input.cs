@@ -0,0 +1,11 @@
+using System;
+
+namespace Demo.Services
+{
+    public class Sample
+    {
+        public void Run()
+        {
+        }
+    }
+}
@@ -0,0 +1,10 @@
+using System;
+
+namespace Demo.Services;
+
+public class Sample
+{
+    public void Run()
+    {
+    }
+}
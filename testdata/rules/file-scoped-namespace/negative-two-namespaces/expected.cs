@@ -0,0 +1,13 @@
+namespace Demo.First
+{
+    public class A
+    {
+    }
+}
+
+namespace Demo.Second
+{
+    public class B
+    {
+    }
+}
@@ -0,0 +1,8 @@
+using System;
+using System.Text;
+
+namespace Demo;
+
+public class Other
+{
+}
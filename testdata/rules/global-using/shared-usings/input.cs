@@ -0,0 +1,9 @@
+using System;
+using System.Text;
+
+namespace Demo;
+
+public class Sample
+{
+    public string Build() => new StringBuilder().ToString();
+}
@@ -0,0 +1,9 @@
+using System;
+using System.Linq;
+using System.Text;
+
+namespace Demo;
+
+public class Sample
+{
+}
@@ -0,0 +1,7 @@
+using System.Text;
+
+namespace Demo;
+
+public class Sample
+{
+}
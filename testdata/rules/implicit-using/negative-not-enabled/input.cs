@@ -0,0 +1,8 @@
+using System;
+using System.Linq;
+
+namespace Demo;
+
+public class Sample
+{
+}
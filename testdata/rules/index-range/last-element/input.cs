@@ -0,0 +1,9 @@
+namespace Demo;
+
+public class Sample
+{
+    public int Last(int[] values)
+    {
+        return values[values.Length - 1];
+    }
+}
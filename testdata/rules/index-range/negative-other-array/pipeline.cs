@@ -0,0 +1,9 @@
+namespace Demo;
+
+public class Sample
+{
+    public int Last(int[] values, int[] other)
+    {
+        return values[other.Length - 1];
+    }
+}
@@ -0,0 +1,17 @@
+namespace Demo;
+
+public class Sample
+{
+    public string Name { get; init; }
+    public int Age { get; set; }
+
+    public Sample(string name)
+    {
+        Name = name;
+    }
+
+    public void Birthday()
+    {
+        Age++;
+    }
+}
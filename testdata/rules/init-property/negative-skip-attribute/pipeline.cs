@@ -0,0 +1,12 @@
+namespace Demo;
+
+public class Sample
+{
+    [JsonProperty]
+    public required string Name { get; set; }
+
+    public Sample(string name)
+    {
+        Name = name;
+    }
+}
@@ -0,0 +1,12 @@
+namespace Demo;
+
+public class Sample
+{
+    [Key]
+    public int Id { get; set; }
+
+    public Sample(int id)
+    {
+        Id = id;
+    }
+}
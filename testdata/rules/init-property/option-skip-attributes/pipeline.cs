@@ -0,0 +1,12 @@
+namespace Demo;
+
+public record Sample
+{
+    [Key]
+    public int Id { get; init; }
+
+    public Sample(int id)
+    {
+        Id = id;
+    }
+}
@@ -0,0 +1,9 @@
+namespace Demo;
+
+public class Sample
+{
+    public bool HasItems(IEnumerable<int> items)
+    {
+        return items.Any();
+    }
+}
@@ -0,0 +1,9 @@
+namespace Demo;
+
+public class Sample
+{
+    public bool IsEmpty(List<int> items)
+    {
+        return items is [];
+    }
+}
@@ -0,0 +1,9 @@
+namespace Demo;
+
+public class Sample
+{
+    public string Find(List<User> users)
+    {
+        return users.Select(u => u.Name).FirstOrDefault();
+    }
+}
@@ -0,0 +1,9 @@
+namespace Demo;
+
+public class Sample
+{
+    public User Find(List<User> users)
+    {
+        return users.Where(u => u.Active).FirstOrDefault();
+    }
+}
@@ -0,0 +1,9 @@
+namespace Demo;
+
+public class Sample
+{
+    public bool IsEmpty(int[] values)
+    {
+        return values.Length == 0;
+    }
+}
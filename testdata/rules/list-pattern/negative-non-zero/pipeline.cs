@@ -0,0 +1,9 @@
+namespace Demo;
+
+public class Sample
+{
+    public bool IsSingle(int[] values)
+    {
+        return values.Length == 1;
+    }
+}
@@ -0,0 +1,9 @@
+namespace Demo;
+
+public class Sample
+{
+    public void Save(object item)
+    {
+        if (item == null) throw new ArgumentNullException(nameof(item));
+    }
+}
@@ -0,0 +1,10 @@
+namespace Demo;
+
+public class Sample
+{
+    public string Name(string name)
+    {
+        name ??= "unknown";
+        return name;
+    }
+}
@@ -0,0 +1,10 @@
+namespace Demo;
+
+public class Sample
+{
+    public string Name(string name)
+    {
+        if (name is null) name = "unknown"; else name = name.Trim();
+        return name;
+    }
+}
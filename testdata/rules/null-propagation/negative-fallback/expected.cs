@@ -0,0 +1,9 @@
+namespace Demo;
+
+public class Sample
+{
+    public string Name(User user)
+    {
+        return user != null ? user.Name : "";
+    }
+}
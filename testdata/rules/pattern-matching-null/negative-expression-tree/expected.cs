@@ -0,0 +1,9 @@
+namespace Demo;
+
+public class Sample
+{
+    public IQueryable<User> Active(IQueryable<User> users)
+    {
+        return users.Where(u => u.DeletedAt == null);
+    }
+}
@@ -0,0 +1,10 @@
+namespace Demo;
+
+public class Sample
+{
+    public void Handle(string value)
+    {
+        if (value is null) return;
+        if (value is not null) Console.WriteLine(value);
+    }
+}
@@ -0,0 +1,10 @@
+namespace Demo;
+
+public class Sample
+{
+    public void Handle(string value)
+    {
+        if (value == null) return;
+        if (value != null) Console.WriteLine(value);
+    }
+}
@@ -0,0 +1,12 @@
+namespace Demo;
+
+public class Sample
+{
+    public void Handle(object value)
+    {
+        if (value is string text)
+        {
+            Console.WriteLine(text);
+        }
+    }
+}
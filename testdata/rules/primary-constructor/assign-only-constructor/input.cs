@@ -0,0 +1,11 @@
+public class Greeter
+{
+    private readonly ILogger _logger;
+
+    public Greeter(ILogger logger)
+    {
+        _logger = logger;
+    }
+
+    public void Greet() => _logger.Log("hi");
+}
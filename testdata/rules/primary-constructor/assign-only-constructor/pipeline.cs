@@ -0,0 +1,6 @@
+public class Greeter(ILogger logger)
+{
+    private readonly ILogger _logger = logger;
+
+    public void Greet() => _logger.Log("hi");
+}
@@ -0,0 +1,10 @@
+public class Greeter
+{
+    private readonly ILogger _logger;
+
+    public Greeter(ILogger logger)
+    {
+        _logger = logger;
+        _logger.Log("created");
+    }
+}
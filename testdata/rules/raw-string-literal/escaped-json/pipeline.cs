@@ -0,0 +1,6 @@
+namespace Demo;
+
+public class Sample
+{
+    public string Json => """{"name": "sharpify", "kind": "tool"}""";
+}
@@ -0,0 +1,6 @@
+namespace Demo;
+
+public class Sample
+{
+    public string Row => "a\t\"b\"\t\"c\"";
+}
@@ -0,0 +1,15 @@
+public class Point
+{
+    /// <summary>Creates a point.</summary>
+    public Point(int x, int y)
+    {
+        X = x;
+        Y = y;
+    }
+
+    /// <summary>The horizontal coordinate.</summary>
+    public int X { get; }
+
+    /// <summary>The vertical coordinate.</summary>
+    public int Y { get; }
+}
@@ -0,0 +1,5 @@
+public class Point
+{
+    public int X { get; init; }
+    public int Y { get; init; }
+}
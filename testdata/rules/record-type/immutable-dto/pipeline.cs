@@ -0,0 +1,5 @@
+public record Point
+{
+    public int X { get; init; }
+    public int Y { get; init; }
+}
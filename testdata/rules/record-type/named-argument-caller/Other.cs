@@ -0,0 +1,4 @@
+public static class Origin
+{
+    public static Point Value = new Point(x: 0, y: 0);
+}
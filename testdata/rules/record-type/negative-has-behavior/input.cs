@@ -0,0 +1,9 @@
+public class Counter
+{
+    public int Value { get; private set; }
+
+    public void Increment()
+    {
+        Value++;
+    }
+}
@@ -0,0 +1,15 @@
+public class Point
+{
+    public Point(int x, int y)
+    {
+        X = x;
+        Y = y;
+    }
+
+    public int X { get; }
+    public int Y { get; }
+
+    public override bool Equals(object? obj) => obj is Point p && X == p.X;
+
+    public override int GetHashCode() => X.GetHashCode();
+}
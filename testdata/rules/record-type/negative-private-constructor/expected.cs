@@ -0,0 +1,13 @@
+public class Money
+{
+    private Money(decimal amount, string currency)
+    {
+        Amount = amount;
+        Currency = currency;
+    }
+
+    public decimal Amount { get; }
+    public string Currency { get; }
+
+    public static Money Euros(decimal amount) => new Money(amount, "EUR");
+}
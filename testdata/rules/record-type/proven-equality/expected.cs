@@ -0,0 +1 @@
+public record Point(int X, int Y);
@@ -0,0 +1,26 @@
+public class Point : IEquatable<Point>
+{
+    public Point(int x, int y)
+    {
+        X = x;
+        Y = y;
+    }
+
+    public int X { get; }
+    public int Y { get; }
+
+    public bool Equals(Point? other)
+    {
+        if (other is null) return false;
+        if (ReferenceEquals(this, other)) return true;
+        return X == other.X && this.Y.Equals(other.Y);
+    }
+
+    public override bool Equals(object? obj) => Equals(obj as Point);
+
+    public override int GetHashCode() => HashCode.Combine(X, Y);
+
+    public static bool operator ==(Point? left, Point? right) => Equals(left, right);
+
+    public static bool operator !=(Point? left, Point? right) => !(left == right);
+}
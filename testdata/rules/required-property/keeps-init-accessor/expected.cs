@@ -0,0 +1,7 @@
+namespace Demo;
+
+public class Sample
+{
+    public required string Name { get; init; }
+    public required string Email { get; set; }
+}
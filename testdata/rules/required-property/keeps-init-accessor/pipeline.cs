@@ -0,0 +1,7 @@
+namespace Demo;
+
+public record Sample
+{
+    public required string Name { get; init; }
+    public required string Email { get; init; }
+}
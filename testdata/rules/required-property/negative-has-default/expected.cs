@@ -0,0 +1,7 @@
+namespace Demo;
+
+public class Sample
+{
+    public string Name { get; set; } = "";
+    public string? Nickname { get; set; }
+}
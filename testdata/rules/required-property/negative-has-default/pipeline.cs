@@ -0,0 +1,7 @@
+namespace Demo;
+
+public record Sample
+{
+    public string Name { get; init; } = "";
+    public string? Nickname { get; init; }
+}
@@ -0,0 +1,6 @@
+namespace Demo;
+
+public record Sample
+{
+    public required string Name { get; init; }
+}
@@ -0,0 +1,9 @@
+namespace Demo;
+
+public class Sample
+{
+    public bool Same(string a, string b)
+    {
+        return a.ToLower() == b.ToLower();
+    }
+}
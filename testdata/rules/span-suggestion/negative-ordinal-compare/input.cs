@@ -0,0 +1,9 @@
+namespace Demo;
+
+public class Sample
+{
+    public bool Same(string a, string b)
+    {
+        return string.Equals(a, b, StringComparison.OrdinalIgnoreCase);
+    }
+}
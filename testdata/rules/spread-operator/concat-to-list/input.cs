@@ -0,0 +1,9 @@
+namespace Demo;
+
+public class Sample
+{
+    public List<int> Merge(List<int> first, List<int> second)
+    {
+        return first.Concat(second).ToList();
+    }
+}
@@ -0,0 +1,9 @@
+namespace Demo;
+
+public class Sample
+{
+    public List<int> Merge(List<int> first)
+    {
+        return first.Concat(Load()).ToList();
+    }
+}
@@ -0,0 +1,10 @@
+namespace Demo;
+
+public class Sample
+{
+    public Stopwatch Create()
+    {
+        var watch = new Stopwatch();
+        return watch;
+    }
+}
@@ -0,0 +1,11 @@
+namespace Demo;
+
+public class Sample
+{
+    public long Time()
+    {
+        var watch = Stopwatch.StartNew();
+        Work();
+        return watch.ElapsedMilliseconds;
+    }
+}
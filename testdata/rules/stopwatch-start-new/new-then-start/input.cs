@@ -0,0 +1,12 @@
+namespace Demo;
+
+public class Sample
+{
+    public long Time()
+    {
+        var watch = new Stopwatch();
+        watch.Start();
+        Work();
+        return watch.ElapsedMilliseconds;
+    }
+}
@@ -0,0 +1,9 @@
+namespace Demo;
+
+public class Sample
+{
+    public string Greet(string name)
+    {
+        return $"Hello {name}" + "!";
+    }
+}
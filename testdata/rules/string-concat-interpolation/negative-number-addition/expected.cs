@@ -0,0 +1,9 @@
+namespace Demo;
+
+public class Sample
+{
+    public int Sum(int a, int b)
+    {
+        return a + b;
+    }
+}
@@ -0,0 +1,9 @@
+namespace Demo;
+
+public class Sample
+{
+    public string Price(decimal amount)
+    {
+        return amount.ToString("C");
+    }
+}
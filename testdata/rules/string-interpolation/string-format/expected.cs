@@ -0,0 +1,9 @@
+namespace Demo;
+
+public class Sample
+{
+    public string Greet(string name, int count)
+    {
+        return $"Hello {name}, you have {count} messages";
+    }
+}
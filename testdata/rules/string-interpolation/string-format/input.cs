@@ -0,0 +1,9 @@
+namespace Demo;
+
+public class Sample
+{
+    public string Greet(string name, int count)
+    {
+        return string.Format("Hello {0}, you have {1} messages", name, count);
+    }
+}
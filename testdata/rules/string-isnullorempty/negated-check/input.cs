@@ -0,0 +1,9 @@
+namespace Demo;
+
+public class Sample
+{
+    public bool HasName(string name)
+    {
+        return !string.IsNullOrEmpty(name);
+    }
+}
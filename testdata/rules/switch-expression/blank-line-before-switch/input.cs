@@ -0,0 +1,17 @@
+namespace Demo;
+
+public class Sample
+{
+    public string Describe(int code)
+    {
+        Console.WriteLine(code);
+
+        switch (code)
+        {
+            case 1:
+                return "one";
+            default:
+                return "many";
+        }
+    }
+}
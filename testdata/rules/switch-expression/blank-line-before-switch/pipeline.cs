@@ -0,0 +1,15 @@
+namespace Demo;
+
+public class Sample
+{
+    public string Describe(int code)
+    {
+        Console.WriteLine(code);
+
+        return code switch
+        {
+            1 => "one",
+            _ => "many"
+        };
+    }
+}
@@ -0,0 +1,19 @@
+namespace Demo;
+
+public class Sample
+{
+    public string Describe(int code)
+    {
+        switch (code)
+        {
+            default:
+                return "many";
+            case 1:
+            {
+                return "one";
+            }
+            case 2:
+                return "two";
+        }
+    }
+}
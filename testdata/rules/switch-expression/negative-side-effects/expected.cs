@@ -0,0 +1,16 @@
+namespace Demo;
+
+public class Sample
+{
+    public string Describe(int code)
+    {
+        switch (code)
+        {
+            case 1:
+                Console.WriteLine("one");
+                return "one";
+            default:
+                return "many";
+        }
+    }
+}
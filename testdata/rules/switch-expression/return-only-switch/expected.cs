@@ -0,0 +1,14 @@
+namespace Demo;
+
+public class Sample
+{
+    public string Describe(int code)
+    {
+        return code switch
+        {
+            1 => "one",
+            2 => "two",
+            _ => "many"
+        };
+    }
+}
@@ -0,0 +1,17 @@
+namespace Demo;
+
+public class Sample
+{
+    public string Describe(int code)
+    {
+        switch (code)
+        {
+            case 1:
+                return "one";
+            case 2:
+                return "two";
+            default:
+                return "many";
+        }
+    }
+}
@@ -0,0 +1,7 @@
+namespace Demo;
+
+public class Sample
+{
+    private readonly Dictionary<string, int> _counts = new Dictionary<string, int>();
+    private static StringBuilder _builder = new StringBuilder(16);
+}
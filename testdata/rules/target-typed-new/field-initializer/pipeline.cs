@@ -0,0 +1,7 @@
+namespace Demo;
+
+public class Sample
+{
+    private readonly Dictionary<string, int> _counts = new();
+    private static StringBuilder _builder = new(16);
+}
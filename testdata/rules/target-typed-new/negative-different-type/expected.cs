@@ -0,0 +1,6 @@
+namespace Demo;
+
+public class Sample
+{
+    private readonly IList<int> _items = new List<int>();
+}
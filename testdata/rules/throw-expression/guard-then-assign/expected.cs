@@ -0,0 +1,11 @@
+namespace Demo;
+
+public class Sample
+{
+    private readonly ILogger _logger;
+
+    public Sample(ILogger logger)
+    {
+        _logger = logger ?? throw new ArgumentNullException(nameof(logger));
+    }
+}
@@ -0,0 +1,12 @@
+namespace Demo;
+
+public class Sample
+{
+    private readonly ILogger _logger;
+
+    public Sample(ILogger logger, ILogger fallback)
+    {
+        ArgumentNullException.ThrowIfNull(logger);
+        _logger = fallback;
+    }
+}
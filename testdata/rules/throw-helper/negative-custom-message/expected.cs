@@ -0,0 +1,11 @@
+namespace Demo;
+
+public class Sample
+{
+    public void Save(object item)
+    {
+        if (item == null)
+            throw new InvalidOperationException("missing item");
+        Console.WriteLine(item);
+    }
+}
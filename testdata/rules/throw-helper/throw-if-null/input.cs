@@ -0,0 +1,11 @@
+namespace Demo;
+
+public class Sample
+{
+    public void Save(object item)
+    {
+        if (item == null)
+            throw new ArgumentNullException(nameof(item));
+        Console.WriteLine(item);
+    }
+}
@@ -0,0 +1,10 @@
+namespace Demo;
+
+public class Sample
+{
+    public void Save(object item)
+    {
+        ArgumentNullException.ThrowIfNull(item);
+        Console.WriteLine(item);
+    }
+}
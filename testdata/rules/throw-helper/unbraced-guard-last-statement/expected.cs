@@ -0,0 +1,15 @@
+namespace Demo;
+
+public class Sample
+{
+    public void Check(object item)
+    {
+        ArgumentNullException.ThrowIfNull(item);
+    }
+
+    public void Save(object item)
+    {
+        ArgumentNullException.ThrowIfNull(item);
+        Console.WriteLine(item);
+    }
+}
@@ -0,0 +1,16 @@
+namespace Demo;
+
+public class Sample
+{
+    public void Check(object item)
+    {
+        if (item is null)
+            throw new ArgumentNullException(nameof(item));
+    }
+
+    public void Save(object item)
+    {
+        if (item == null) { throw new ArgumentNullException(nameof(item)); }
+        Console.WriteLine(item);
+    }
+}
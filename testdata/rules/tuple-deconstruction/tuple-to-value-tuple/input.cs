@@ -0,0 +1,9 @@
+namespace Demo;
+
+public class Sample
+{
+    public Tuple<int, string> Pair()
+    {
+        return new Tuple<int, string>(1, "one");
+    }
+}
@@ -0,0 +1,9 @@
+namespace Demo;
+
+public class Sample
+{
+    public (int, string) Pair()
+    {
+        return (1, "one");
+    }
+}
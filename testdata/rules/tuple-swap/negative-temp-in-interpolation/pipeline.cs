@@ -0,0 +1,12 @@
+namespace Demo;
+
+public class Sample
+{
+    public void Swap(int a, int b)
+    {
+        var t = a;
+        a = b;
+        b = t;
+        Console.WriteLine($"{t}");
+    }
+}
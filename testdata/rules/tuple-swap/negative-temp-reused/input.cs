@@ -0,0 +1,12 @@
+namespace Demo;
+
+public class Sample
+{
+    public void Swap(int a, int b)
+    {
+        var temp = a;
+        a = b;
+        b = temp;
+        Console.WriteLine(temp);
+    }
+}
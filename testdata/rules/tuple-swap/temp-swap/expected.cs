@@ -0,0 +1,10 @@
+namespace Demo;
+
+public class Sample
+{
+    public void Swap(int a, int b)
+    {
+        (a, b) = (b, a);
+        Console.WriteLine(a + b);
+    }
+}
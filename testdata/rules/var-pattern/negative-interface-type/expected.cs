@@ -0,0 +1,10 @@
+namespace Demo;
+
+public class Sample
+{
+    public void Build()
+    {
+        IList<int> items = new List<int>();
+        items.Add(1);
+    }
+}
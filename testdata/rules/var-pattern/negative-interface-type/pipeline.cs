@@ -0,0 +1,10 @@
+namespace Demo;
+
+public class Sample
+{
+    public void Build()
+    {
+        IList<int> items = [];
+        items.Add(1);
+    }
+}
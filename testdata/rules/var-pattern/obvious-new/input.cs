@@ -0,0 +1,10 @@
+namespace Demo;
+
+public class Sample
+{
+    public void Build()
+    {
+        StringBuilder builder = new StringBuilder();
+        builder.Append("x");
+    }
+}